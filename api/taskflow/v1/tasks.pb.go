@@ -0,0 +1,1733 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: taskflow/v1/tasks.proto
+
+package taskflowv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_MEDIUM      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_MEDIUM",
+		3: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_MEDIUM":      2,
+		"PRIORITY_HIGH":        3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_taskflow_v1_tasks_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_taskflow_v1_tasks_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+type Category int32
+
+const (
+	Category_CATEGORY_UNSPECIFIED Category = 0
+	Category_CATEGORY_PERSONAL    Category = 1
+	Category_CATEGORY_WORK        Category = 2
+	Category_CATEGORY_URGENT      Category = 3
+)
+
+// Enum value maps for Category.
+var (
+	Category_name = map[int32]string{
+		0: "CATEGORY_UNSPECIFIED",
+		1: "CATEGORY_PERSONAL",
+		2: "CATEGORY_WORK",
+		3: "CATEGORY_URGENT",
+	}
+	Category_value = map[string]int32{
+		"CATEGORY_UNSPECIFIED": 0,
+		"CATEGORY_PERSONAL":    1,
+		"CATEGORY_WORK":        2,
+		"CATEGORY_URGENT":      3,
+	}
+)
+
+func (x Category) Enum() *Category {
+	p := new(Category)
+	*p = x
+	return p
+}
+
+func (x Category) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Category) Descriptor() protoreflect.EnumDescriptor {
+	return file_taskflow_v1_tasks_proto_enumTypes[1].Descriptor()
+}
+
+func (Category) Type() protoreflect.EnumType {
+	return &file_taskflow_v1_tasks_proto_enumTypes[1]
+}
+
+func (x Category) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Category.Descriptor instead.
+func (Category) EnumDescriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskEventType int32
+
+const (
+	TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED TaskEventType = 0
+	TaskEventType_TASK_EVENT_TYPE_CREATED     TaskEventType = 1
+	TaskEventType_TASK_EVENT_TYPE_UPDATED     TaskEventType = 2
+	// The task is the last version before deletion.
+	TaskEventType_TASK_EVENT_TYPE_DELETED TaskEventType = 3
+)
+
+// Enum value maps for TaskEventType.
+var (
+	TaskEventType_name = map[int32]string{
+		0: "TASK_EVENT_TYPE_UNSPECIFIED",
+		1: "TASK_EVENT_TYPE_CREATED",
+		2: "TASK_EVENT_TYPE_UPDATED",
+		3: "TASK_EVENT_TYPE_DELETED",
+	}
+	TaskEventType_value = map[string]int32{
+		"TASK_EVENT_TYPE_UNSPECIFIED": 0,
+		"TASK_EVENT_TYPE_CREATED":     1,
+		"TASK_EVENT_TYPE_UPDATED":     2,
+		"TASK_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x TaskEventType) Enum() *TaskEventType {
+	p := new(TaskEventType)
+	*p = x
+	return p
+}
+
+func (x TaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_taskflow_v1_tasks_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskEventType) Type() protoreflect.EnumType {
+	return &file_taskflow_v1_tasks_proto_enumTypes[2]
+}
+
+func (x TaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEventType.Descriptor instead.
+func (TaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WorkspaceId *int64                 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
+	ProjectId   *int64                 `protobuf:"varint,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	AssigneeId  *int64                 `protobuf:"varint,5,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	Title       string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Description *string                `protobuf:"bytes,7,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Priority    Priority               `protobuf:"varint,8,opt,name=priority,proto3,enum=taskflow.v1.Priority" json:"priority,omitempty"`
+	Category    Category               `protobuf:"varint,9,opt,name=category,proto3,enum=taskflow.v1.Category" json:"category,omitempty"`
+	// Workflow status key of the task's board.
+	Status      string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Position    string                 `protobuf:"bytes,11,opt,name=position,proto3" json:"position,omitempty"`
+	IsCompleted bool                   `protobuf:"varint,12,opt,name=is_completed,json=isCompleted,proto3" json:"is_completed,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Incremented on every change. Pass it as expected_version to avoid lost updates.
+	Version       int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Task) GetWorkspaceId() int64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *Task) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetAssigneeId() int64 {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_UNSPECIFIED
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetPosition() string {
+	if x != nil {
+		return x.Position
+	}
+	return ""
+}
+
+func (x *Task) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *Task) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// TaskFilter holds the query string filters of GET /tasks.
+type TaskFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Workspace ID, or "personal" for tasks outside any workspace.
+	WorkspaceId string `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// User ID, "me" or "none".
+	Assignee string `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// User ID or "me".
+	CreatedBy   string   `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ProjectId   *int64   `protobuf:"varint,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Priority    Priority `protobuf:"varint,5,opt,name=priority,proto3,enum=taskflow.v1.Priority" json:"priority,omitempty"`
+	Category    Category `protobuf:"varint,6,opt,name=category,proto3,enum=taskflow.v1.Category" json:"category,omitempty"`
+	Status      string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	IsCompleted *bool    `protobuf:"varint,8,opt,name=is_completed,json=isCompleted,proto3,oneof" json:"is_completed,omitempty"`
+	// Searches title and description.
+	Search          string `protobuf:"bytes,9,opt,name=search,proto3" json:"search,omitempty"`
+	IncludeArchived bool   `protobuf:"varint,10,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFilter) ProtoMessage() {}
+
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskFilter) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *TaskFilter) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *TaskFilter) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_UNSPECIFIED
+}
+
+func (x *TaskFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetIsCompleted() bool {
+	if x != nil && x.IsCompleted != nil {
+		return *x.IsCompleted
+	}
+	return false
+}
+
+func (x *TaskFilter) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type CreateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	WorkspaceId *int64                 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3,oneof" json:"workspace_id,omitempty"`
+	ProjectId   *int64                 `protobuf:"varint,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	AssigneeId  *int64                 `protobuf:"varint,5,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	// Defaults to medium.
+	Priority Priority `protobuf:"varint,6,opt,name=priority,proto3,enum=taskflow.v1.Priority" json:"priority,omitempty"`
+	// Defaults to personal.
+	Category Category `protobuf:"varint,7,opt,name=category,proto3,enum=taskflow.v1.Category" json:"category,omitempty"`
+	// Defaults to the first open status, or the first terminal one when is_completed is set.
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	IsCompleted   *bool                  `protobuf:"varint,9,opt,name=is_completed,json=isCompleted,proto3,oneof" json:"is_completed,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetWorkspaceId() int64 {
+	if x != nil && x.WorkspaceId != nil {
+		return *x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetAssigneeId() int64 {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetIsCompleted() bool {
+	if x != nil && x.IsCompleted != nil {
+		return *x.IsCompleted
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTasksRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// TaskInput holds the editable fields of a task.
+type TaskInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	ProjectId     *int64                 `protobuf:"varint,3,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	AssigneeId    *int64                 `protobuf:"varint,4,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	Priority      Priority               `protobuf:"varint,5,opt,name=priority,proto3,enum=taskflow.v1.Priority" json:"priority,omitempty"`
+	Category      Category               `protobuf:"varint,6,opt,name=category,proto3,enum=taskflow.v1.Category" json:"category,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	IsCompleted   *bool                  `protobuf:"varint,8,opt,name=is_completed,json=isCompleted,proto3,oneof" json:"is_completed,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskInput) Reset() {
+	*x = TaskInput{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInput) ProtoMessage() {}
+
+func (x *TaskInput) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInput.ProtoReflect.Descriptor instead.
+func (*TaskInput) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TaskInput) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskInput) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *TaskInput) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *TaskInput) GetAssigneeId() int64 {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return 0
+}
+
+func (x *TaskInput) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *TaskInput) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_UNSPECIFIED
+}
+
+func (x *TaskInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskInput) GetIsCompleted() bool {
+	if x != nil && x.IsCompleted != nil {
+		return *x.IsCompleted
+	}
+	return false
+}
+
+func (x *TaskInput) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Task  *TaskInput             `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Paths are TaskInput field names. A listed field that is unset in task is cleared.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Fails with ABORTED when the task's version differs, like If-Match.
+	ExpectedVersion *int64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetTask() *TaskInput {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ToggleCompleteRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ToggleCompleteRequest) Reset() {
+	*x = ToggleCompleteRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleCompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleCompleteRequest) ProtoMessage() {}
+
+func (x *ToggleCompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleCompleteRequest.ProtoReflect.Descriptor instead.
+func (*ToggleCompleteRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ToggleCompleteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ToggleCompleteRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type MoveTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Target status key.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AfterId       *int64 `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3,oneof" json:"after_id,omitempty"`
+	BeforeId      *int64 `protobuf:"varint,4,opt,name=before_id,json=beforeId,proto3,oneof" json:"before_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetAfterId() int64 {
+	if x != nil && x.AfterId != nil {
+		return *x.AfterId
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetBeforeId() int64 {
+	if x != nil && x.BeforeId != nil {
+		return *x.BeforeId
+	}
+	return 0
+}
+
+type AssignTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unset to unassign.
+	AssigneeId      *int64 `protobuf:"varint,2,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	ExpectedVersion *int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AssignTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetAssigneeId() int64 {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetStatsRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type TaskStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int64                  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Pending       int64                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	HighPriority  int64                  `protobuf:"varint,4,opt,name=high_priority,json=highPriority,proto3" json:"high_priority,omitempty"`
+	Overdue       int64                  `protobuf:"varint,5,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	ByAssignee    []*AssigneeStats       `protobuf:"bytes,6,rep,name=by_assignee,json=byAssignee,proto3" json:"by_assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskStats) Reset() {
+	*x = TaskStats{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStats) ProtoMessage() {}
+
+func (x *TaskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStats.ProtoReflect.Descriptor instead.
+func (*TaskStats) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskStats) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TaskStats) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *TaskStats) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *TaskStats) GetHighPriority() int64 {
+	if x != nil {
+		return x.HighPriority
+	}
+	return 0
+}
+
+func (x *TaskStats) GetOverdue() int64 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+func (x *TaskStats) GetByAssignee() []*AssigneeStats {
+	if x != nil {
+		return x.ByAssignee
+	}
+	return nil
+}
+
+type AssigneeStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset for unassigned tasks.
+	AssigneeId    *int64 `protobuf:"varint,1,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Total         int64  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int64  `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	Pending       int64  `protobuf:"varint,5,opt,name=pending,proto3" json:"pending,omitempty"`
+	Overdue       int64  `protobuf:"varint,6,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssigneeStats) Reset() {
+	*x = AssigneeStats{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssigneeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssigneeStats) ProtoMessage() {}
+
+func (x *AssigneeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssigneeStats.ProtoReflect.Descriptor instead.
+func (*AssigneeStats) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AssigneeStats) GetAssigneeId() int64 {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return 0
+}
+
+func (x *AssigneeStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssigneeStats) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AssigneeStats) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *AssigneeStats) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *AssigneeStats) GetOverdue() int64 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only tasks in this workspace, or "personal". Empty for all.
+	WorkspaceId string `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// Resume after this event ID, like Last-Event-ID. Fails with OUT_OF_RANGE
+	// when those events were already pruned; reload the tasks and watch again.
+	AfterEventId  int64 `protobuf:"varint,2,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchTasksRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *WatchTasksRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+type TaskEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          TaskEventType          `protobuf:"varint,2,opt,name=type,proto3,enum=taskflow.v1.TaskEventType" json:"type,omitempty"`
+	ActorId       int64                  `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Task          *Task                  `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_taskflow_v1_tasks_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_taskflow_v1_tasks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_taskflow_v1_tasks_proto protoreflect.FileDescriptor
+
+const file_taskflow_v1_tasks_proto_rawDesc = "" +
+	"\n" +
+	"\x17taskflow/v1/tasks.proto\x12\vtaskflow.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa2\x05\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x03H\x00R\vworkspaceId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\x03H\x01R\tprojectId\x88\x01\x01\x12$\n" +
+	"\vassignee_id\x18\x05 \x01(\x03H\x02R\n" +
+	"assigneeId\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\a \x01(\tH\x03R\vdescription\x88\x01\x01\x121\n" +
+	"\bpriority\x18\b \x01(\x0e2\x15.taskflow.v1.PriorityR\bpriority\x121\n" +
+	"\bcategory\x18\t \x01(\x0e2\x15.taskflow.v1.CategoryR\bcategory\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1a\n" +
+	"\bposition\x18\v \x01(\tR\bposition\x12!\n" +
+	"\fis_completed\x18\f \x01(\bR\visCompleted\x125\n" +
+	"\bdue_date\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x18\n" +
+	"\aversion\x18\x0e \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0f\n" +
+	"\r_workspace_idB\r\n" +
+	"\v_project_idB\x0e\n" +
+	"\f_assignee_idB\x0e\n" +
+	"\f_description\"\x97\x03\n" +
+	"\n" +
+	"TaskFilter\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\x03H\x00R\tprojectId\x88\x01\x01\x121\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x15.taskflow.v1.PriorityR\bpriority\x121\n" +
+	"\bcategory\x18\x06 \x01(\x0e2\x15.taskflow.v1.CategoryR\bcategory\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12&\n" +
+	"\fis_completed\x18\b \x01(\bH\x01R\visCompleted\x88\x01\x01\x12\x16\n" +
+	"\x06search\x18\t \x01(\tR\x06search\x12)\n" +
+	"\x10include_archived\x18\n" +
+	" \x01(\bR\x0fincludeArchivedB\r\n" +
+	"\v_project_idB\x0f\n" +
+	"\r_is_completed\"\xf0\x03\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12&\n" +
+	"\fworkspace_id\x18\x03 \x01(\x03H\x01R\vworkspaceId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\x03H\x02R\tprojectId\x88\x01\x01\x12$\n" +
+	"\vassignee_id\x18\x05 \x01(\x03H\x03R\n" +
+	"assigneeId\x88\x01\x01\x121\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x15.taskflow.v1.PriorityR\bpriority\x121\n" +
+	"\bcategory\x18\a \x01(\x0e2\x15.taskflow.v1.CategoryR\bcategory\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12&\n" +
+	"\fis_completed\x18\t \x01(\bH\x04R\visCompleted\x88\x01\x01\x125\n" +
+	"\bdue_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\adueDateB\x0e\n" +
+	"\f_descriptionB\x0f\n" +
+	"\r_workspace_idB\r\n" +
+	"\v_project_idB\x0e\n" +
+	"\f_assignee_idB\x0f\n" +
+	"\r_is_completed\"C\n" +
+	"\x10ListTasksRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.taskflow.v1.TaskFilterR\x06filter\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xaf\x03\n" +
+	"\tTaskInput\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\x03H\x01R\tprojectId\x88\x01\x01\x12$\n" +
+	"\vassignee_id\x18\x04 \x01(\x03H\x02R\n" +
+	"assigneeId\x88\x01\x01\x121\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x15.taskflow.v1.PriorityR\bpriority\x121\n" +
+	"\bcategory\x18\x06 \x01(\x0e2\x15.taskflow.v1.CategoryR\bcategory\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12&\n" +
+	"\fis_completed\x18\b \x01(\bH\x03R\visCompleted\x88\x01\x01\x125\n" +
+	"\bdue_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\adueDateB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_project_idB\x0e\n" +
+	"\f_assignee_idB\x0f\n" +
+	"\r_is_completed\"\xd1\x01\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12*\n" +
+	"\x04task\x18\x02 \x01(\v2\x16.taskflow.v1.TaskInputR\x04task\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"h\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"l\n" +
+	"\x15ToggleCompleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x96\x01\n" +
+	"\x0fMoveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1e\n" +
+	"\bafter_id\x18\x03 \x01(\x03H\x00R\aafterId\x88\x01\x01\x12 \n" +
+	"\tbefore_id\x18\x04 \x01(\x03H\x01R\bbeforeId\x88\x01\x01B\v\n" +
+	"\t_after_idB\f\n" +
+	"\n" +
+	"_before_id\"\x9e\x01\n" +
+	"\x11AssignTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12$\n" +
+	"\vassignee_id\x18\x02 \x01(\x03H\x00R\n" +
+	"assigneeId\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x01R\x0fexpectedVersion\x88\x01\x01B\x0e\n" +
+	"\f_assignee_idB\x13\n" +
+	"\x11_expected_version\"B\n" +
+	"\x0fGetStatsRequest\x12/\n" +
+	"\x06filter\x18\x01 \x01(\v2\x17.taskflow.v1.TaskFilterR\x06filter\"\xd5\x01\n" +
+	"\tTaskStats\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x03R\tcompleted\x12\x18\n" +
+	"\apending\x18\x03 \x01(\x03R\apending\x12#\n" +
+	"\rhigh_priority\x18\x04 \x01(\x03R\fhighPriority\x12\x18\n" +
+	"\aoverdue\x18\x05 \x01(\x03R\aoverdue\x12;\n" +
+	"\vby_assignee\x18\x06 \x03(\v2\x1a.taskflow.v1.AssigneeStatsR\n" +
+	"byAssignee\"\xc1\x01\n" +
+	"\rAssigneeStats\x12$\n" +
+	"\vassignee_id\x18\x01 \x01(\x03H\x00R\n" +
+	"assigneeId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\x03R\tcompleted\x12\x18\n" +
+	"\apending\x18\x05 \x01(\x03R\apending\x12\x18\n" +
+	"\aoverdue\x18\x06 \x01(\x03R\aoverdueB\x0e\n" +
+	"\f_assignee_id\"\\\n" +
+	"\x11WatchTasksRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12$\n" +
+	"\x0eafter_event_id\x18\x02 \x01(\x03R\fafterEventId\"\xca\x01\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1a.taskflow.v1.TaskEventTypeR\x04type\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\x03R\aactorId\x12%\n" +
+	"\x04task\x18\x04 \x01(\v2\x11.taskflow.v1.TaskR\x04task\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*^\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_MEDIUM\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03*c\n" +
+	"\bCategory\x12\x18\n" +
+	"\x14CATEGORY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11CATEGORY_PERSONAL\x10\x01\x12\x11\n" +
+	"\rCATEGORY_WORK\x10\x02\x12\x13\n" +
+	"\x0fCATEGORY_URGENT\x10\x03*\x87\x01\n" +
+	"\rTaskEventType\x12\x1f\n" +
+	"\x1bTASK_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_DELETED\x10\x032\xa2\x05\n" +
+	"\vTaskService\x12?\n" +
+	"\n" +
+	"CreateTask\x12\x1e.taskflow.v1.CreateTaskRequest\x1a\x11.taskflow.v1.Task\x12?\n" +
+	"\tListTasks\x12\x1d.taskflow.v1.ListTasksRequest\x1a\x11.taskflow.v1.Task0\x01\x129\n" +
+	"\aGetTask\x12\x1b.taskflow.v1.GetTaskRequest\x1a\x11.taskflow.v1.Task\x12?\n" +
+	"\n" +
+	"UpdateTask\x12\x1e.taskflow.v1.UpdateTaskRequest\x1a\x11.taskflow.v1.Task\x12D\n" +
+	"\n" +
+	"DeleteTask\x12\x1e.taskflow.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x0eToggleComplete\x12\".taskflow.v1.ToggleCompleteRequest\x1a\x11.taskflow.v1.Task\x12;\n" +
+	"\bMoveTask\x12\x1c.taskflow.v1.MoveTaskRequest\x1a\x11.taskflow.v1.Task\x12?\n" +
+	"\n" +
+	"AssignTask\x12\x1e.taskflow.v1.AssignTaskRequest\x1a\x11.taskflow.v1.Task\x12@\n" +
+	"\bGetStats\x12\x1c.taskflow.v1.GetStatsRequest\x1a\x16.taskflow.v1.TaskStats\x12F\n" +
+	"\n" +
+	"WatchTasks\x12\x1e.taskflow.v1.WatchTasksRequest\x1a\x16.taskflow.v1.TaskEvent0\x01B)Z'taskflow-api/api/taskflow/v1;taskflowv1b\x06proto3"
+
+var (
+	file_taskflow_v1_tasks_proto_rawDescOnce sync.Once
+	file_taskflow_v1_tasks_proto_rawDescData []byte
+)
+
+func file_taskflow_v1_tasks_proto_rawDescGZIP() []byte {
+	file_taskflow_v1_tasks_proto_rawDescOnce.Do(func() {
+		file_taskflow_v1_tasks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taskflow_v1_tasks_proto_rawDesc), len(file_taskflow_v1_tasks_proto_rawDesc)))
+	})
+	return file_taskflow_v1_tasks_proto_rawDescData
+}
+
+var file_taskflow_v1_tasks_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_taskflow_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_taskflow_v1_tasks_proto_goTypes = []any{
+	(Priority)(0),                 // 0: taskflow.v1.Priority
+	(Category)(0),                 // 1: taskflow.v1.Category
+	(TaskEventType)(0),            // 2: taskflow.v1.TaskEventType
+	(*Task)(nil),                  // 3: taskflow.v1.Task
+	(*TaskFilter)(nil),            // 4: taskflow.v1.TaskFilter
+	(*CreateTaskRequest)(nil),     // 5: taskflow.v1.CreateTaskRequest
+	(*ListTasksRequest)(nil),      // 6: taskflow.v1.ListTasksRequest
+	(*GetTaskRequest)(nil),        // 7: taskflow.v1.GetTaskRequest
+	(*TaskInput)(nil),             // 8: taskflow.v1.TaskInput
+	(*UpdateTaskRequest)(nil),     // 9: taskflow.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),     // 10: taskflow.v1.DeleteTaskRequest
+	(*ToggleCompleteRequest)(nil), // 11: taskflow.v1.ToggleCompleteRequest
+	(*MoveTaskRequest)(nil),       // 12: taskflow.v1.MoveTaskRequest
+	(*AssignTaskRequest)(nil),     // 13: taskflow.v1.AssignTaskRequest
+	(*GetStatsRequest)(nil),       // 14: taskflow.v1.GetStatsRequest
+	(*TaskStats)(nil),             // 15: taskflow.v1.TaskStats
+	(*AssigneeStats)(nil),         // 16: taskflow.v1.AssigneeStats
+	(*WatchTasksRequest)(nil),     // 17: taskflow.v1.WatchTasksRequest
+	(*TaskEvent)(nil),             // 18: taskflow.v1.TaskEvent
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 20: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 21: google.protobuf.Empty
+}
+var file_taskflow_v1_tasks_proto_depIdxs = []int32{
+	0,  // 0: taskflow.v1.Task.priority:type_name -> taskflow.v1.Priority
+	1,  // 1: taskflow.v1.Task.category:type_name -> taskflow.v1.Category
+	19, // 2: taskflow.v1.Task.due_date:type_name -> google.protobuf.Timestamp
+	19, // 3: taskflow.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	19, // 4: taskflow.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: taskflow.v1.TaskFilter.priority:type_name -> taskflow.v1.Priority
+	1,  // 6: taskflow.v1.TaskFilter.category:type_name -> taskflow.v1.Category
+	0,  // 7: taskflow.v1.CreateTaskRequest.priority:type_name -> taskflow.v1.Priority
+	1,  // 8: taskflow.v1.CreateTaskRequest.category:type_name -> taskflow.v1.Category
+	19, // 9: taskflow.v1.CreateTaskRequest.due_date:type_name -> google.protobuf.Timestamp
+	4,  // 10: taskflow.v1.ListTasksRequest.filter:type_name -> taskflow.v1.TaskFilter
+	0,  // 11: taskflow.v1.TaskInput.priority:type_name -> taskflow.v1.Priority
+	1,  // 12: taskflow.v1.TaskInput.category:type_name -> taskflow.v1.Category
+	19, // 13: taskflow.v1.TaskInput.due_date:type_name -> google.protobuf.Timestamp
+	8,  // 14: taskflow.v1.UpdateTaskRequest.task:type_name -> taskflow.v1.TaskInput
+	20, // 15: taskflow.v1.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 16: taskflow.v1.GetStatsRequest.filter:type_name -> taskflow.v1.TaskFilter
+	16, // 17: taskflow.v1.TaskStats.by_assignee:type_name -> taskflow.v1.AssigneeStats
+	2,  // 18: taskflow.v1.TaskEvent.type:type_name -> taskflow.v1.TaskEventType
+	3,  // 19: taskflow.v1.TaskEvent.task:type_name -> taskflow.v1.Task
+	19, // 20: taskflow.v1.TaskEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	5,  // 21: taskflow.v1.TaskService.CreateTask:input_type -> taskflow.v1.CreateTaskRequest
+	6,  // 22: taskflow.v1.TaskService.ListTasks:input_type -> taskflow.v1.ListTasksRequest
+	7,  // 23: taskflow.v1.TaskService.GetTask:input_type -> taskflow.v1.GetTaskRequest
+	9,  // 24: taskflow.v1.TaskService.UpdateTask:input_type -> taskflow.v1.UpdateTaskRequest
+	10, // 25: taskflow.v1.TaskService.DeleteTask:input_type -> taskflow.v1.DeleteTaskRequest
+	11, // 26: taskflow.v1.TaskService.ToggleComplete:input_type -> taskflow.v1.ToggleCompleteRequest
+	12, // 27: taskflow.v1.TaskService.MoveTask:input_type -> taskflow.v1.MoveTaskRequest
+	13, // 28: taskflow.v1.TaskService.AssignTask:input_type -> taskflow.v1.AssignTaskRequest
+	14, // 29: taskflow.v1.TaskService.GetStats:input_type -> taskflow.v1.GetStatsRequest
+	17, // 30: taskflow.v1.TaskService.WatchTasks:input_type -> taskflow.v1.WatchTasksRequest
+	3,  // 31: taskflow.v1.TaskService.CreateTask:output_type -> taskflow.v1.Task
+	3,  // 32: taskflow.v1.TaskService.ListTasks:output_type -> taskflow.v1.Task
+	3,  // 33: taskflow.v1.TaskService.GetTask:output_type -> taskflow.v1.Task
+	3,  // 34: taskflow.v1.TaskService.UpdateTask:output_type -> taskflow.v1.Task
+	21, // 35: taskflow.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	3,  // 36: taskflow.v1.TaskService.ToggleComplete:output_type -> taskflow.v1.Task
+	3,  // 37: taskflow.v1.TaskService.MoveTask:output_type -> taskflow.v1.Task
+	3,  // 38: taskflow.v1.TaskService.AssignTask:output_type -> taskflow.v1.Task
+	15, // 39: taskflow.v1.TaskService.GetStats:output_type -> taskflow.v1.TaskStats
+	18, // 40: taskflow.v1.TaskService.WatchTasks:output_type -> taskflow.v1.TaskEvent
+	31, // [31:41] is the sub-list for method output_type
+	21, // [21:31] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_taskflow_v1_tasks_proto_init() }
+func file_taskflow_v1_tasks_proto_init() {
+	if File_taskflow_v1_tasks_proto != nil {
+		return
+	}
+	file_taskflow_v1_tasks_proto_msgTypes[0].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[1].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[2].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[5].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[6].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[7].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[8].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[9].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[10].OneofWrappers = []any{}
+	file_taskflow_v1_tasks_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taskflow_v1_tasks_proto_rawDesc), len(file_taskflow_v1_tasks_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_taskflow_v1_tasks_proto_goTypes,
+		DependencyIndexes: file_taskflow_v1_tasks_proto_depIdxs,
+		EnumInfos:         file_taskflow_v1_tasks_proto_enumTypes,
+		MessageInfos:      file_taskflow_v1_tasks_proto_msgTypes,
+	}.Build()
+	File_taskflow_v1_tasks_proto = out.File
+	file_taskflow_v1_tasks_proto_goTypes = nil
+	file_taskflow_v1_tasks_proto_depIdxs = nil
+}
@@ -1,17 +1,48 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	taskflowv1 "taskflow-api/api/taskflow/v1"
+	"taskflow-api/internal/authz"
 	"taskflow-api/internal/config"
 	"taskflow-api/internal/database"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/export"
 	"taskflow-api/internal/handlers"
+	"taskflow-api/internal/idempotency"
+	"taskflow-api/internal/importer"
+	"taskflow-api/internal/jwtkeys"
 	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/migrate"
+	"taskflow-api/internal/notify"
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/scheduler"
+	"taskflow-api/internal/service"
+	"taskflow-api/internal/webhook"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	// Subcommand: api migrate up|down|status|create
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate.Command("api", os.Args[2:], func() *sql.DB {
+			return database.Connect(config.Load().DatabaseURL)
+		})
+		return
+	}
+
 	// Load configuration
 	cfg := config.Load()
 	log.Println("✅ Config loaded")
@@ -20,21 +51,121 @@ func main() {
 	db := database.Connect(cfg.DatabaseURL)
 	defer db.Close()
 
+	if cfg.AutoMigrate {
+		migrator, err := migrate.New(db)
+		if err != nil {
+			log.Fatal("Failed to load migrations: ", err)
+		}
+		applied, err := migrator.Up(context.Background(), 0)
+		if err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		log.Printf("✅ Migrations applied (%d new)", len(applied))
+	}
+
+	// Notifications: email (log only when SMTP is not configured), in-app inbox, optional webhook
+	var channel notify.Channel = notify.LogChannel{}
+	if cfg.SMTPHost != "" {
+		channel = notify.NewEmailChannel(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	}
+	channels := []notify.Channel{channel, notify.NewInAppChannel(db)}
+	if cfg.NotifyWebhookURL != "" {
+		channels = append(channels, notify.NewWebhookChannel(cfg.NotifyWebhookURL))
+	}
+	notifier := notify.NewNotifier(db, channels...)
+
+	// Reminders and due date notifications
+	interval, err := time.ParseDuration(cfg.SchedulerInterval)
+	if err != nil || interval <= 0 {
+		log.Fatalf("Invalid SCHEDULER_INTERVAL %q", cfg.SchedulerInterval)
+	}
+	go scheduler.New(db, notifier, interval).Run(context.Background())
+	log.Printf("✅ Scheduler running every %s", interval)
+
+	// Idempotency-Key for POST routes: responses are kept for the window, then pruned.
+	// Routes whose response carries a one-time secret (app password, share, invitation
+	// and calendar feed tokens) don't use it, so the secret is never stored.
+	window, err := time.ParseDuration(cfg.IdempotencyWindow)
+	if err != nil || window <= 0 {
+		log.Fatalf("Invalid IDEMPOTENCY_WINDOW %q", cfg.IdempotencyWindow)
+	}
+	idempotencyStore := idempotency.New(db, window)
+	go idempotencyStore.Run(context.Background())
+	idempotent := idempotencyStore.Middleware()
+
+	// Outgoing webhooks: events are queued in the database and delivered in the background
+	webhookPolicy := webhook.Policy{AllowPrivate: cfg.WebhookAllowPrivate}
+	dispatcher := webhook.NewDispatcher(db, webhookPolicy)
+	go dispatcher.Run(context.Background())
+
+	// Real-time stream: events go through Postgres NOTIFY so every instance sees them
+	// (with SQLite they stay inside this process)
+	hub := realtime.NewHub(db, cfg.DatabaseURL)
+	go hub.Run(context.Background())
+
+	// Task events
+	bus := events.NewBus()
+	bus.Subscribe(notifier.HandleEvent)
+	bus.Subscribe(dispatcher.HandleEvent)
+	bus.Subscribe(hub.HandleEvent)
+
+	// Business logic shared by REST, GraphQL, gRPC, CalDAV, sync and imports
+	taskRepo := repository.NewTaskRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	authorizer := authz.New(db)
+	// JWT_SECRET signs tokens until the first "taskflow-admin jwt rotate"
+	jwtKeys := jwtkeys.New(db, cfg.JWTSecret)
+	taskService := service.NewTaskService(taskRepo, userRepo, authorizer, bus)
+	authService := service.NewAuthService(userRepo, jwtKeys)
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(db, cfg.JWTSecret)
-	taskHandler := handlers.NewTaskHandler(db)
+	authHandler := handlers.NewAuthHandler(repository.NewCredentialRepository(db), authService)
+	taskHandler := handlers.NewTaskHandler(taskService, authorizer)
+	statusHandler := handlers.NewStatusHandler(repository.NewStatusRepository(db), authorizer)
+	projectHandler := handlers.NewProjectHandler(projectRepo, authorizer)
+	workspaceHandler := handlers.NewWorkspaceHandler(repository.NewWorkspaceRepository(db), userRepo, authorizer)
+	shareHandler := handlers.NewShareHandler(repository.NewShareRepository(db), authorizer, taskService)
+	reminderHandler := handlers.NewReminderHandler(repository.NewReminderRepository(db), authorizer)
+	notificationHandler := handlers.NewNotificationHandler(repository.NewNotificationRepository(db))
+	webhookHandler := handlers.NewWebhookHandler(repository.NewWebhookRepository(db), authorizer, webhookPolicy)
+	eventHandler := handlers.NewEventHandler(hub)
+	collabHandler := handlers.NewCollabHandler(authorizer, hub)
+	syncHandler := handlers.NewSyncHandler(repository.NewSyncRepository(db), taskService)
+	calendarHandler := handlers.NewCalendarHandler(repository.NewCalendarRepository(db))
+	caldavHandler := handlers.NewCalDAVHandler(repository.NewCalDAVRepository(db), projectRepo, userRepo, authorizer, taskService)
+	graphqlHandler := handlers.NewGraphQLHandler(taskService, userRepo, projectRepo, hub)
+
+	// Imports run in the background through the same create path as POST /tasks
+	importRunner := importer.New(db, taskService.Import)
+	go importRunner.Run(context.Background())
+	importHandler := handlers.NewImportHandler(repository.NewImportRepository(db), authorizer, importRunner)
 
-	// Setup Gin router
-	router := gin.Default()
+	// Exports too large to stream are generated in the background and kept for a day
+	exportRunner := export.NewRunner(db, repository.ExportSource(db))
+	go exportRunner.Run(context.Background())
+	exportHandler := handlers.NewExportHandler(repository.NewExportRepository(db), exportRunner)
+
+	// Setup Gin router. The logger redacts stream tickets and tokens in query strings.
+	router := gin.New()
+	router.Use(middleware.Logger(), gin.Recovery())
 
 	// Add CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Last-Event-ID, If-Match, If-None-Match, Idempotency-Key")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed, Location, Content-Disposition")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
-		if c.Request.Method == "OPTIONS" {
+		// CalDAV clients use OPTIONS to discover the server, so it is not a preflight there
+		if c.Request.Method == "OPTIONS" && !strings.HasPrefix(c.Request.URL.Path, "/caldav/") {
 			c.AbortWithStatus(204)
 			return
 		}
@@ -50,6 +181,32 @@ func main() {
 		})
 	})
 
+	// GraphQL: queries and mutations over POST, queries and SSE subscriptions over GET
+	// (GET also accepts a stream ticket as ?ticket= for EventSource, like /events)
+	router.POST("/graphql", middleware.AuthMiddleware(jwtKeys, db), idempotent, graphqlHandler.Execute)
+	router.GET("/graphql", middleware.StreamAuthMiddleware(jwtKeys, db), graphqlHandler.Query)
+
+	// CalDAV for calendar and reminder apps (HTTP Basic auth with an app password)
+	router.GET("/.well-known/caldav", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "/caldav/")
+	})
+	router.Handle("PROPFIND", "/.well-known/caldav", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "/caldav/")
+	})
+	router.OPTIONS("/caldav/*path", caldavHandler.Options)
+	caldav := router.Group("/caldav")
+	caldav.Use(middleware.BasicAuthMiddleware(db))
+	{
+		caldav.Handle("PROPFIND", "/*path", caldavHandler.Propfind)
+		caldav.Handle("REPORT", "/*path", caldavHandler.Report)
+		caldav.Handle("PROPPATCH", "/*path", caldavHandler.Forbidden)
+		caldav.Handle("MKCALENDAR", "/*path", caldavHandler.Forbidden)
+		caldav.GET("/*path", caldavHandler.Get)
+		caldav.HEAD("/*path", caldavHandler.Get)
+		caldav.PUT("/*path", caldavHandler.Put)
+		caldav.DELETE("/*path", caldavHandler.Delete)
+	}
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -58,23 +215,186 @@ func main() {
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
-			auth.GET("/profile", middleware.AuthMiddleware(cfg.JWTSecret, db), authHandler.GetProfile)
+			auth.GET("/profile", middleware.AuthMiddleware(jwtKeys, db), authHandler.GetProfile)
+			auth.POST("/app-passwords", middleware.AuthMiddleware(jwtKeys, db), authHandler.CreateAppPassword)
+			auth.GET("/app-passwords", middleware.AuthMiddleware(jwtKeys, db), authHandler.GetAppPasswords)
+			auth.DELETE("/app-passwords/:id", middleware.AuthMiddleware(jwtKeys, db), authHandler.DeleteAppPassword)
+			auth.POST("/stream-ticket", middleware.AuthMiddleware(jwtKeys, db), authHandler.CreateStreamTicket)
 		}
 
 		// Task routes (protected)
 		tasks := v1.Group("/tasks")
-		tasks.Use(middleware.AuthMiddleware(cfg.JWTSecret, db))
+		tasks.Use(middleware.AuthMiddleware(jwtKeys, db))
 		{
-			tasks.POST("", taskHandler.CreateTask)
+			tasks.POST("", idempotent, taskHandler.CreateTask)
 			tasks.GET("", taskHandler.GetTasks)
 			tasks.GET("/stats", taskHandler.GetStats)
 			tasks.GET("/:id", taskHandler.GetTask)
-			tasks.PUT("/:id", taskHandler.UpdateTask)
+			tasks.PUT("/:id", taskHandler.ReplaceTask)
+			tasks.PATCH("/:id", taskHandler.PatchTask)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
 			tasks.PATCH("/:id/complete", taskHandler.ToggleComplete)
+			tasks.POST("/:id/move", idempotent, taskHandler.MoveTask)
+			tasks.PATCH("/:id/assign", taskHandler.AssignTask)
+			tasks.POST("/:id/shares", shareHandler.CreateShare)
+			tasks.GET("/:id/shares", shareHandler.GetShares)
+			tasks.DELETE("/:id/shares/:shareId", shareHandler.RevokeShare)
+			tasks.POST("/:id/reminders", idempotent, reminderHandler.CreateReminder)
+			tasks.GET("/:id/reminders", reminderHandler.GetTaskReminders)
+			tasks.DELETE("/:id/reminders/:reminderId", reminderHandler.DeleteReminder)
+		}
+
+		// Project routes (protected)
+		projects := v1.Group("/projects")
+		projects.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			projects.POST("", projectHandler.CreateProject)
+			projects.GET("", projectHandler.GetProjects)
+			projects.GET("/:id", projectHandler.GetProject)
+			projects.PUT("/:id", projectHandler.UpdateProject)
+			projects.DELETE("/:id", projectHandler.DeleteProject)
+			projects.GET("/:id/tasks", projectHandler.GetProjectTasks)
+			projects.GET("/:id/stats", projectHandler.GetProjectStats)
+		}
+
+		// Workspace routes (protected)
+		workspaces := v1.Group("/workspaces")
+		workspaces.Use(middleware.AuthMiddleware(jwtKeys, db))
+		{
+			workspaces.POST("", idempotent, workspaceHandler.CreateWorkspace)
+			workspaces.GET("", workspaceHandler.GetWorkspaces)
+			workspaces.GET("/:id", workspaceHandler.GetWorkspace)
+			workspaces.PUT("/:id", workspaceHandler.UpdateWorkspace)
+			workspaces.DELETE("/:id", workspaceHandler.DeleteWorkspace)
+			workspaces.GET("/:id/members", workspaceHandler.GetMembers)
+			workspaces.PUT("/:id/members/:userId", workspaceHandler.UpdateMember)
+			workspaces.DELETE("/:id/members/:userId", workspaceHandler.RemoveMember)
+			workspaces.POST("/:id/invitations", workspaceHandler.CreateInvitation)
+			workspaces.GET("/:id/invitations", workspaceHandler.GetInvitations)
+			workspaces.DELETE("/:id/invitations/:invitationId", workspaceHandler.RevokeInvitation)
+		}
+
+		// Invitation routes for the invitee (protected)
+		invitations := v1.Group("/invitations")
+		invitations.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			invitations.GET("", workspaceHandler.GetMyInvitations)
+			invitations.POST("/:token/accept", workspaceHandler.AcceptInvitation)
+		}
+
+		// Reminder routes (protected)
+		v1.GET("/reminders", middleware.AuthMiddleware(jwtKeys, db), reminderHandler.GetReminders)
+
+		// Notification inbox routes (protected)
+		notifications := v1.Group("/notifications")
+		notifications.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			notifications.GET("", notificationHandler.GetNotifications)
+			notifications.GET("/unread-count", notificationHandler.GetUnreadCount)
+			notifications.POST("/read-all", notificationHandler.MarkAllRead)
+			notifications.PATCH("/:id/read", notificationHandler.MarkRead)
+			notifications.DELETE("/:id", notificationHandler.DeleteNotification)
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// Webhook routes (protected)
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.GET("", webhookHandler.GetWebhooks)
+			webhooks.GET("/:id", webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries)
+			webhooks.GET("/:id/deliveries/:deliveryId", webhookHandler.GetDelivery)
+			webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+		}
+
+		// Real-time task event stream (protected, browsers pass a stream ticket as ?ticket=)
+		v1.GET("/events", middleware.StreamAuthMiddleware(jwtKeys, db), eventHandler.Stream)
+
+		// Collaboration WebSocket: subscriptions and presence (protected, same token rules as /events)
+		v1.GET("/ws", middleware.StreamAuthMiddleware(jwtKeys, db), collabHandler.Connect)
+
+		// Delta sync for offline clients (protected)
+		sync := v1.Group("/sync")
+		sync.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			sync.GET("", syncHandler.Pull)
+			sync.POST("", syncHandler.Push)
+		}
+
+		// Import routes (protected)
+		imports := v1.Group("/imports")
+		imports.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			imports.POST("", importHandler.CreateImport)
+			imports.GET("", importHandler.GetImports)
+			imports.GET("/:id", importHandler.GetImport)
+		}
+
+		// Export routes (protected)
+		v1.GET("/export", middleware.AuthMiddleware(jwtKeys, db), exportHandler.Export)
+		exports := v1.Group("/exports")
+		exports.Use(middleware.AuthMiddleware(jwtKeys, db))
+		{
+			exports.GET("", exportHandler.GetExports)
+			exports.GET("/:id", exportHandler.GetExport)
+			exports.GET("/:id/download", exportHandler.DownloadExport)
+		}
+
+		// Calendar subscription and ICS import routes (protected)
+		calendar := v1.Group("/calendar")
+		calendar.Use(middleware.AuthMiddleware(jwtKeys, db))
+		{
+			calendar.GET("/feed", calendarHandler.GetFeed)
+			calendar.POST("/feed", calendarHandler.CreateFeed)
+			calendar.DELETE("/feed", calendarHandler.DeleteFeed)
+			calendar.POST("/import", idempotent, importHandler.ImportCalendar)
+		}
+
+		// Board routes (protected)
+		v1.GET("/board", middleware.AuthMiddleware(jwtKeys, db), taskHandler.GetBoard)
+
+		// Workflow status routes (protected)
+		statuses := v1.Group("/statuses")
+		statuses.Use(middleware.AuthMiddleware(jwtKeys, db), idempotent)
+		{
+			statuses.GET("", statusHandler.GetStatuses)
+			statuses.POST("", statusHandler.CreateStatus)
+			statuses.PUT("/:id", statusHandler.UpdateStatus)
+			statuses.DELETE("/:id", statusHandler.DeleteStatus)
+		}
+
+		// Shared task, export download and calendar feed routes (public, token in path)
+		public := v1.Group("/public")
+		{
+			public.GET("/tasks/:token", shareHandler.GetPublicTask)
+			public.PATCH("/tasks/:token", shareHandler.UpdatePublicTask)
+			public.GET("/exports/:token", exportHandler.DownloadPublicExport)
+			public.GET("/calendar/:token", calendarHandler.PublicFeed)
 		}
 	}
 
+	// gRPC server on its own port, sharing the same services
+	unaryAuth, streamAuth := middleware.GRPCAuthInterceptors(jwtKeys, db, handlers.GRPCPublicMethods...)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(unaryAuth), grpc.ChainStreamInterceptor(streamAuth))
+	taskflowv1.RegisterAuthServiceServer(grpcServer, handlers.NewGRPCAuthServer(authService))
+	taskflowv1.RegisterTaskServiceServer(grpcServer, handlers.NewGRPCTaskServer(taskService, hub))
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC:", err)
+	}
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("Failed to start gRPC server:", err)
+		}
+	}()
+
 	// Start server
 	log.Println("============================================================")
 	log.Printf("🚀 Server starting on port %s", cfg.Port)
@@ -83,6 +403,7 @@ func main() {
 	log.Printf("   - Register:     POST http://localhost:%s/api/v1/auth/register", cfg.Port)
 	log.Printf("   - Login:        POST http://localhost:%s/api/v1/auth/login", cfg.Port)
 	log.Printf("   - Get Tasks:    GET http://localhost:%s/api/v1/tasks", cfg.Port)
+	log.Printf("   - gRPC:         localhost:%s (taskflow.v1.AuthService, taskflow.v1.TaskService)", cfg.GRPCPort)
 	log.Println("============================================================")
 
 	if err := router.Run(":" + cfg.Port); err != nil {
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"taskflow-api/internal/jwtkeys"
+)
+
+func runJWT(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("jwt "+args[0], flag.ExitOnError)
+	// Sama dengan umur token dari login, jadi tidak ada yang harus login ulang
+	grace := flags.Duration("grace", 24*time.Hour, "how long tokens signed with older keys stay valid")
+	flags.Parse(args[1:])
+
+	db := connect()
+	defer db.Close()
+	keys := jwtkeys.New(db, "")
+	ctx := context.Background()
+
+	switch args[0] {
+	case "rotate":
+		if *grace < 0 {
+			fail("-grace must not be negative")
+		}
+		key, err := keys.Rotate(ctx, *grace)
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("New signing key %s\n", key.ID)
+		if *grace == 0 {
+			fmt.Println("Tokens signed with older keys are no longer accepted")
+		} else {
+			fmt.Printf("Tokens signed with older keys are accepted for another %s\n", *grace)
+		}
+		fmt.Printf("Running servers pick up the new key within %s\n", jwtkeys.RefreshInterval)
+
+	case "keys":
+		list, err := keys.Keys(ctx)
+		if err != nil {
+			fail("%v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KID\tCREATED\tSTATUS")
+		if len(list) == 0 {
+			fmt.Fprintln(w, "(JWT_SECRET)\t-\tsigning")
+		}
+		for _, key := range list {
+			kid, created := key.ID, key.CreatedAt.Format("2006-01-02 15:04")
+			if kid == "" {
+				kid, created = "(JWT_SECRET)", "-"
+			}
+			status := "signing"
+			switch {
+			case key.Expired:
+				status = "expired"
+			case key.ExpiresAt != nil:
+				status = "valid until " + key.ExpiresAt.Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", kid, created, status)
+		}
+		w.Flush()
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,73 @@
+// taskflow-admin - tugas operasional (user, kunci JWT, migrasi, data demo,
+// statistik) langsung ke database, memakai package yang sama dengan server
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/migrate"
+)
+
+const usage = `Usage: taskflow-admin <command> [flags]
+
+Users (USER is an email address or a numeric ID):
+  users list [-json]                             list all users
+  users create -email EMAIL -name NAME [-password PASSWORD]
+  users disable USER                             block login, tokens and app passwords
+  users enable USER
+  users reset-password [-password PASSWORD] USER
+  users delete -yes USER                         delete a user and all their data
+
+JWT signing keys:
+  jwt rotate [-grace DURATION]                   sign new tokens with a fresh key; old
+                                                 tokens stay valid for the grace period
+  jwt keys                                       list keys
+
+Database:
+  migrate up|down|status|create                  same as "api migrate"
+  seed [-email EMAIL] [-password PASSWORD]       create a demo user with sample data
+  stats [-json]                                  counts across the whole instance
+
+Passwords that are not given are generated and printed. The database comes from
+DATABASE_URL (or .env), like the server.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "users":
+		runUsers(args)
+	case "jwt":
+		runJWT(args)
+	case "migrate":
+		migrate.Command("taskflow-admin", args, connect)
+	case "seed":
+		runSeed(args)
+	case "stats":
+		runStats(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+func connect() *sql.DB {
+	return database.Connect(config.Load().DatabaseURL)
+}
+
+// fail - tulis pesan error lalu keluar dengan status 1
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+	os.Exit(1)
+}
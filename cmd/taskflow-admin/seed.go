@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/jwtkeys"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+)
+
+// demoTask - task contoh; due relatif terhadap sekarang, 0 = tanpa due date
+type demoTask struct {
+	title    string
+	project  int // indeks di demoProjects, -1 = tanpa project
+	priority models.Priority
+	category models.Category
+	status   string
+	due      time.Duration
+}
+
+var demoProjects = []struct{ name, description, color string }{
+	{"Website Redesign", "New marketing site and blog", "#6366f1"},
+	{"Home", "Errands and chores", "#10b981"},
+}
+
+var demoTasks = []demoTask{
+	{"Write project proposal", 0, models.PriorityHigh, models.CategoryWork, "in_progress", 2 * 24 * time.Hour},
+	{"Review homepage mockups", 0, models.PriorityMedium, models.CategoryWork, "review", 24 * time.Hour},
+	{"Fix broken contact form", 0, models.PriorityHigh, models.CategoryUrgent, "todo", -24 * time.Hour},
+	{"Set up analytics", 0, models.PriorityLow, models.CategoryWork, "todo", 0},
+	{"Plan sprint", 0, models.PriorityMedium, models.CategoryWork, "done", 0},
+	{"Buy groceries", 1, models.PriorityLow, models.CategoryPersonal, "todo", 12 * time.Hour},
+	{"Book dentist appointment", 1, models.PriorityMedium, models.CategoryPersonal, "todo", 7 * 24 * time.Hour},
+	{"Renew passport", -1, models.PriorityHigh, models.CategoryPersonal, "todo", 30 * 24 * time.Hour},
+	{"Read Clean Architecture", -1, models.PriorityLow, models.CategoryPersonal, "done", 0},
+}
+
+// runSeed - user demo dengan project dan task contoh, lewat service yang sama
+// dengan API supaya board, posisi dan change_seq terisi benar
+func runSeed(args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	email := flags.String("email", "demo@taskflow.local", "email of the demo user")
+	password := flags.String("password", "", "password (generated when empty)")
+	flags.Parse(args)
+
+	db := connect()
+	defer db.Close()
+	users := repository.NewUserRepository(db)
+	auth := service.NewAuthService(users, jwtkeys.New(db, ""))
+	tasks := service.NewTaskService(repository.NewTaskRepository(db), users, authz.New(db), events.NewBus())
+
+	generated := ensurePassword(password)
+	user, err := auth.Register(models.RegisterRequest{Name: "Demo User", Email: *email, Password: *password})
+	if err != nil {
+		fail("%v", err)
+	}
+
+	projectIDs := make([]int, len(demoProjects))
+	for i, p := range demoProjects {
+		err := db.QueryRow(
+			"INSERT INTO projects (user_id, name, description, color) VALUES ($1, $2, $3, $4) RETURNING id",
+			user.ID, p.name, p.description, p.color,
+		).Scan(&projectIDs[i])
+		if err != nil {
+			fail("create project %q: %v", p.name, err)
+		}
+	}
+
+	now := time.Now()
+	for _, t := range demoTasks {
+		req := models.CreateTaskRequest{Title: t.title, Priority: t.priority, Category: t.category, Status: t.status}
+		if t.project >= 0 {
+			req.ProjectID = &projectIDs[t.project]
+		}
+		if t.due != 0 {
+			due := now.Add(t.due).Truncate(time.Hour)
+			req.DueDate = &due
+		}
+		if _, err := tasks.Create(user.ID, req); err != nil {
+			fail("create task %q: %v", t.title, err)
+		}
+	}
+
+	fmt.Printf("Created demo user %d <%s> with %d projects and %d tasks\n",
+		user.ID, user.Email, len(demoProjects), len(demoTasks))
+	if generated {
+		fmt.Printf("Password: %s\n", *password)
+	}
+}
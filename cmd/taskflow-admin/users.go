@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"taskflow-api/internal/jwtkeys"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+	"taskflow-api/internal/utils"
+)
+
+func runUsers(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("users "+args[0], flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON")
+	email := flags.String("email", "", "email address")
+	name := flags.String("name", "", "display name")
+	password := flags.String("password", "", "password (generated when empty)")
+	yes := flags.Bool("yes", false, "confirm deletion")
+	flags.Parse(args[1:])
+
+	db := connect()
+	defer db.Close()
+	users := repository.NewUserRepository(db)
+	auth := service.NewAuthService(users, jwtkeys.New(db, ""))
+
+	switch args[0] {
+	case "list":
+		list, err := users.List()
+		if err != nil {
+			fail("%v", err)
+		}
+		if *asJSON {
+			printJSON(list)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tCREATED\tSTATUS")
+		for _, u := range list {
+			status := "active"
+			if u.DisabledAt != nil {
+				status = "disabled " + u.DisabledAt.Format("2006-01-02")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", u.ID, u.Name, u.Email, u.CreatedAt.Format("2006-01-02"), status)
+		}
+		w.Flush()
+
+	case "create":
+		if *email == "" || *name == "" {
+			fail("users create needs -email and -name")
+		}
+		if !strings.Contains(*email, "@") {
+			fail("invalid email address %q", *email)
+		}
+		generated := ensurePassword(password)
+		user, err := auth.Register(models.RegisterRequest{Name: *name, Email: *email, Password: *password})
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("Created user %d <%s>\n", user.ID, user.Email)
+		if generated {
+			fmt.Printf("Password: %s\n", *password)
+		}
+
+	case "disable", "enable":
+		user := lookupUser(users, flags)
+		if err := auth.SetDisabled(user.ID, args[0] == "disable"); err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("User %d <%s> %sd\n", user.ID, user.Email, args[0])
+
+	case "reset-password":
+		user := lookupUser(users, flags)
+		generated := ensurePassword(password)
+		if err := auth.ResetPassword(user.ID, *password); err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("Password reset for user %d <%s>\n", user.ID, user.Email)
+		if generated {
+			fmt.Printf("Password: %s\n", *password)
+		}
+
+	case "delete":
+		user := lookupUser(users, flags)
+		if !*yes {
+			fail("deleting user %d <%s> removes all their tasks, projects and owned workspaces; repeat with -yes", user.ID, user.Email)
+		}
+		if err := users.Delete(user.ID); err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("Deleted user %d <%s>\n", user.ID, user.Email)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// lookupUser - user dari satu-satunya argumen: ID angka atau email
+func lookupUser(users repository.UserRepository, flags *flag.FlagSet) models.User {
+	if flags.NArg() != 1 {
+		fail("expected exactly one USER (email or ID)")
+	}
+	ref := flags.Arg(0)
+
+	var user models.User
+	var err error
+	if id, convErr := strconv.Atoi(ref); convErr == nil {
+		user, err = users.Get(id)
+	} else {
+		user, _, err = users.GetByEmail(ref)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		fail("user %s not found", ref)
+	}
+	if err != nil {
+		fail("%v", err)
+	}
+	return user
+}
+
+// ensurePassword - isi password acak kalau kosong; true kalau digenerate
+func ensurePassword(password *string) bool {
+	if *password != "" {
+		return false
+	}
+	token, err := utils.GenerateToken(9)
+	if err != nil {
+		fail("%v", err)
+	}
+	*password = token
+	return true
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fail("%v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+func runLogin(args []string) error {
+	flags := flag.NewFlagSet("login", flag.ExitOnError)
+	server := flags.String("server", "", "API server URL")
+	email := flags.String("email", "", "account email")
+	parseArgs(flags, args)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		cfg.Server = strings.TrimRight(*server, "/")
+	}
+
+	stdin := bufio.NewReader(os.Stdin)
+	if *email == "" {
+		*email = cfg.Email
+		if *email == "" || !isTerminal() {
+			if *email, err = prompt(stdin, "Email: "); err != nil {
+				return err
+			}
+		}
+	}
+	password, err := readPassword(stdin)
+	if err != nil {
+		return err
+	}
+	if *email == "" || password == "" {
+		return errors.New("email and password are required")
+	}
+
+	var resp models.LoginResponse
+	cfg.Token = ""
+	request := models.LoginRequest{Email: *email, Password: password}
+	if err := newClient(cfg).do("POST", "/auth/login", nil, "", request, &resp); err != nil {
+		return err
+	}
+
+	cfg.Email = resp.User.Email
+	cfg.Token = resp.Token
+	cfg.ExpiresAt = tokenExpiry(resp.Token)
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Logged in to %s as %s\n", cfg.Server, resp.User.Email)
+	return nil
+}
+
+func runLogout(args []string) error {
+	flags := flag.NewFlagSet("logout", flag.ExitOnError)
+	parseArgs(flags, args)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Token == "" {
+		fmt.Println("Not logged in")
+		return nil
+	}
+	cfg.Token = ""
+	cfg.ExpiresAt = time.Time{}
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Println("Logged out")
+	return nil
+}
+
+func prompt(stdin *bufio.Reader, label string) (string, error) {
+	if isTerminal() {
+		fmt.Fprint(os.Stderr, label)
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no input for " + strings.TrimSuffix(label, ": "))
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// readPassword - di terminal echo dimatikan lewat stty; selain itu password
+// dibaca dari baris stdin (echo secret | taskflow login -email ...)
+func readPassword(stdin *bufio.Reader) (string, error) {
+	if !isTerminal() {
+		return prompt(stdin, "Password: ")
+	}
+
+	if err := stty("-echo"); err == nil {
+		defer func() {
+			stty("echo")
+			fmt.Fprintln(os.Stderr)
+		}()
+	}
+	return prompt(stdin, "Password: ")
+}
+
+func isTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func stty(mode string) error {
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
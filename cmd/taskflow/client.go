@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var errNotLoggedIn = errors.New("not logged in, run: taskflow login")
+
+// client - request ke API dengan token dari config
+type client struct {
+	cfg  *config
+	http *http.Client
+}
+
+// envelope - format response API (utils.Response)
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+func newClient(cfg *config) *client {
+	return &client{cfg: cfg, http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// authedClient - client untuk command yang butuh login
+func authedClient() (*client, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Token == "" {
+		return nil, errNotLoggedIn
+	}
+	if !cfg.ExpiresAt.IsZero() && time.Now().After(cfg.ExpiresAt) {
+		return nil, errors.New("session expired, run: taskflow login")
+	}
+	return newClient(cfg), nil
+}
+
+// do - kirim request dan decode field data ke out (boleh nil). contentType
+// kosong berarti application/json.
+func (c *client) do(method, path string, query url.Values, contentType string, body, out interface{}) error {
+	endpoint := c.cfg.Server + "/api/v1" + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("%s %s: unexpected response (%s)", method, path, resp.Status)
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.cfg.Token != "" {
+		return fmt.Errorf("%s, run: taskflow login", env.Error)
+	}
+	if resp.StatusCode >= 300 || !env.Success {
+		if env.Error == "" {
+			env.Error = resp.Status
+		}
+		return errors.New(env.Error)
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		return json.Unmarshal(env.Data, out)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// config - isi file config; hanya token yang disimpan, bukan password
+type config struct {
+	Server    string    `json:"server"`
+	Email     string    `json:"email,omitempty"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+func configPath() (string, error) {
+	if path := os.Getenv("TASKFLOW_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "taskflow", "config.json"), nil
+}
+
+// loadConfig - config kosong (server default) kalau file belum ada
+func loadConfig() (*config, error) {
+	cfg := &config{Server: defaultServer}
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, errors.New("invalid config file " + path + ": " + err.Error())
+		}
+	}
+
+	if server := os.Getenv("TASKFLOW_SERVER"); server != "" {
+		cfg.Server = server
+	}
+	cfg.Server = strings.TrimRight(cfg.Server, "/")
+	return cfg, nil
+}
+
+// save - file hanya bisa dibaca pemiliknya karena berisi token
+func (c *config) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// tokenExpiry - klaim exp dari JWT, dibaca tanpa verifikasi hanya supaya client
+// bisa bilang "login lagi" sebelum request ditolak
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
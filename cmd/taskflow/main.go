@@ -0,0 +1,101 @@
+// taskflow - client terminal untuk TaskFlow API. Login sekali, token disimpan
+// di file config, lalu task dikelola lewat REST API seperti client lain.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: taskflow <command> [flags] [args]
+
+Commands:
+  login [-server URL] [-email EMAIL]   log in and store the token
+  logout                               forget the stored token
+  add [flags] TEXT...                  create a task (quick-add syntax below)
+  ls [flags] [FILTER...]               list tasks
+  done [-undo] ID...                   mark tasks as completed (or open again)
+  edit [flags] ID [TEXT...]            change a task; TEXT replaces the title
+  rm ID...                             delete tasks
+  stats [flags] [FILTER...]            task statistics
+
+Quick-add syntax (add, edit, and as filters for ls and stats):
+  p:high p:medium p:low   priority (also p:h, p:m, p:l, or p1, p2, p3)
+  c:personal c:work c:urgent
+                          category
+  s:KEY                   board status, e.g. s:in_progress
+  @today @tomorrow @fri @2026-11-03 @2026-11-03T15:00 @+3d @+4h @+2w
+                          due date (dates without a time are due at 23:59);
+                          @none clears it in edit
+  Other words form the title (add, edit) or the search text (ls, stats).
+  Prefix a word with \ to keep it literal, e.g. '\p:high'.
+
+  taskflow add Send invoice c:work p:high @fri
+
+ls and stats filters: -priority -category -status -project -workspace
+  -assignee (me|ID|none) -created-by (me|ID) -search -open -done -archived
+Every command accepts -json to print the API response as JSON.
+
+The config file is $TASKFLOW_CONFIG, or taskflow/config.json in the user
+config directory. TASKFLOW_SERVER overrides the stored server URL.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	var err error
+	switch command {
+	case "login":
+		err = runLogin(args)
+	case "logout":
+		err = runLogout(args)
+	case "add":
+		err = runAdd(args)
+	case "ls", "list":
+		err = runList(args)
+	case "done":
+		err = runDone(args)
+	case "edit":
+		err = runEdit(args)
+	case "rm":
+		err = runRemove(args)
+	case "stats":
+		err = runStats(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// parseArgs - seperti flags.Parse, tapi flag boleh muncul setelah argumen biasa
+// (taskflow add Beli susu -json). Setelah "--" semuanya argumen biasa.
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+
+	var positional []string
+	for len(args) > 0 {
+		flags.Parse(args)
+		rest := flags.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...)
+		}
+		if len(rest) == 0 {
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	return positional
+}
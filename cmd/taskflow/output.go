@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTasks - tabel task; task selesai ditandai [x], due lewat ditandai overdue
+func printTasks(tasks []models.Task) {
+	if len(tasks) == 0 {
+		fmt.Println("No tasks")
+		return
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\t\tPRIORITY\tCATEGORY\tSTATUS\tDUE\tTITLE")
+	for _, task := range tasks {
+		mark := "[ ]"
+		if task.IsCompleted {
+			mark = "[x]"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", task.ID, mark, task.Priority, task.Category,
+			task.Status, formatDue(task, now), task.Title)
+	}
+	w.Flush()
+}
+
+// formatDue - waktu lokal; jam 23:59 (default quick-add) cukup tanggalnya
+func formatDue(task models.Task, now time.Time) string {
+	if task.DueDate == nil {
+		return "-"
+	}
+	due := task.DueDate.Local()
+	text := due.Format("2006-01-02 15:04")
+	if due.Hour() == 23 && due.Minute() == 59 {
+		text = due.Format("2006-01-02")
+	}
+	if !task.IsCompleted && due.Before(now) {
+		text += " (overdue)"
+	}
+	return text
+}
+
+func printStats(stats models.TaskStats) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Total\t%d\n", stats.Total)
+	fmt.Fprintf(w, "Completed\t%d\n", stats.Completed)
+	fmt.Fprintf(w, "Pending\t%d\n", stats.Pending)
+	fmt.Fprintf(w, "Overdue\t%d\n", stats.Overdue)
+	fmt.Fprintf(w, "High priority\t%d\n", stats.HighPriority)
+	w.Flush()
+
+	if len(stats.ByAssignee) == 0 {
+		return
+	}
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSIGNEE\tTOTAL\tCOMPLETED\tPENDING\tOVERDUE")
+	for _, a := range stats.ByAssignee {
+		name := strings.TrimSpace(a.Name)
+		if a.AssigneeID == nil {
+			name = "(unassigned)"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", name, a.Total, a.Completed, a.Pending, a.Overdue)
+	}
+	w.Flush()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+// quickAdd - hasil parse teks quick-add. Field kosong/nil berarti tidak disebut.
+type quickAdd struct {
+	Words    []string
+	Priority models.Priority
+	Category models.Category
+	Status   string
+	Due      *time.Time
+	ClearDue bool
+}
+
+// Text - kata-kata biasa, jadi title atau teks pencarian
+func (q quickAdd) Text() string {
+	return strings.Join(q.Words, " ")
+}
+
+var (
+	priorities = map[string]models.Priority{
+		"high": models.PriorityHigh, "h": models.PriorityHigh, "1": models.PriorityHigh,
+		"medium": models.PriorityMedium, "med": models.PriorityMedium, "m": models.PriorityMedium, "2": models.PriorityMedium,
+		"low": models.PriorityLow, "l": models.PriorityLow, "3": models.PriorityLow,
+	}
+	categories = map[string]models.Category{
+		"personal": models.CategoryPersonal,
+		"work":     models.CategoryWork,
+		"urgent":   models.CategoryUrgent,
+	}
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+
+	shortPriority = regexp.MustCompile(`^p([123])$`)
+	relativeDue   = regexp.MustCompile(`^\+(\d+)([hdw])$`)
+)
+
+// parseQuickAdd - pisahkan token p:, c:, s: dan @ dari kata biasa. Argumen
+// shell boleh berisi beberapa kata sekaligus ("taskflow add 'Beli susu p:low'").
+func parseQuickAdd(args []string, now time.Time) (quickAdd, error) {
+	var q quickAdd
+	for _, arg := range args {
+		for _, word := range strings.Fields(arg) {
+			if err := q.add(word, now); err != nil {
+				return q, err
+			}
+		}
+	}
+	return q, nil
+}
+
+func (q *quickAdd) add(word string, now time.Time) error {
+	if strings.HasPrefix(word, `\`) {
+		q.Words = append(q.Words, word[1:])
+		return nil
+	}
+
+	lower := strings.ToLower(word)
+	if m := shortPriority.FindStringSubmatch(lower); m != nil {
+		q.Priority = priorities[m[1]]
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(lower, "p:"):
+		priority, ok := priorities[lower[2:]]
+		if !ok {
+			return fmt.Errorf("unknown priority %q (high, medium or low)", word)
+		}
+		q.Priority = priority
+	case strings.HasPrefix(lower, "c:"):
+		category, ok := categories[lower[2:]]
+		if !ok {
+			return fmt.Errorf("unknown category %q (personal, work or urgent)", word)
+		}
+		q.Category = category
+	case strings.HasPrefix(lower, "s:") && len(lower) > 2:
+		q.Status = word[2:]
+	case strings.HasPrefix(lower, "@") && len(lower) > 1:
+		if lower == "@none" {
+			q.Due, q.ClearDue = nil, true
+			return nil
+		}
+		due, err := parseDue(lower[1:], now)
+		if err != nil {
+			return err
+		}
+		q.Due, q.ClearDue = &due, false
+	default:
+		q.Words = append(q.Words, word)
+	}
+	return nil
+}
+
+// parseDue - today, tomorrow, nama hari, tanggal (opsional dengan jam) atau
+// +N jam/hari/minggu. Tanpa jam berarti jatuh tempo 23:59 waktu lokal.
+func parseDue(value string, now time.Time) (time.Time, error) {
+	endOfDay := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 0, 0, now.Location())
+	}
+
+	switch value {
+	case "today":
+		return endOfDay(now), nil
+	case "tomorrow", "tmr":
+		return endOfDay(now.AddDate(0, 0, 1)), nil
+	}
+
+	if len(value) >= 3 {
+		if day, ok := weekdays[value[:3]]; ok && strings.HasPrefix(weekdayName(day), value) {
+			// Hari yang sama berarti minggu depan
+			days := (int(day)-int(now.Weekday())+6)%7 + 1
+			return endOfDay(now.AddDate(0, 0, days)), nil
+		}
+	}
+
+	if m := relativeDue.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "h":
+			return now.Add(time.Duration(n) * time.Hour).Truncate(time.Minute), nil
+		case "d":
+			return endOfDay(now.AddDate(0, 0, n)), nil
+		default:
+			return endOfDay(now.AddDate(0, 0, 7*n)), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return endOfDay(t), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02t15:04", value, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unknown due date @%s", value)
+}
+
+func weekdayName(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
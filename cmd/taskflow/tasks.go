@@ -0,0 +1,329 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+const mergePatchType = "application/merge-patch+json"
+
+func runAdd(args []string) error {
+	flags := flag.NewFlagSet("add", flag.ExitOnError)
+	desc := flags.String("desc", "", "description")
+	project := flags.Int("project", 0, "project ID")
+	workspace := flags.Int("workspace", 0, "workspace ID")
+	asJSON := flags.Bool("json", false, "print JSON")
+	words := parseArgs(flags, args)
+
+	q, err := parseQuickAdd(words, time.Now())
+	if err != nil {
+		return err
+	}
+	if q.Text() == "" {
+		return errors.New("task title is required")
+	}
+
+	req := models.CreateTaskRequest{
+		Title:    q.Text(),
+		Priority: q.Priority,
+		Category: q.Category,
+		Status:   q.Status,
+		DueDate:  q.Due,
+	}
+	if *desc != "" {
+		req.Description = desc
+	}
+	if *project != 0 {
+		req.ProjectID = project
+	}
+	if *workspace != 0 {
+		req.WorkspaceID = workspace
+	}
+
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	var task models.Task
+	if err := c.do("POST", "/tasks", nil, "", req, &task); err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(task)
+	}
+	printTasks([]models.Task{task})
+	return nil
+}
+
+func runList(args []string) error {
+	flags := flag.NewFlagSet("ls", flag.ExitOnError)
+	filter := registerFilterFlags(flags)
+	asJSON := flags.Bool("json", false, "print JSON")
+	words := parseArgs(flags, args)
+
+	query, err := filter.query(words)
+	if err != nil {
+		return err
+	}
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	var tasks []models.Task
+	if err := c.do("GET", "/tasks", query, "", nil, &tasks); err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(tasks)
+	}
+	printTasks(tasks)
+	return nil
+}
+
+func runStats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	filter := registerFilterFlags(flags)
+	asJSON := flags.Bool("json", false, "print JSON")
+	words := parseArgs(flags, args)
+
+	query, err := filter.query(words)
+	if err != nil {
+		return err
+	}
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	var stats models.TaskStats
+	if err := c.do("GET", "/tasks/stats", query, "", nil, &stats); err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(stats)
+	}
+	printStats(stats)
+	return nil
+}
+
+func runDone(args []string) error {
+	flags := flag.NewFlagSet("done", flag.ExitOnError)
+	undo := flags.Bool("undo", false, "mark as not completed")
+	asJSON := flags.Bool("json", false, "print JSON")
+	ids, err := taskIDs(parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	var updated []models.Task
+	for _, id := range ids {
+		var task models.Task
+		patch := map[string]interface{}{"is_completed": !*undo}
+		if err := c.do("PATCH", "/tasks/"+strconv.Itoa(id), nil, mergePatchType, patch, &task); err != nil {
+			return fmt.Errorf("task %d: %w", id, err)
+		}
+		updated = append(updated, task)
+		if !*asJSON {
+			state := "completed"
+			if *undo {
+				state = "reopened"
+			}
+			fmt.Printf("Task %d %s: %s\n", task.ID, state, task.Title)
+		}
+	}
+	if *asJSON {
+		return printJSON(updated)
+	}
+	return nil
+}
+
+func runEdit(args []string) error {
+	flags := flag.NewFlagSet("edit", flag.ExitOnError)
+	desc := flags.String("desc", "", "new description (empty string clears it)")
+	project := flags.Int("project", 0, "move to project ID")
+	noProject := flags.Bool("no-project", false, "remove the task from its project")
+	asJSON := flags.Bool("json", false, "print JSON")
+	positional := parseArgs(flags, args)
+	if len(positional) == 0 {
+		return errors.New("task ID is required")
+	}
+	ids, err := taskIDs(positional[:1])
+	if err != nil {
+		return err
+	}
+
+	q, err := parseQuickAdd(positional[1:], time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Merge patch: hanya field yang disebut yang berubah, null menghapus nilai
+	patch := map[string]interface{}{}
+	if q.Text() != "" {
+		patch["title"] = q.Text()
+	}
+	if q.Priority != "" {
+		patch["priority"] = q.Priority
+	}
+	if q.Category != "" {
+		patch["category"] = q.Category
+	}
+	if q.Status != "" {
+		patch["status"] = q.Status
+	}
+	if q.Due != nil {
+		patch["due_date"] = q.Due
+	} else if q.ClearDue {
+		patch["due_date"] = nil
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "desc":
+			if *desc == "" {
+				patch["description"] = nil
+			} else {
+				patch["description"] = *desc
+			}
+		case "project":
+			patch["project_id"] = *project
+		}
+	})
+	if *noProject {
+		patch["project_id"] = nil
+	}
+	if len(patch) == 0 {
+		return errors.New("nothing to change")
+	}
+
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	var task models.Task
+	if err := c.do("PATCH", "/tasks/"+strconv.Itoa(ids[0]), nil, mergePatchType, patch, &task); err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(task)
+	}
+	printTasks([]models.Task{task})
+	return nil
+}
+
+func runRemove(args []string) error {
+	flags := flag.NewFlagSet("rm", flag.ExitOnError)
+	ids, err := taskIDs(parseArgs(flags, args))
+	if err != nil {
+		return err
+	}
+
+	c, err := authedClient()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := c.do("DELETE", "/tasks/"+strconv.Itoa(id), nil, "", nil, nil); err != nil {
+			return fmt.Errorf("task %d: %w", id, err)
+		}
+		fmt.Printf("Task %d deleted\n", id)
+	}
+	return nil
+}
+
+func taskIDs(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, errors.New("task ID is required")
+	}
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid task ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// filterFlags - flag ls/stats, sama dengan query string GET /tasks
+type filterFlags struct {
+	priority, category, status string
+	project, workspace         string
+	assignee, createdBy        string
+	search                     string
+	open, done, archived       bool
+}
+
+func registerFilterFlags(flags *flag.FlagSet) *filterFlags {
+	f := &filterFlags{}
+	flags.StringVar(&f.priority, "priority", "", "high, medium or low")
+	flags.StringVar(&f.category, "category", "", "personal, work or urgent")
+	flags.StringVar(&f.status, "status", "", "board status key")
+	flags.StringVar(&f.project, "project", "", "project ID")
+	flags.StringVar(&f.workspace, "workspace", "", "workspace ID")
+	flags.StringVar(&f.assignee, "assignee", "", "me, user ID or none")
+	flags.StringVar(&f.createdBy, "created-by", "", "me or user ID")
+	flags.StringVar(&f.search, "search", "", "search title and description")
+	flags.BoolVar(&f.open, "open", false, "only tasks that are not completed")
+	flags.BoolVar(&f.done, "done", false, "only completed tasks")
+	flags.BoolVar(&f.archived, "archived", false, "include tasks in archived projects")
+	return f
+}
+
+// query - flag ditambah token quick-add; kata biasa jadi teks pencarian
+func (f *filterFlags) query(words []string) (url.Values, error) {
+	q, err := parseQuickAdd(words, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if q.Due != nil || q.ClearDue {
+		return nil, errors.New("due dates cannot be used as a filter")
+	}
+	if f.open && f.done {
+		return nil, errors.New("-open and -done cannot be combined")
+	}
+
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("priority", first(string(q.Priority), f.priority))
+	set("category", first(string(q.Category), f.category))
+	set("status", first(q.Status, f.status))
+	set("project_id", f.project)
+	set("workspace_id", f.workspace)
+	set("assignee", f.assignee)
+	set("created_by", f.createdBy)
+	set("search", strings.TrimSpace(f.search+" "+q.Text()))
+	if f.open {
+		values.Set("is_completed", "false")
+	}
+	if f.done {
+		values.Set("is_completed", "true")
+	}
+	if f.archived {
+		values.Set("include_archived", "true")
+	}
+	return values, nil
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -317,11 +317,74 @@ function formatDate(dateString) {
     }
 }
 
+// Real-time updates: refresh when tasks change in another tab or for a teammate.
+// The stream is opened with a single-use ticket, so after an error it is reopened
+// with a new ticket and resumes from the last event it saw.
+let refreshTimer = null;
+let lastEventId = null;
+
+function scheduleRefresh() {
+    clearTimeout(refreshTimer);
+    refreshTimer = setTimeout(async () => {
+        await fetchTasks();
+        await fetchStats();
+    }, 300);
+}
+
+async function fetchStreamTicket() {
+    const response = await fetch(`${API_BASE_URL}/auth/stream-ticket`, {
+        method: 'POST',
+        headers: {
+            'Authorization': `Bearer ${token}`,
+        },
+    });
+    const data = await response.json();
+    if (!data.success) {
+        throw new Error(data.error || 'Failed to get stream ticket');
+    }
+    return data.data.ticket;
+}
+
+async function subscribeToEvents() {
+    if (!window.EventSource) {
+        return;
+    }
+
+    let ticket;
+    try {
+        ticket = await fetchStreamTicket();
+    } catch (error) {
+        console.error('Event stream error:', error);
+        setTimeout(subscribeToEvents, 5000);
+        return;
+    }
+
+    let url = `${API_BASE_URL}/events?ticket=${encodeURIComponent(ticket)}`;
+    if (lastEventId) {
+        url += `&last_event_id=${encodeURIComponent(lastEventId)}`;
+    }
+    const source = new EventSource(url);
+    ['task.created', 'task.updated', 'task.deleted', 'resync'].forEach((type) => {
+        source.addEventListener(type, (event) => {
+            if (event.lastEventId) {
+                lastEventId = event.lastEventId;
+            }
+            scheduleRefresh();
+        });
+    });
+    source.onerror = (error) => {
+        console.error('Event stream error:', error);
+        source.close();
+        setTimeout(subscribeToEvents, 5000);
+    };
+}
+
 // Initialize dashboard
 async function initDashboard() {
     await fetchProfile();
     await fetchStats();
     await fetchTasks();
+    subscribeToEvents();
 }
 
 // Start the app
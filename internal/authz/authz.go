@@ -0,0 +1,166 @@
+package authz
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrNotFound - resource tidak ada atau tidak terlihat oleh user
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden - resource terlihat, tapi role user tidak cukup
+	ErrForbidden = errors.New("forbidden")
+)
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	RoleViewer Role = "viewer"
+)
+
+type Action string
+
+const (
+	ActionView   Action = "view"   // baca task, project, board
+	ActionEdit   Action = "edit"   // buat dan ubah task
+	ActionDelete Action = "delete" // hapus task (member hanya task buatannya sendiri)
+	ActionManage Action = "manage" // project, status board, anggota, undangan
+	ActionOwn    Action = "own"    // hapus workspace
+)
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Can - matriks izin per role
+func (r Role) Can(action Action) bool {
+	switch action {
+	case ActionView:
+		return r.Valid()
+	case ActionEdit, ActionDelete:
+		return r == RoleOwner || r == RoleAdmin || r == RoleMember
+	case ActionManage:
+		return r == RoleOwner || r == RoleAdmin
+	case ActionOwn:
+		return r == RoleOwner
+	}
+	return false
+}
+
+// Resource - kepemilikan task atau project. WorkspaceID nil berarti personal.
+type Resource struct {
+	ID          int
+	UserID      int
+	WorkspaceID *int
+}
+
+// Authorizer - satu-satunya tempat yang memutuskan siapa boleh melakukan apa
+type Authorizer struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Authorizer {
+	return &Authorizer{db: db}
+}
+
+// WorkspaceRole - role user di workspace, ErrNotFound kalau bukan anggota
+func (a *Authorizer) WorkspaceRole(userID, workspaceID int) (Role, error) {
+	var role Role
+	err := a.db.QueryRow(
+		"SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2",
+		workspaceID, userID,
+	).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return role, err
+}
+
+// Workspace - cek action di workspace dan kembalikan role user
+func (a *Authorizer) Workspace(userID, workspaceID int, action Action) (Role, error) {
+	role, err := a.WorkspaceRole(userID, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	if !role.Can(action) {
+		return role, ErrForbidden
+	}
+	return role, nil
+}
+
+// Scope - cek action untuk membuat sesuatu di personal space (nil) atau workspace
+func (a *Authorizer) Scope(userID int, workspaceID *int, action Action) error {
+	if workspaceID == nil {
+		return nil
+	}
+	_, err := a.Workspace(userID, *workspaceID, action)
+	return err
+}
+
+// Task - load kepemilikan task dan cek action
+func (a *Authorizer) Task(userID, taskID int, action Action) (Resource, error) {
+	return a.resource("tasks", userID, taskID, action)
+}
+
+// Project - load kepemilikan project dan cek action
+func (a *Authorizer) Project(userID, projectID int, action Action) (Resource, error) {
+	return a.resource("projects", userID, projectID, action)
+}
+
+// Webhook - load kepemilikan webhook dan cek action
+func (a *Authorizer) Webhook(userID, webhookID int, action Action) (Resource, error) {
+	return a.resource("webhooks", userID, webhookID, action)
+}
+
+func (a *Authorizer) resource(table string, userID, id int, action Action) (Resource, error) {
+	res := Resource{ID: id}
+	err := a.db.QueryRow(
+		"SELECT user_id, workspace_id FROM "+table+" WHERE id = $1", id,
+	).Scan(&res.UserID, &res.WorkspaceID)
+	if err == sql.ErrNoRows {
+		return res, ErrNotFound
+	}
+	if err != nil {
+		return res, err
+	}
+	return res, a.Check(userID, res, action)
+}
+
+// Check - putuskan apakah user boleh melakukan action pada resource
+func (a *Authorizer) Check(userID int, res Resource, action Action) error {
+	// Personal resource hanya untuk pemiliknya
+	if res.WorkspaceID == nil {
+		if res.UserID == userID {
+			return nil
+		}
+		return ErrNotFound
+	}
+
+	role, err := a.WorkspaceRole(userID, *res.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if !role.Can(action) {
+		return ErrForbidden
+	}
+	if action == ActionDelete && role == RoleMember && res.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Visible - predicate SQL untuk baris (tasks/projects) yang boleh dilihat user.
+// Placeholder $argPos harus diisi dengan user ID.
+func Visible(table string, argPos int) string {
+	arg := "$" + strconv.Itoa(argPos)
+	return "((" + table + ".workspace_id IS NULL AND " + table + ".user_id = " + arg + ") OR " +
+		table + ".workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = " + arg + "))"
+}
@@ -0,0 +1,352 @@
+// Package caldav berisi bagian protokol WebDAV/CalDAV (RFC 4918, 4791, 6578)
+// yang dipakai server task: parsing body PROPFIND/REPORT dan menulis multistatus.
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Namespace XML
+const (
+	NSDAV    = "DAV:"
+	NSCalDAV = "urn:ietf:params:xml:ns:caldav"
+	NSCS     = "http://calendarserver.org/ns/"
+	NSApple  = "http://apple.com/ns/ical/"
+)
+
+// prefixes - prefix tetap di root multistatus; namespace lain dideklarasikan per elemen
+var prefixes = map[string]string{
+	NSDAV:    "d",
+	NSCalDAV: "c",
+	NSCS:     "cs",
+	NSApple:  "ical",
+}
+
+// maxBody - body PROPFIND/REPORT maksimum
+const maxBody = 1 << 20
+
+// Name - nama property, mis. Name(NSDAV, "getetag")
+func Name(space, local string) xml.Name {
+	return xml.Name{Space: space, Local: local}
+}
+
+// Propfind - body PROPFIND. Body kosong sama dengan allprop.
+type Propfind struct {
+	AllProp  bool
+	PropName bool
+	Props    []xml.Name
+}
+
+// Query - body REPORT calendar-query, calendar-multiget atau sync-collection
+type Query struct {
+	Type  string // nama elemen root: calendar-query, calendar-multiget, sync-collection
+	Props []xml.Name
+
+	// calendar-multiget
+	Hrefs []string
+
+	// calendar-query: komponen yang diminta (VTODO), rentang waktu dan task yang
+	// belum selesai (prop-filter COMPLETED is-not-defined)
+	Component    string
+	Start, End   *time.Time
+	NotCompleted bool
+
+	// sync-collection
+	SyncToken string
+}
+
+// Report types
+const (
+	CalendarQuery    = "calendar-query"
+	CalendarMultiget = "calendar-multiget"
+	SyncCollection   = "sync-collection"
+)
+
+// ErrUnsupportedReport - REPORT selain tiga di atas
+var ErrUnsupportedReport = errors.New("unsupported report")
+
+// ReadPropfind - baca body PROPFIND
+func ReadPropfind(r io.Reader) (Propfind, error) {
+	var pf Propfind
+	root, err := readXML(r)
+	if err != nil {
+		return pf, err
+	}
+	if root == nil {
+		pf.AllProp = true
+		return pf, nil
+	}
+	if root.XMLName != Name(NSDAV, "propfind") {
+		return pf, fmt.Errorf("expected DAV:propfind, got %s", root.XMLName.Local)
+	}
+	for _, child := range root.Children {
+		switch child.XMLName {
+		case Name(NSDAV, "allprop"):
+			pf.AllProp = true
+		case Name(NSDAV, "propname"):
+			pf.PropName = true
+		case Name(NSDAV, "prop"):
+			pf.Props = child.names()
+		}
+	}
+	if !pf.AllProp && !pf.PropName && pf.Props == nil {
+		return pf, errors.New("propfind needs prop, allprop or propname")
+	}
+	return pf, nil
+}
+
+// ReadReport - baca body REPORT
+func ReadReport(r io.Reader) (Query, error) {
+	var q Query
+	root, err := readXML(r)
+	if err != nil {
+		return q, err
+	}
+	if root == nil {
+		return q, errors.New("REPORT needs a body")
+	}
+
+	switch root.XMLName {
+	case Name(NSCalDAV, CalendarQuery):
+		q.Type = CalendarQuery
+	case Name(NSCalDAV, CalendarMultiget):
+		q.Type = CalendarMultiget
+	case Name(NSDAV, SyncCollection):
+		q.Type = SyncCollection
+	default:
+		return q, ErrUnsupportedReport
+	}
+
+	for _, child := range root.Children {
+		switch child.XMLName {
+		case Name(NSDAV, "prop"):
+			q.Props = child.names()
+		case Name(NSDAV, "href"):
+			q.Hrefs = append(q.Hrefs, strings.TrimSpace(child.Text))
+		case Name(NSDAV, "sync-token"):
+			q.SyncToken = strings.TrimSpace(child.Text)
+		case Name(NSCalDAV, "filter"):
+			if err := q.readFilter(child); err != nil {
+				return q, err
+			}
+		}
+	}
+	return q, nil
+}
+
+// readFilter - comp-filter VCALENDAR > comp-filter VTODO dengan time-range dan
+// prop-filter COMPLETED is-not-defined. Filter lain diabaikan, jadi hasilnya
+// bisa lebih banyak dari yang diminta (client tetap memfilter sendiri).
+func (q *Query) readFilter(filter *element) error {
+	for _, cal := range filter.Children {
+		if cal.XMLName != Name(NSCalDAV, "comp-filter") {
+			continue
+		}
+		for _, comp := range cal.Children {
+			if comp.XMLName != Name(NSCalDAV, "comp-filter") {
+				continue
+			}
+			q.Component = strings.ToUpper(comp.attr("name"))
+			for _, f := range comp.Children {
+				switch {
+				case f.XMLName == Name(NSCalDAV, "time-range"):
+					var err error
+					if q.Start, err = parseUTC(f.attr("start")); err != nil {
+						return err
+					}
+					if q.End, err = parseUTC(f.attr("end")); err != nil {
+						return err
+					}
+				case f.XMLName == Name(NSCalDAV, "prop-filter") && strings.EqualFold(f.attr("name"), "COMPLETED"):
+					for _, p := range f.Children {
+						if p.XMLName == Name(NSCalDAV, "is-not-defined") {
+							q.NotCompleted = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func parseUTC(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("20060102T150405Z", s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time-range %q", s)
+	}
+	return &t, nil
+}
+
+// element - pohon XML generik
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []*element `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+func (e *element) names() []xml.Name {
+	names := make([]xml.Name, 0, len(e.Children))
+	for _, child := range e.Children {
+		names = append(names, child.XMLName)
+	}
+	return names
+}
+
+func (e *element) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// readXML - nil kalau body kosong
+func readXML(r io.Reader) (*element, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBody+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBody {
+		return nil, errors.New("request body too large")
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return nil, nil
+	}
+	var root element
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid XML: %v", err)
+	}
+	return &root, nil
+}
+
+// Props - nilai property satu resource
+type Props map[xml.Name]string
+
+// Set - isi property dengan XML mentah (gunakan Text/Href untuk membuatnya)
+func (p Props) Set(name xml.Name, inner string) { p[name] = inner }
+
+// Multistatus - respons 207
+type Multistatus struct {
+	responses []string
+	syncToken string
+}
+
+// Add - satu resource dengan property yang diminta. Kalau names nil (allprop)
+// semua property ditulis; propname hanya menulis nama. Property yang tidak ada
+// masuk propstat 404.
+func (m *Multistatus) Add(href string, props Props, names []xml.Name, nameOnly bool) {
+	if names == nil {
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if names[i].Space != names[j].Space {
+				return names[i].Space < names[j].Space
+			}
+			return names[i].Local < names[j].Local
+		})
+	}
+
+	var found, missing strings.Builder
+	for _, name := range names {
+		inner, ok := props[name]
+		switch {
+		case !ok:
+			missing.WriteString(emptyElement(name))
+		case nameOnly || inner == "":
+			found.WriteString(emptyElement(name))
+		default:
+			open, end := tags(name)
+			found.WriteString(open + inner + end)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<d:response>" + Href(href))
+	if found.Len() > 0 {
+		b.WriteString("<d:propstat><d:prop>" + found.String() + "</d:prop>" + status(http.StatusOK) + "</d:propstat>")
+	}
+	if missing.Len() > 0 {
+		b.WriteString("<d:propstat><d:prop>" + missing.String() + "</d:prop>" + status(http.StatusNotFound) + "</d:propstat>")
+	}
+	b.WriteString("</d:response>")
+	m.responses = append(m.responses, b.String())
+}
+
+// AddStatus - resource tanpa property, mis. 404 untuk href yang sudah dihapus
+func (m *Multistatus) AddStatus(href string, code int) {
+	m.responses = append(m.responses, "<d:response>"+Href(href)+status(code)+"</d:response>")
+}
+
+// SetSyncToken - token baru untuk respons sync-collection
+func (m *Multistatus) SetSyncToken(token string) { m.syncToken = token }
+
+// Write - tulis 207 Multi-Status
+func (m *Multistatus) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus`)
+	for _, space := range []string{NSDAV, NSCalDAV, NSCS, NSApple} {
+		b.WriteString(` xmlns:` + prefixes[space] + `="` + space + `"`)
+	}
+	b.WriteString(">")
+	for _, r := range m.responses {
+		b.WriteString(r)
+	}
+	if m.syncToken != "" {
+		b.WriteString("<d:sync-token>" + Text(m.syncToken) + "</d:sync-token>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Text - escape teks untuk isi elemen
+func Text(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// Href - elemen DAV:href
+func Href(href string) string {
+	return "<d:href>" + Text(href) + "</d:href>"
+}
+
+// Element - elemen kosong dengan nama property, mis. untuk resourcetype
+func Element(space, local string) string {
+	return emptyElement(Name(space, local))
+}
+
+func emptyElement(name xml.Name) string {
+	open, _ := tags(name)
+	return strings.TrimSuffix(open, ">") + "/>"
+}
+
+func tags(name xml.Name) (string, string) {
+	if prefix, ok := prefixes[name.Space]; ok {
+		return "<" + prefix + ":" + name.Local + ">", "</" + prefix + ":" + name.Local + ">"
+	}
+	return `<x:` + name.Local + ` xmlns:x="` + Text(name.Space) + `">`, "</x:" + name.Local + ">"
+}
+
+func status(code int) string {
+	return "<d:status>HTTP/1.1 " + fmt.Sprint(code) + " " + http.StatusText(code) + "</d:status>"
+}
@@ -7,8 +7,15 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// Connect - buka database sesuai skema DATABASE_URL: sqlite://path (atau sqlite:,
+// file:) untuk file SQLite, selain itu Postgres
 func Connect(databaseURL string) *sql.DB {
-	db, err := sql.Open("postgres", databaseURL)
+	driverName, dsn := "postgres", databaseURL
+	if path, ok := sqlitePath(databaseURL); ok {
+		driverName, dsn = sqliteDriverName, sqliteDSN(path)
+	}
+
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
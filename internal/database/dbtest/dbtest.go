@@ -0,0 +1,43 @@
+// Package dbtest menyiapkan database SQLite sungguhan untuk test package yang
+// memakai *sql.DB langsung (middleware, worker, migrasi).
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/migrate"
+)
+
+// Open - database SQLite baru di direktori sementara test dengan semua migrasi
+// sudah diterapkan; ditutup otomatis saat test selesai
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	db := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "taskflow.db"))
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrate.New(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// User - buat user dengan email unik dan kembalikan id-nya
+func User(t testing.TB, db *sql.DB, email string) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(
+		"INSERT INTO users (email, password, name) VALUES ($1, 'x', $1) RETURNING id", email,
+	).Scan(&id)
+	if err != nil {
+		t.Fatalf("create user %s: %v", email, err)
+	}
+	return id
+}
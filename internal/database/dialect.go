@@ -0,0 +1,56 @@
+package database
+
+import "database/sql"
+
+// Dialect - dialek SQL database. Query yang berbeda antara Postgres dan SQLite
+// ditulis lengkap untuk keduanya di tempat query itu dipakai; driver SQLite tidak
+// menerjemahkan apa pun selain placeholder $N (lihat rebind).
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	SQLite
+)
+
+// DialectOf - dialek database db; nil (test tanpa database) dianggap Postgres
+func DialectOf(db *sql.DB) Dialect {
+	if db != nil && IsSQLite(db) {
+		return SQLite
+	}
+	return Postgres
+}
+
+func (d Dialect) String() string {
+	if d == SQLite {
+		return "sqlite"
+	}
+	return "postgres"
+}
+
+// Pick - query (atau potongan query) untuk dialek d
+func (d Dialect) Pick(postgres, sqlite string) string {
+	if d == SQLite {
+		return sqlite
+	}
+	return postgres
+}
+
+// LockRows - klausa penguncian baris (mis. "FOR UPDATE SKIP LOCKED") di akhir
+// SELECT. SQLite tidak punya row lock: setiap transaksi dibuka dengan BEGIN
+// IMMEDIATE (lihat sqliteDSN) dan UPDATE ... WHERE id IN (SELECT ...) berjalan
+// di bawah write lock database, jadi baris yang sama tidak bisa diklaim dua kali.
+func (d Dialect) LockRows(clause string) string {
+	if d == SQLite {
+		return ""
+	}
+	return " " + clause
+}
+
+// InArray - predicate expr ada di array param (pq.Array). SQLite menyimpan dan
+// menerima array sebagai literal array Postgres, dibaca dengan pg_array_json.
+func (d Dialect) InArray(expr, param string) string {
+	if d == SQLite {
+		return expr + " IN (SELECT value FROM json_each(pg_array_json(" + param + ")))"
+	}
+	return expr + " = ANY(" + param + ")"
+}
@@ -0,0 +1,401 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	sqliteDriverName = "taskflow-sqlite"
+	// sqliteTimeFormat - format waktu yang disimpan; urutan teksnya sama dengan urutan waktunya
+	sqliteTimeFormat = "2006-01-02 15:04:05.999999999"
+	// notifyBuffer - notifikasi yang belum dibaca hub sebelum ada yang dibuang
+	notifyBuffer = 1024
+)
+
+// sqliteDefaults - penulis lain menunggu, bukan langsung gagal
+var sqliteDefaults = []string{"_busy_timeout=5000", "_foreign_keys=1", "_journal_mode=WAL"}
+
+// sqliteTxLock - setiap transaksi langsung mengambil write lock database. Ini
+// pengganti FOR UPDATE dan advisory lock Postgres (lihat Dialect.LockRows), jadi
+// tidak bisa diubah lewat DATABASE_URL.
+const sqliteTxLock = "_txlock=immediate"
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteDriver{notify: make(chan *pq.Notification, notifyBuffer)})
+}
+
+// sqlitePath - path file dari DATABASE_URL sqlite://, sqlite: atau file:
+func sqlitePath(databaseURL string) (string, bool) {
+	for _, prefix := range []string{"sqlite://", "sqlite:", "file:"} {
+		if strings.HasPrefix(databaseURL, prefix) {
+			return strings.TrimPrefix(databaseURL, prefix), true
+		}
+	}
+	return "", false
+}
+
+// sqliteDSN - DSN go-sqlite3; opsi dari URL menang atas default, kecuali
+// sqliteTxLock (go-sqlite3 memakai nilai pertama)
+func sqliteDSN(path string) string {
+	params := []string{sqliteTxLock}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		params = append(params, path[i+1:])
+		path = path[:i]
+	}
+	return "file:" + path + "?" + strings.Join(append(params, sqliteDefaults...), "&")
+}
+
+// sqliteDriver - driver SQLite aplikasi. Query SQLite ditulis sendiri oleh
+// pemanggilnya (lihat Dialect); driver hanya mengganti placeholder dan
+// mendaftarkan pg_notify dan pg_array_json sebagai fungsi Go per koneksi.
+type sqliteDriver struct {
+	notify   chan *pq.Notification
+	overflow atomic.Bool
+}
+
+func (d *sqliteDriver) Open(dsn string) (driver.Conn, error) {
+	c := &sqliteConn{driver: d}
+	inner := &sqlite3.SQLiteDriver{ConnectHook: c.register}
+	conn, err := inner.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn.(*sqlite3.SQLiteConn)
+	return c, nil
+}
+
+// publish - kirim notifikasi ke hub tanpa pernah memblokir penulis. Kalau buffer
+// penuh notifikasi dibuang, lalu hub dikirimi nil (sama seperti pq.Listener yang
+// tersambung lagi) supaya ia mengambil event yang terlewat dari tabel.
+func (d *sqliteDriver) publish(pending []*pq.Notification) {
+	if d.overflow.Load() {
+		select {
+		case d.notify <- nil:
+			d.overflow.Store(false)
+		default:
+		}
+	}
+	for _, n := range pending {
+		select {
+		case d.notify <- n:
+		default:
+			d.overflow.Store(true)
+		}
+	}
+}
+
+// sqliteConn - satu koneksi SQLite. Notifikasi ditahan sampai transaksinya commit,
+// seperti NOTIFY di Postgres.
+type sqliteConn struct {
+	driver *sqliteDriver
+	conn   *sqlite3.SQLiteConn
+
+	inTx    bool
+	pending []*pq.Notification
+}
+
+// register - fungsi yang dipanggil query SQLite aplikasi: pg_notify(channel,
+// payload) seperti di Postgres, dan pg_array_json untuk kolom array
+func (c *sqliteConn) register(conn *sqlite3.SQLiteConn) error {
+	functions := []struct {
+		name string
+		impl interface{}
+		pure bool
+	}{
+		{"pg_notify", c.queueNotify, false},
+		{"pg_array_json", pgArrayJSON, true},
+	}
+	for _, f := range functions {
+		if err := conn.RegisterFunc(f.name, f.impl, f.pure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *sqliteConn) queueNotify(channel, payload interface{}) string {
+	n := &pq.Notification{Channel: sqliteText(channel), Extra: sqliteText(payload)}
+	for _, p := range c.pending {
+		// Postgres juga menggabungkan NOTIFY yang sama dalam satu transaksi
+		if *p == *n {
+			return n.Extra
+		}
+	}
+	c.pending = append(c.pending, n)
+	return n.Extra
+}
+
+// flush - kirim notifikasi yang ditahan kalau tidak sedang dalam transaksi
+func (c *sqliteConn) flush() {
+	if c.inTx || len(c.pending) == 0 {
+		return
+	}
+	c.driver.publish(c.pending)
+	c.pending = nil
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.PrepareContext(ctx, rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteStmt{Stmt: stmt, conn: c}, nil
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.conn.ExecContext(ctx, rebind(query), args)
+	c.flush()
+	return result, err
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.conn.QueryContext(ctx, rebind(query), args)
+	if err != nil {
+		c.flush()
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows, conn: c}, nil
+}
+
+func (c *sqliteConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx - level isolasi diabaikan; transaksi SQLite selalu serializable
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.inTx = true
+	return &sqliteTx{Tx: tx, conn: c}, nil
+}
+
+func (c *sqliteConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+func (c *sqliteConn) Close() error {
+	return c.conn.Close()
+}
+
+// CheckNamedValue - waktu disimpan sebagai teks UTC dengan format tetap supaya
+// perbandingan dan ORDER BY pada kolom TIMESTAMP benar
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if v, ok := nv.Value.(driver.Valuer); ok {
+		value, err := v.Value()
+		if err != nil {
+			return err
+		}
+		nv.Value = value
+	}
+	switch v := nv.Value.(type) {
+	case time.Time:
+		nv.Value = v.UTC().Format(sqliteTimeFormat)
+		return nil
+	case *time.Time:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = v.UTC().Format(sqliteTimeFormat)
+		}
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+type sqliteTx struct {
+	driver.Tx
+	conn *sqliteConn
+}
+
+func (tx *sqliteTx) Commit() error {
+	err := tx.Tx.Commit()
+	tx.conn.inTx = false
+	if err != nil {
+		tx.conn.pending = nil
+		return err
+	}
+	tx.conn.flush()
+	return nil
+}
+
+func (tx *sqliteTx) Rollback() error {
+	tx.conn.inTx = false
+	tx.conn.pending = nil
+	return tx.Tx.Rollback()
+}
+
+type sqliteStmt struct {
+	driver.Stmt
+	conn *sqliteConn
+}
+
+func (s *sqliteStmt) Exec(args []driver.Value) (driver.Result, error) {
+	result, err := s.Stmt.Exec(args)
+	s.conn.flush()
+	return result, err
+}
+
+func (s *sqliteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.Stmt.Query(args)
+	if err != nil {
+		s.conn.flush()
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows, conn: s.conn}, nil
+}
+
+// sqliteRows - pg_notify di RETURNING baru jalan saat baris dibaca, jadi
+// notifikasi di luar transaksi dikirim setelah rows ditutup
+type sqliteRows struct {
+	driver.Rows
+	conn *sqliteConn
+}
+
+func (r *sqliteRows) Close() error {
+	err := r.Rows.Close()
+	r.conn.flush()
+	return err
+}
+
+// rebind - placeholder $N jadi ?N. SQLite juga menerima $N, tapi sebagai
+// parameter bernama yang nomornya mengikuti urutan kemunculan, bukan N. String
+// literal, identifier berkutip dan komentar tidak diubah.
+func rebind(query string) string {
+	if !strings.Contains(query, "$") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		end := i + 1
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			// '' di dalam literal = literal berikutnya yang langsung menyambung
+			for end < len(query) && query[end] != c {
+				end++
+			}
+			end++
+		case strings.HasPrefix(query[i:], "--"):
+			end = strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			end += i
+		case strings.HasPrefix(query[i:], "/*"):
+			end = strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i - 4
+			}
+			end += i + 4
+		case c == '$' && end < len(query) && query[end] >= '0' && query[end] <= '9':
+			b.WriteByte('?')
+			continue
+		}
+		if end > len(query) {
+			end = len(query)
+		}
+		b.WriteString(query[i:end])
+		i = end - 1
+	}
+	return b.String()
+}
+
+// pgArrayJSON - literal array Postgres ({1,2} atau {"a","b"}) jadi array JSON.
+// Elemen tanpa kutip yang berupa angka tetap angka supaya cocok dengan kolom INTEGER.
+func pgArrayJSON(value interface{}) (string, error) {
+	if value == nil {
+		return "[]", nil
+	}
+	literal := strings.TrimSpace(sqliteText(value))
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return "", strconv.ErrSyntax
+	}
+
+	elems := []interface{}{}
+	body := literal[1 : len(literal)-1]
+	for i := 0; i < len(body); {
+		if body[i] == '"' {
+			var b strings.Builder
+			i++
+			for ; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				b.WriteByte(body[i])
+			}
+			elems = append(elems, b.String())
+			i += 2 // kutip penutup dan koma
+			continue
+		}
+
+		end := strings.IndexByte(body[i:], ',')
+		if end < 0 {
+			end = len(body) - i
+		}
+		raw := strings.TrimSpace(body[i : i+end])
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			elems = append(elems, n)
+		} else if strings.EqualFold(raw, "NULL") {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, raw)
+		}
+		i += end + 1
+	}
+
+	out, err := json.Marshal(elems)
+	return string(out), err
+}
+
+func sqliteText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Notifications - channel notifikasi pg_notify untuk database SQLite; nil untuk
+// Postgres (pakai pq.Listener). Notifikasi hanya terlihat di proses ini.
+func Notifications(db *sql.DB) <-chan *pq.Notification {
+	if d, ok := db.Driver().(*sqliteDriver); ok {
+		return d.notify
+	}
+	return nil
+}
+
+// IsSQLite - db dibuka dengan backend SQLite
+func IsSQLite(db *sql.DB) bool {
+	_, ok := db.Driver().(*sqliteDriver)
+	return ok
+}
+
+var _ interface {
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.NamedValueChecker
+	driver.Pinger
+} = (*sqliteConn)(nil)
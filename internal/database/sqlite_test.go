@@ -0,0 +1,63 @@
+package database
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "placeholders out of order",
+			query: "SELECT * FROM tasks WHERE id = $2 AND user_id = $1 OR owner_id = $1",
+			want:  "SELECT * FROM tasks WHERE id = ?2 AND user_id = ?1 OR owner_id = ?1",
+		},
+		{
+			name:  "string literal",
+			query: "SELECT '$1', 'it''s $2' FROM t WHERE a = $3",
+			want:  "SELECT '$1', 'it''s $2' FROM t WHERE a = ?3",
+		},
+		{
+			name:  "quoted identifier",
+			query: `SELECT "col$1" FROM t WHERE a = $1`,
+			want:  `SELECT "col$1" FROM t WHERE a = ?1`,
+		},
+		{
+			name:  "comments",
+			query: "SELECT a -- $1\nFROM t /* $2 */ WHERE a = $1",
+			want:  "SELECT a -- $1\nFROM t /* $2 */ WHERE a = ?1",
+		},
+		{
+			name:  "dollar without digit",
+			query: "SELECT '$' || $1, $",
+			want:  "SELECT '$' || ?1, $",
+		},
+		{
+			name:  "unterminated literal",
+			query: "SELECT $1, 'abc $2",
+			want:  "SELECT ?1, 'abc $2",
+		},
+		{
+			name:  "unterminated comment",
+			query: "SELECT $10 /* $2",
+			want:  "SELECT ?10 /* $2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebind(tt.query); got != tt.want {
+				t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteDSNKeepsImmediateTxLock(t *testing.T) {
+	got := sqliteDSN("/tmp/t.db?_txlock=deferred&_busy_timeout=100")
+	want := "file:/tmp/t.db?_txlock=immediate&_txlock=deferred&_busy_timeout=100&_busy_timeout=5000&_foreign_keys=1&_journal_mode=WAL"
+	if got != want {
+		t.Errorf("sqliteDSN = %q, want %q", got, want)
+	}
+}
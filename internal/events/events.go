@@ -0,0 +1,63 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+type Type string
+
+const (
+	TaskCreated   Type = "task.created"
+	TaskUpdated   Type = "task.updated"
+	TaskCompleted Type = "task.completed"
+	TaskDeleted   Type = "task.deleted"
+	TaskAssigned  Type = "task.assigned"
+	TaskMentioned Type = "task.mentioned"
+)
+
+// Event - sesuatu yang terjadi pada task
+type Event struct {
+	Type       Type        `json:"type"`
+	ActorID    int         `json:"actor_id"` // 0 = lewat share link publik
+	Task       models.Task `json:"task"`
+	OccurredAt time.Time   `json:"occurred_at"`
+
+	// Mentioned - user yang baru di-mention, hanya untuk TaskMentioned
+	Mentioned []int `json:"mentioned,omitempty"`
+}
+
+type Handler func(Event)
+
+// Bus - event bus in-process. Handler dipanggil secara sinkron di goroutine
+// yang mem-publish, jadi handler yang lambat harus membuat goroutine sendiri.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+func (b *Bus) Publish(e Event) {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
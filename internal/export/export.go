@@ -0,0 +1,376 @@
+// Package export menulis seluruh data satu user sebagai JSON, arsip CSV (zip)
+// atau checklist Markdown. Data dibaca baris demi baris dari Source dan langsung
+// ditulis ke io.Writer, jadi akun besar tidak ditampung di memori.
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+// Format export
+const (
+	JSON     = "json"
+	CSV      = "csv"
+	Markdown = "markdown"
+)
+
+// FormatVersion - naik kalau struktur export JSON berubah
+const FormatVersion = 1
+
+// ErrUnknownFormat - format bukan json, csv atau markdown
+var ErrUnknownFormat = errors.New("format must be json, csv or markdown")
+
+// Source - data satu user. Setiap method memanggil fn untuk setiap baris
+// dan berhenti di error pertama (dari query atau dari fn).
+type Source interface {
+	Profile() (models.User, error)
+	Workspaces(fn func(models.Workspace) error) error
+	Projects(fn func(models.Project) error) error
+	Statuses(fn func(models.TaskStatus) error) error
+	// Tasks - urut per board (personal dulu), lalu project, status dan posisi
+	Tasks(fn func(models.Task) error) error
+	Reminders(fn func(models.TaskReminder) error) error
+	Notifications(fn func(models.Notification) error) error
+	Webhooks(fn func(models.Webhook) error) error
+}
+
+// ContentType dan Filename - header response untuk format
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "application/zip"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	}
+	return "application/json"
+}
+
+func Filename(format string, at time.Time) string {
+	ext := map[string]string{JSON: "json", CSV: "zip", Markdown: "md"}[format]
+	return "taskflow-export-" + at.Format("20060102-150405") + "." + ext
+}
+
+func Valid(format string) bool {
+	return format == JSON || format == CSV || format == Markdown
+}
+
+// Write - tulis export lengkap dalam format ke w
+func Write(w io.Writer, format string, src Source) error {
+	buf := bufio.NewWriterSize(w, 64<<10)
+	var err error
+	switch format {
+	case JSON:
+		err = writeJSON(buf, src)
+	case CSV:
+		err = writeCSV(buf, src)
+	case Markdown:
+		err = writeMarkdown(buf, src)
+	default:
+		return ErrUnknownFormat
+	}
+	if err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// writeJSON - satu object; task list-nya bisa diimpor lagi dengan format json
+func writeJSON(w io.Writer, src Source) error {
+	user, err := src.Profile()
+	if err != nil {
+		return err
+	}
+	header, err := json.Marshal(struct {
+		Version    int         `json:"version"`
+		ExportedAt time.Time   `json:"exported_at"`
+		User       models.User `json:"user"`
+	}{FormatVersion, time.Now().UTC(), user})
+	if err != nil {
+		return err
+	}
+	// Buka object tanpa kurung tutup, lalu tambahkan array satu per satu
+	if _, err := w.Write(header[:len(header)-1]); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		name string
+		each func(func(interface{}) error) error
+	}{
+		{"workspaces", func(fn func(interface{}) error) error {
+			return src.Workspaces(func(v models.Workspace) error { return fn(v) })
+		}},
+		{"projects", func(fn func(interface{}) error) error {
+			return src.Projects(func(v models.Project) error { return fn(v) })
+		}},
+		{"statuses", func(fn func(interface{}) error) error {
+			return src.Statuses(func(v models.TaskStatus) error { return fn(v) })
+		}},
+		{"tasks", func(fn func(interface{}) error) error {
+			return src.Tasks(func(v models.Task) error { return fn(v) })
+		}},
+		{"reminders", func(fn func(interface{}) error) error {
+			return src.Reminders(func(v models.TaskReminder) error { return fn(v) })
+		}},
+		{"notifications", func(fn func(interface{}) error) error {
+			return src.Notifications(func(v models.Notification) error { return fn(v) })
+		}},
+		{"webhooks", func(fn func(interface{}) error) error {
+			return src.Webhooks(func(v models.Webhook) error { return fn(v) })
+		}},
+	}
+	for _, section := range sections {
+		if _, err := fmt.Fprintf(w, ",%q:[", section.name); err != nil {
+			return err
+		}
+		first := true
+		err := section.each(func(v interface{}) error {
+			item, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := w.Write([]byte{','}); err != nil {
+					return err
+				}
+			}
+			first = false
+			_, err = w.Write(item)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{']'}); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write([]byte("}\n"))
+	return err
+}
+
+// writeCSV - zip berisi satu file CSV per jenis data. tasks.csv memakai header
+// yang dikenali import CSV.
+func writeCSV(w io.Writer, src Source) error {
+	archive := zip.NewWriter(w)
+
+	file := func(name string, header []string, rows func(write func([]string) error) error) error {
+		f, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		out := csv.NewWriter(f)
+		if err := out.Write(header); err != nil {
+			return err
+		}
+		if err := rows(out.Write); err != nil {
+			return err
+		}
+		out.Flush()
+		return out.Error()
+	}
+
+	user, err := src.Profile()
+	if err != nil {
+		return err
+	}
+	err = file("profile.csv", []string{"id", "name", "email", "created_at"}, func(write func([]string) error) error {
+		return write([]string{strconv.Itoa(user.ID), user.Name, user.Email, formatTime(&user.CreatedAt)})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("workspaces.csv", []string{"id", "name", "role", "owner_id", "created_at"}, func(write func([]string) error) error {
+		return src.Workspaces(func(ws models.Workspace) error {
+			return write([]string{strconv.Itoa(ws.ID), ws.Name, ws.Role, strconv.Itoa(ws.OwnerID), formatTime(&ws.CreatedAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("projects.csv", []string{"id", "workspace_id", "name", "description", "color", "is_archived", "created_at"}, func(write func([]string) error) error {
+		return src.Projects(func(p models.Project) error {
+			return write([]string{strconv.Itoa(p.ID), formatID(p.WorkspaceID), p.Name, p.Description, p.Color,
+				strconv.FormatBool(p.IsArchived), formatTime(&p.CreatedAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("statuses.csv", []string{"id", "workspace_id", "project_id", "key", "name", "position", "is_terminal"}, func(write func([]string) error) error {
+		return src.Statuses(func(s models.TaskStatus) error {
+			return write([]string{strconv.Itoa(s.ID), formatID(s.WorkspaceID), formatID(s.ProjectID), s.Key, s.Name,
+				strconv.Itoa(s.Position), strconv.FormatBool(s.IsTerminal)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	taskHeader := []string{"id", "workspace_id", "project_id", "assignee_id", "title", "description", "priority",
+		"category", "status", "completed", "due_date", "position", "version", "created_at", "updated_at"}
+	err = file("tasks.csv", taskHeader, func(write func([]string) error) error {
+		return src.Tasks(func(t models.Task) error {
+			description := ""
+			if t.Description != nil {
+				description = *t.Description
+			}
+			return write([]string{strconv.Itoa(t.ID), formatID(t.WorkspaceID), formatID(t.ProjectID),
+				formatID(t.AssigneeID), t.Title, description, string(t.Priority), string(t.Category), t.Status,
+				strconv.FormatBool(t.IsCompleted), formatTime(t.DueDate), t.Position, strconv.Itoa(t.Version),
+				formatTime(&t.CreatedAt), formatTime(&t.UpdatedAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("reminders.csv", []string{"id", "task_id", "remind_at", "offset_minutes", "fire_at", "channels", "sent_at"}, func(write func([]string) error) error {
+		return src.Reminders(func(r models.TaskReminder) error {
+			offset := ""
+			if r.OffsetMinutes != nil {
+				offset = strconv.Itoa(*r.OffsetMinutes)
+			}
+			return write([]string{strconv.Itoa(r.ID), strconv.Itoa(r.TaskID), formatTime(r.RemindAt), offset,
+				formatTime(r.FireAt), strings.Join(r.Channels, ","), formatTime(r.SentAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("notifications.csv", []string{"id", "type", "title", "body", "task_id", "read_at", "created_at"}, func(write func([]string) error) error {
+		return src.Notifications(func(n models.Notification) error {
+			return write([]string{strconv.Itoa(n.ID), n.Type, n.Title, n.Body, formatID(n.TaskID),
+				formatTime(n.ReadAt), formatTime(&n.CreatedAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = file("webhooks.csv", []string{"id", "workspace_id", "url", "events", "description", "is_active", "created_at"}, func(write func([]string) error) error {
+		return src.Webhooks(func(wh models.Webhook) error {
+			return write([]string{strconv.Itoa(wh.ID), formatID(wh.WorkspaceID), wh.URL, strings.Join(wh.Events, ","),
+				wh.Description, strconv.FormatBool(wh.IsActive), formatTime(&wh.CreatedAt)})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// writeMarkdown - checklist per board dan project
+func writeMarkdown(w io.Writer, src Source) error {
+	user, err := src.Profile()
+	if err != nil {
+		return err
+	}
+
+	// Nama workspace dan project untuk judul bagian; jumlahnya kecil dibanding task
+	workspaces := map[int]string{}
+	if err := src.Workspaces(func(ws models.Workspace) error {
+		workspaces[ws.ID] = ws.Name
+		return nil
+	}); err != nil {
+		return err
+	}
+	projects := map[int]string{}
+	if err := src.Projects(func(p models.Project) error {
+		projects[p.ID] = p.Name
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "# TaskFlow export: %s <%s>\n\nExported %s.\n", user.Name, user.Email,
+		time.Now().UTC().Format(time.RFC1123)); err != nil {
+		return err
+	}
+
+	var board, project *int
+	started := false
+	return src.Tasks(func(t models.Task) error {
+		if !started || !sameID(board, t.WorkspaceID) {
+			title := "Personal"
+			if t.WorkspaceID != nil {
+				title = workspaces[*t.WorkspaceID]
+			}
+			if _, err := fmt.Fprintf(w, "\n## %s\n", markdownText(title)); err != nil {
+				return err
+			}
+			project = nil
+		}
+		if !started || !sameID(board, t.WorkspaceID) || !sameID(project, t.ProjectID) {
+			title := "No project"
+			if t.ProjectID != nil {
+				title = projects[*t.ProjectID]
+			}
+			if _, err := fmt.Fprintf(w, "\n### %s\n\n", markdownText(title)); err != nil {
+				return err
+			}
+		}
+		started = true
+		board, project = t.WorkspaceID, t.ProjectID
+
+		check := " "
+		if t.IsCompleted {
+			check = "x"
+		}
+		details := []string{string(t.Priority), string(t.Category), t.Status}
+		if t.DueDate != nil {
+			details = append(details, "due "+t.DueDate.UTC().Format("2006-01-02"))
+		}
+		if _, err := fmt.Fprintf(w, "- [%s] %s (%s)\n", check, markdownText(t.Title), strings.Join(details, ", ")); err != nil {
+			return err
+		}
+		if t.Description != nil && strings.TrimSpace(*t.Description) != "" {
+			for _, line := range strings.Split(strings.TrimSpace(*t.Description), "\n") {
+				if _, err := fmt.Fprintf(w, "  > %s\n", line); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// markdownText - satu baris teks tanpa karakter yang mengubah struktur checklist
+func markdownText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "#", `\#`).Replace(s)
+}
+
+func sameID(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func formatID(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(*id)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
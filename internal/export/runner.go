@@ -0,0 +1,215 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+)
+
+const (
+	// Retention - berapa lama export background bisa diunduh
+	Retention = 24 * time.Hour
+	// chunkSize - ukuran satu baris export_chunks
+	chunkSize = 1 << 20
+	// pollInterval - seberapa sering antrean dicek kalau tidak dibangunkan
+	pollInterval = 5 * time.Second
+	// lease - job running yang tidak menulis chunk selama ini diulang dari awal
+	lease = 2 * time.Minute
+)
+
+// SourceFunc - Source untuk satu user
+type SourceFunc func(ctx context.Context, userID int) Source
+
+// Runner - membuat export yang terlalu besar untuk di-stream dalam satu request.
+// Job diklaim dengan FOR UPDATE SKIP LOCKED dan lease seperti import.
+type Runner struct {
+	db      *sql.DB
+	dialect database.Dialect
+	source  SourceFunc
+	wake    chan struct{}
+}
+
+func NewRunner(db *sql.DB, source SourceFunc) *Runner {
+	return &Runner{db: db, dialect: database.DialectOf(db), source: source, wake: make(chan struct{}, 1)}
+}
+
+// Wake - ada job baru; mulai tanpa menunggu poll berikutnya
+func (r *Runner) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run - kerjakan job dan hapus export kadaluarsa sampai ctx dibatalkan
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			id, userID, format, ok, err := r.claim(ctx)
+			if err != nil {
+				log.Printf("export: claim: %v", err)
+				break
+			}
+			if !ok {
+				break
+			}
+			r.generate(ctx, id, userID, format)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		case <-prune.C:
+			if _, err := r.db.ExecContext(ctx, "DELETE FROM exports WHERE expires_at < $1", time.Now()); err != nil {
+				log.Printf("export: prune: %v", err)
+			}
+		}
+	}
+}
+
+// claim - ambil job pending, atau job running yang lease-nya habis
+func (r *Runner) claim(ctx context.Context) (id, userID int, format string, ok bool, err error) {
+	now := time.Now()
+	err = r.db.QueryRowContext(ctx,
+		`UPDATE exports SET status = $1, lease_until = $4
+		 WHERE id = (
+		     SELECT id FROM exports
+		     WHERE status = $2 OR (status = $1 AND lease_until < $3)
+		     ORDER BY id
+		     LIMIT 1`+r.dialect.LockRows("FOR UPDATE SKIP LOCKED")+`)
+		 RETURNING id, user_id, format`,
+		models.ExportRunning, models.ExportPending, now, now.Add(lease),
+	).Scan(&id, &userID, &format)
+	if err == sql.ErrNoRows {
+		return 0, 0, "", false, nil
+	}
+	return id, userID, format, err == nil, err
+}
+
+func (r *Runner) generate(ctx context.Context, id, userID int, format string) {
+	// Job yang diambil alih mulai dari awal
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM export_chunks WHERE export_id = $1", id); err != nil {
+		log.Printf("export: job %d: %v", id, err)
+		return
+	}
+
+	w := &chunkWriter{ctx: ctx, db: r.db, exportID: id}
+	err := Write(w, format, r.source(ctx, userID))
+	if err == nil {
+		err = w.flush()
+	}
+	if err != nil {
+		log.Printf("export: job %d: %v", id, err)
+		_, err = r.db.Exec(
+			`UPDATE exports SET status = $1, error = $2, finished_at = CURRENT_TIMESTAMP, lease_until = NULL
+			 WHERE id = $3`,
+			models.ExportFailed, "Failed to generate export", id,
+		)
+		if err != nil {
+			log.Printf("export: job %d fail: %v", id, err)
+		}
+		return
+	}
+
+	_, err = r.db.Exec(
+		`UPDATE exports SET status = $1, size = $2, finished_at = $3, expires_at = $4, lease_until = NULL
+		 WHERE id = $5`,
+		models.ExportCompleted, w.size, time.Now(), time.Now().Add(Retention), id,
+	)
+	if err != nil {
+		log.Printf("export: job %d finish: %v", id, err)
+	}
+}
+
+// chunkWriter - tulis ke export_chunks per chunkSize byte
+type chunkWriter struct {
+	ctx      context.Context
+	db       *sql.DB
+	exportID int
+	seq      int
+	size     int64
+	buf      []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		space := chunkSize - len(w.buf)
+		if space > len(p) {
+			space = len(p)
+		}
+		w.buf = append(w.buf, p[:space]...)
+		p = p[space:]
+		if len(w.buf) == chunkSize {
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// flush - simpan isi buffer sebagai chunk berikutnya dan perpanjang lease
+func (w *chunkWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	tx, err := w.db.BeginTx(w.ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(w.ctx,
+		"INSERT INTO export_chunks (export_id, seq, data) VALUES ($1, $2, $3)",
+		w.exportID, w.seq, w.buf,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(w.ctx,
+		"UPDATE exports SET lease_until = $1 WHERE id = $2",
+		time.Now().Add(lease), w.exportID,
+	); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	w.seq++
+	w.size += int64(len(w.buf))
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Copy - tulis file export yang sudah selesai ke w, satu chunk per query
+func Copy(ctx context.Context, db *sql.DB, w io.Writer, exportID int) error {
+	for seq := 0; ; seq++ {
+		var data []byte
+		err := db.QueryRowContext(ctx,
+			"SELECT data FROM export_chunks WHERE export_id = $1 AND seq = $2",
+			exportID, seq,
+		).Scan(&data)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+}
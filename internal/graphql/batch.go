@@ -0,0 +1,56 @@
+package graphql
+
+import "sync"
+
+// Batch - nilai untuk sekumpulan key yang diketahui di depan, mis. owner semua
+// task di satu hasil list. Get pertama memuat semua key dengan satu panggilan
+// fetch; graph-gophers menjalankan resolver secara paralel, jadi aman dipakai
+// bersama oleh semua item list.
+type Batch[K comparable, V any] struct {
+	keys  []K
+	fetch func(keys []K) (map[K]V, error)
+
+	once   sync.Once
+	values map[K]V
+	err    error
+}
+
+// NewBatch - fetch mengembalikan nilai per key; key yang tidak ada di map berarti null
+func NewBatch[K comparable, V any](fetch func(keys []K) (map[K]V, error), keys ...K) *Batch[K, V] {
+	seen := make(map[K]bool, len(keys))
+	var unique []K
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	return &Batch[K, V]{keys: unique, fetch: fetch}
+}
+
+// Get - nilai untuk key; ok false kalau key tidak ditemukan. Key di luar batch
+// dimuat sendiri-sendiri.
+func (b *Batch[K, V]) Get(key K) (V, bool, error) {
+	b.once.Do(func() {
+		if len(b.keys) > 0 {
+			b.values, b.err = b.fetch(b.keys)
+		}
+	})
+	if b.err != nil {
+		var zero V
+		return zero, false, b.err
+	}
+	if v, ok := b.values[key]; ok {
+		return v, true, nil
+	}
+	for _, k := range b.keys {
+		if k == key {
+			var zero V
+			return zero, false, nil
+		}
+	}
+
+	values, err := b.fetch([]K{key})
+	v, ok := values[key]
+	return v, ok, err
+}
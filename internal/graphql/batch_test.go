@@ -0,0 +1,69 @@
+package graphql
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBatchFetchesOnce(t *testing.T) {
+	var mu sync.Mutex
+	var calls [][]int
+	fetch := func(keys []int) (map[int]string, error) {
+		mu.Lock()
+		calls = append(calls, slices.Clone(keys))
+		mu.Unlock()
+		values := map[int]string{}
+		for _, k := range keys {
+			if k != 3 {
+				values[k] = string(rune('a' + k))
+			}
+		}
+		return values, nil
+	}
+
+	b := NewBatch(fetch, 1, 2, 1, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, ok, err := b.Get(2); v != "c" || !ok || err != nil {
+				t.Errorf("Get(2) = %q, %v, %v", v, ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, ok, err := b.Get(3); ok || err != nil {
+		t.Errorf("Get(3) = %v, %v; want not found", ok, err)
+	}
+	if v, ok, _ := b.Get(5); v != "f" || !ok {
+		t.Errorf("Get(5) = %q, %v", v, ok)
+	}
+	if len(calls) != 2 || !slices.Equal(calls[0], []int{1, 2, 3}) || !slices.Equal(calls[1], []int{5}) {
+		t.Errorf("fetch calls = %v, want [1 2 3] then [5]", calls)
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	b := NewBatch(func([]int) (map[int]string, error) { return nil, errors.New("down") }, 1)
+	if _, _, err := b.Get(1); err == nil || err.Error() != "down" {
+		t.Errorf("Get = %v, want the fetch error", err)
+	}
+}
+
+func TestEmptyBatchDoesNotFetch(t *testing.T) {
+	called := false
+	b := NewBatch(func(keys []int) (map[int]string, error) {
+		called = true
+		return map[int]string{keys[0]: "x"}, nil
+	})
+	if v, ok, _ := b.Get(7); v != "x" || !ok {
+		t.Errorf("Get(7) = %q, %v", v, ok)
+	}
+	if !called {
+		t.Error("key outside the batch not fetched")
+	}
+}
@@ -0,0 +1,257 @@
+// Package graphql - lapisan tipis di atas dua library GraphQL. Eksekusi,
+// introspeksi dan subscription memakai github.com/graph-gophers/graphql-go;
+// library itu hanya punya batas kedalaman dan tidak memberi akses ke AST query,
+// jadi sebelum dieksekusi query divalidasi dan dihitung biayanya dengan
+// github.com/vektah/gqlparser/v2 (parser yang juga dipakai gqlgen).
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	gophers "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Request - body POST /graphql
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response - hasil satu operation. Data tidak ditulis kalau operation gagal
+// sebelum dieksekusi (parse/validasi), dan null kalau field root non-null gagal.
+type Response = gophers.Response
+
+// NewError - error untuk dikembalikan resolver; extensions ikut ditulis di respons
+func NewError(message string, extensions map[string]interface{}) error {
+	return &resolverError{message: message, extensions: extensions}
+}
+
+type resolverError struct {
+	message    string
+	extensions map[string]interface{}
+}
+
+func (e *resolverError) Error() string { return e.message }
+
+// Extensions - dibaca graph-gophers untuk field "extensions" di error
+func (e *resolverError) Extensions() map[string]interface{} { return e.extensions }
+
+// ErrorResponse - respons tanpa data dengan satu error, mis. untuk body yang tidak valid
+func ErrorResponse(message string) *Response {
+	return &Response{Errors: []*gqlerrors.QueryError{{Message: message}}}
+}
+
+// Config - batas query. Nol berarti tanpa batas.
+type Config struct {
+	MaxDepth      int
+	MaxComplexity int
+	// ListSize - pengali biaya list tanpa argumen limit/first/last (default 10)
+	ListSize int
+}
+
+// Schema - schema yang dieksekusi graph-gophers dan salinannya di gqlparser untuk validasi
+type Schema struct {
+	exec   *gophers.Schema
+	schema *ast.Schema
+	config Config
+}
+
+// MustSchema - parse schema SDL dan cocokkan dengan resolver root; panic kalau
+// ada field yang tidak punya resolver
+func MustSchema(sdl string, resolver interface{}, config Config) *Schema {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema", Input: sdl})
+	if err != nil {
+		panic(err)
+	}
+	if config.ListSize == 0 {
+		config.ListSize = 10
+	}
+	return &Schema{
+		exec:   gophers.MustParseSchema(sdl, resolver, gophers.UseStringDescriptions()),
+		schema: schema,
+		config: config,
+	}
+}
+
+// Prepared - operation yang sudah lolos validasi dan batas query
+type Prepared struct {
+	schema *Schema
+	req    Request
+	kind   string
+}
+
+// Prepare - parse dan validasi request. Respons non-nil berisi error untuk client.
+func (s *Schema) Prepare(req Request) (*Prepared, *Response) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, ErrorResponse("Must provide query string.")
+	}
+	doc, err := parser.ParseQuery(&ast.Source{Name: "query", Input: req.Query})
+	if err != nil {
+		return nil, errorResponse(gqlerror.List{toGQLError(err)})
+	}
+	// Aturan validasi membandingkan field sepasang-sepasang; dokumen yang terlalu
+	// besar ditolak sebelum validasi
+	if countFields(doc) > maxFields {
+		return nil, ErrorResponse("Query is too large.")
+	}
+	if errs := validator.ValidateWithRules(s.schema, doc, nil); len(errs) > 0 {
+		return nil, errorResponse(errs)
+	}
+
+	var op *ast.OperationDefinition
+	switch {
+	case req.OperationName != "":
+		if op = doc.Operations.ForName(req.OperationName); op == nil {
+			return nil, ErrorResponse(fmt.Sprintf("Unknown operation named %q.", req.OperationName))
+		}
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	case len(doc.Operations) == 0:
+		return nil, ErrorResponse("Must provide an operation.")
+	default:
+		return nil, ErrorResponse("Must provide operation name if query contains multiple operations.")
+	}
+
+	vars, err := validator.VariableValues(s.schema, op, req.Variables)
+	if err != nil {
+		return nil, errorResponse(gqlerror.List{toGQLError(err)})
+	}
+	for _, def := range op.VariableDefinitions {
+		if err := s.checkInt(def.Type, vars[def.Variable]); err != "" {
+			return nil, errorResponse(gqlerror.List{gqlerror.ErrorPosf(def.Position, "Variable \"$%s\" got invalid value; %s", def.Variable, err)})
+		}
+	}
+	if errs := s.checkLimits(op, vars); len(errs) > 0 {
+		return nil, errorResponse(errs)
+	}
+
+	if req.Variables != nil {
+		req.Variables = execVariables(req.Variables).(map[string]interface{})
+	}
+	return &Prepared{schema: s, req: req, kind: string(op.Operation)}, nil
+}
+
+// Kind - query, mutation atau subscription
+func (p *Prepared) Kind() string { return p.kind }
+
+// Execute - jalankan query atau mutation. Field root mutation dijalankan berurutan.
+func (p *Prepared) Execute(ctx context.Context) *Response {
+	if p.kind == string(ast.Subscription) {
+		return ErrorResponse("Subscriptions must be executed with Subscribe.")
+	}
+	return p.schema.exec.Exec(ctx, p.req.Query, p.req.OperationName, p.req.Variables)
+}
+
+// Subscribe - satu hasil per event sampai ctx selesai atau sumber event ditutup.
+// Error dari resolver subscription dikirim sebagai hasil tunggal.
+func (p *Prepared) Subscribe(ctx context.Context) <-chan *Response {
+	out := make(chan *Response)
+	results, err := p.schema.exec.Subscribe(ctx, p.req.Query, p.req.OperationName, p.req.Variables)
+	if err != nil {
+		go func() {
+			defer close(out)
+			out <- ErrorResponse(err.Error())
+		}()
+		return out
+	}
+	go func() {
+		defer close(out)
+		for result := range results {
+			select {
+			case out <- result.(*Response):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// errorResponse - error validasi gqlparser dalam format respons graph-gophers
+func errorResponse(errs gqlerror.List) *Response {
+	resp := &Response{}
+	for _, e := range errs {
+		qe := &gqlerrors.QueryError{Message: e.Message, Extensions: e.Extensions}
+		for _, loc := range e.Locations {
+			qe.Locations = append(qe.Locations, gqlerrors.Location{Line: loc.Line, Column: loc.Column})
+		}
+		resp.Errors = append(resp.Errors, qe)
+	}
+	return resp
+}
+
+func toGQLError(err error) *gqlerror.Error {
+	if e, ok := err.(*gqlerror.Error); ok {
+		return e
+	}
+	return gqlerror.Wrap(err)
+}
+
+// checkInt - nilai Int di variable (juga di dalam list dan input object) harus
+// muat di 32 bit; gqlparser menerima int64
+func (s *Schema) checkInt(t *ast.Type, v interface{}) string {
+	switch {
+	case v == nil:
+		return ""
+	case t.Elem != nil:
+		list, _ := v.([]interface{})
+		for _, item := range list {
+			if err := s.checkInt(t.Elem, item); err != "" {
+				return err
+			}
+		}
+	case t.NamedType == "Int":
+		if n, ok := toInt(v); !ok || n < math.MinInt32 || n > math.MaxInt32 {
+			return fmt.Sprintf("Int cannot represent non 32-bit signed integer value: %v", v)
+		}
+	default:
+		def := s.schema.Types[t.NamedType]
+		fields, _ := v.(map[string]interface{})
+		if def == nil || def.Kind != ast.InputObject {
+			return ""
+		}
+		for _, field := range def.Fields {
+			if err := s.checkInt(field.Type, fields[field.Name]); err != "" {
+				return err
+			}
+		}
+	}
+	return ""
+}
+
+// execVariables - angka variable didekode sebagai json.Number supaya presisinya
+// utuh; graph-gophers menerima int32 untuk Int dan ID, dan float64 selain itu
+func execVariables(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil && n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int32(n)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[key] = execVariables(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = execVariables(value)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,254 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	gophers "github.com/graph-gophers/graphql-go"
+)
+
+const testSDL = `
+scalar Time
+
+type Item {
+  id: ID!
+  name: String!
+  children(limit: Int = 5): [Item!]!
+  "Always fails."
+  broken: String
+}
+
+type Query {
+  item(id: ID!): Item
+  items(first: Int): [Item!]!
+  echo(n: Int!, when: Time): String!
+}
+
+type Mutation {
+  rename(id: ID!, name: String!): Item!
+}
+
+type Subscription {
+  ticks(count: Int!): Int!
+}
+`
+
+type testRoot struct{}
+
+type testItem struct{ id string }
+
+func (i *testItem) ID() gophers.ID { return gophers.ID(i.id) }
+func (i *testItem) Name() string   { return "item " + i.id }
+
+func (i *testItem) Children(args struct{ Limit gophers.NullInt }) []*testItem {
+	return []*testItem{{id: i.id + ".1"}}
+}
+
+func (i *testItem) Broken() (*string, error) {
+	return nil, NewError("broken field", map[string]interface{}{"code": "BROKEN"})
+}
+
+func (testRoot) Item(args struct{ ID gophers.ID }) *testItem {
+	if args.ID == "missing" {
+		return nil
+	}
+	return &testItem{id: string(args.ID)}
+}
+
+func (testRoot) Items(args struct{ First *int32 }) []*testItem {
+	return []*testItem{{id: "1"}, {id: "2"}}
+}
+
+func (testRoot) Echo(args struct {
+	N    int32
+	When *Time
+}) string {
+	s, _ := json.Marshal(args.N)
+	if args.When != nil {
+		return string(s) + " " + args.When.UTC().Format("2006-01-02")
+	}
+	return string(s)
+}
+
+func (testRoot) Rename(args struct {
+	ID   gophers.ID
+	Name string
+}) *testItem {
+	return &testItem{id: string(args.ID)}
+}
+
+func (testRoot) Ticks(ctx context.Context, args struct{ Count int32 }) <-chan int32 {
+	out := make(chan int32)
+	go func() {
+		defer close(out)
+		for i := int32(1); i <= args.Count; i++ {
+			select {
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func newTestSchema(config Config) *Schema {
+	return MustSchema(testSDL, &testRoot{}, config)
+}
+
+// prepareError - pesan error pertama dari Prepare, atau "" kalau lolos
+func prepareError(t *testing.T, s *Schema, req Request) string {
+	t.Helper()
+	_, resp := s.Prepare(req)
+	if resp == nil {
+		return ""
+	}
+	if len(resp.Errors) == 0 || resp.Data != nil {
+		t.Fatalf("%q: error response without errors or with data: %+v", req.Query, resp)
+	}
+	return resp.Errors[0].Message
+}
+
+func TestPrepareRejectsInvalidRequests(t *testing.T) {
+	s := newTestSchema(Config{})
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{"empty", Request{Query: "  "}, "Must provide query string."},
+		{"syntax", Request{Query: "{ item(id: 1) { id "}, "Expected Name, found <EOF>"},
+		{"unknown field", Request{Query: "{ nope }"}, `Cannot query field "nope" on type "Query".`},
+		{"missing argument", Request{Query: "{ item { id } }"}, `Field "item" argument "id" of type "ID!" is required`},
+		{"missing selection", Request{Query: "{ item(id: 1) }"}, `Field "item" of type "Item" must have a selection of subfields`},
+		{"leaf selection", Request{Query: "{ item(id: 1) { id { x } } }"}, `Cannot query field "x" on type "ID".`},
+		{"wrong literal", Request{Query: `{ echo(n: "one") }`}, `Int cannot represent non-integer value: "one"`},
+		{"undefined variable", Request{Query: "{ echo(n: $n) }"}, `Variable "$n" is not defined.`},
+		{"unused variable", Request{Query: "query($n: Int) { echo(n: 1) }"}, `Variable "$n" is never used.`},
+		{"unknown fragment", Request{Query: "{ ...F }"}, `Unknown fragment "F".`},
+		{"fragment cycle", Request{Query: "{ item(id: 1) { ...A } } fragment A on Item { children { ...B } } fragment B on Item { children { ...A } }"}, `Cannot spread fragment "A" within itself via "B".`},
+		{"conflicting aliases", Request{Query: "{ x: item(id: 1) { id } x: items { id } }"}, "Fields \"x\" conflict"},
+		{"duplicate operation", Request{Query: "query A { items { id } } query A { items { id } }"}, `There can be only one operation named "A".`},
+		{"unknown operation", Request{Query: "query A { items { id } }", OperationName: "B"}, `Unknown operation named "B".`},
+		{"no operation", Request{Query: "fragment F on Item { id }"}, `Fragment "F" is never used.`},
+		{"ambiguous operation", Request{Query: "query A { items { id } } query B { items { id } }"}, "Must provide operation name if query contains multiple operations."},
+		{"missing variable", Request{Query: "query($n: Int!) { echo(n: $n) }"}, "must be defined"},
+		{"variable type", Request{Query: "query($n: Int!) { echo(n: $n) }", Variables: map[string]interface{}{"n": "one"}}, "cannot use string as Int"},
+		{"variable overflow", Request{Query: "query($n: Int!) { echo(n: $n) }", Variables: map[string]interface{}{"n": json.Number("3000000000")}}, "Int cannot represent non 32-bit signed integer value: 3000000000"},
+	}
+	for _, tt := range tests {
+		if got := prepareError(t, s, tt.req); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: error = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	s := newTestSchema(Config{})
+	run := func(req Request) string {
+		t.Helper()
+		p, resp := s.Prepare(req)
+		if resp != nil {
+			t.Fatalf("%q: %+v", req.Query, resp.Errors[0])
+		}
+		out, _ := json.Marshal(p.Execute(context.Background()))
+		return string(out)
+	}
+
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{"aliases and fragments", Request{Query: "{ a: item(id: 1) { ...F } b: item(id: 2) { ... on Item { id } } } fragment F on Item { name }"},
+			`{"data":{"a":{"name":"item 1"},"b":{"id":"2"}}}`},
+		{"null result", Request{Query: `{ item(id: "missing") { id } }`}, `{"data":{"item":null}}`},
+		{"json.Number variables", Request{Query: "query($n: Int!, $id: ID!) { echo(n: $n) item(id: $id) { id } }", Variables: map[string]interface{}{"n": json.Number("42"), "id": json.Number("7")}},
+			`{"data":{"echo":"42","item":{"id":"7"}}}`},
+		{"Time variable", Request{Query: "query($t: Time) { echo(n: 1, when: $t) }", Variables: map[string]interface{}{"t": "2024-05-01T23:00:00-02:00"}},
+			`{"data":{"echo":"1 2024-05-02"}}`},
+		{"skip and include", Request{Query: "query($no: Boolean!) { item(id: 1) { id @skip(if: true) name @include(if: $no) } }", Variables: map[string]interface{}{"no": false}},
+			`{"data":{"item":{}}}`},
+		{"typename", Request{Query: "{ items { __typename } }"}, `{"data":{"items":[{"__typename":"Item"},{"__typename":"Item"}]}}`},
+		{"operation name", Request{Query: "query A { items { id } } mutation B { rename(id: 3, name: \"x\") { id } }", OperationName: "B"},
+			`{"data":{"rename":{"id":"3"}}}`},
+	}
+	for _, tt := range tests {
+		if got := run(tt.req); got != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Error resolver: field jadi null, extensions ikut ditulis
+	got := run(Request{Query: "{ item(id: 1) { id broken } }"})
+	want := `{"errors":[{"message":"broken field","path":["item","broken"],"extensions":{"code":"BROKEN"}}],"data":{"item":{"id":"1","broken":null}}}`
+	if got != want {
+		t.Errorf("resolver error:\n got  %s\n want %s", got, want)
+	}
+}
+
+func TestTimeRejectsNonRFC3339(t *testing.T) {
+	s := newTestSchema(Config{})
+	for _, v := range []interface{}{json.Number("1714600000"), "2024-05-01", "yesterday"} {
+		p, resp := s.Prepare(Request{Query: "query($t: Time) { echo(n: 1, when: $t) }", Variables: map[string]interface{}{"t": v}})
+		if resp == nil {
+			resp = p.Execute(context.Background())
+		}
+		if len(resp.Errors) == 0 {
+			t.Errorf("Time %v accepted", v)
+		}
+	}
+}
+
+func TestIntrospection(t *testing.T) {
+	s := newTestSchema(Config{MaxDepth: 3})
+	p, resp := s.Prepare(Request{Query: `{ __schema { queryType { name } types { name fields { name } } } __type(name: "Item") { fields { name description } } }`})
+	if resp != nil {
+		t.Fatalf("introspection rejected: %+v", resp.Errors[0])
+	}
+	out, _ := json.Marshal(p.Execute(context.Background()))
+	for _, want := range []string{`"queryType":{"name":"Query"}`, `"name":"broken","description":"Always fails."`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("introspection missing %s: %s", want, out)
+		}
+	}
+
+	// Introspeksi yang bersarang terlalu dalam ditolak gqlparser
+	deep := `{ __schema { types { fields { type { fields { type { fields { type { fields { name } } } } } } } } } }`
+	if got := prepareError(t, s, Request{Query: deep}); got == "" {
+		t.Error("deep introspection accepted")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	s := newTestSchema(Config{})
+	p, resp := s.Prepare(Request{Query: "subscription { ticks(count: 3) }"})
+	if resp != nil {
+		t.Fatalf("prepare: %+v", resp.Errors[0])
+	}
+	if p.Kind() != "subscription" {
+		t.Errorf("kind = %q", p.Kind())
+	}
+	if resp := p.Execute(context.Background()); len(resp.Errors) == 0 {
+		t.Error("Execute ran a subscription")
+	}
+
+	var got []string
+	for result := range p.Subscribe(context.Background()) {
+		got = append(got, string(result.Data))
+	}
+	if strings.Join(got, ",") != `{"ticks":1},{"ticks":2},{"ticks":3}` {
+		t.Errorf("results = %v", got)
+	}
+}
+
+func TestNewErrorKeepsMessage(t *testing.T) {
+	err := NewError("boom", nil)
+	var ext interface{ Extensions() map[string]interface{} }
+	if err.Error() != "boom" || !errors.As(err, &ext) {
+		t.Errorf("NewError = %v", err)
+	}
+}
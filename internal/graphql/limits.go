@@ -0,0 +1,149 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// maxFields - selection di dokumen sebelum fragment dibuka
+	maxFields = 2000
+	// maxFieldVisits - selection setelah fragment dibuka; fragment yang disebar
+	// berkali-kali di fragment lain bisa tumbuh eksponensial
+	maxFieldVisits = 10000
+)
+
+// listArgs - argumen yang menentukan panjang list untuk hitungan complexity
+var listArgs = []string{"limit", "first", "last"}
+
+// countFields - jumlah selection di seluruh dokumen, tiap fragment sekali
+func countFields(doc *ast.QueryDocument) int {
+	var count func(set ast.SelectionSet) int
+	count = func(set ast.SelectionSet) int {
+		n := 0
+		for _, sel := range set {
+			n++
+			switch s := sel.(type) {
+			case *ast.Field:
+				n += count(s.SelectionSet)
+			case *ast.InlineFragment:
+				n += count(s.SelectionSet)
+			}
+		}
+		return n
+	}
+
+	n := 0
+	for _, op := range doc.Operations {
+		n += count(op.SelectionSet)
+	}
+	for _, frag := range doc.Fragments {
+		n += count(frag.SelectionSet)
+	}
+	return n
+}
+
+// limits - hitung kedalaman dan biaya satu operation yang sudah valid
+type limits struct {
+	vars     map[string]interface{}
+	listSize int
+	visits   int
+}
+
+// checkLimits - kedalaman dan complexity operation. Setiap field berbiaya 1 dan
+// field di bawah list dihitung sekali per item. Field introspeksi tidak dihitung;
+// kedalamannya sudah dibatasi aturan MaxIntrospectionDepth gqlparser.
+func (s *Schema) checkLimits(op *ast.OperationDefinition, vars map[string]interface{}) gqlerror.List {
+	l := &limits{vars: vars, listSize: s.config.ListSize}
+	depth, cost := l.selectionSet(op.SelectionSet, 1)
+	if l.visits > maxFieldVisits {
+		return gqlerror.List{gqlerror.ErrorPosf(op.Position, "Query is too large.")}
+	}
+
+	var errs gqlerror.List
+	if s.config.MaxDepth > 0 && depth > s.config.MaxDepth {
+		errs = append(errs, gqlerror.ErrorPosf(op.Position, "Query depth %d exceeds the maximum of %d.", depth, s.config.MaxDepth))
+	}
+	if s.config.MaxComplexity > 0 && cost > s.config.MaxComplexity {
+		errs = append(errs, gqlerror.ErrorPosf(op.Position, "Query complexity %d exceeds the maximum of %d.", cost, s.config.MaxComplexity))
+	}
+	return errs
+}
+
+// selectionSet - kedalaman field terdalam dan biaya selection di kedalaman depth
+func (l *limits) selectionSet(set ast.SelectionSet, depth int) (int, int) {
+	maxDepth, cost := depth, 0
+	for _, sel := range set {
+		if l.visits++; l.visits > maxFieldVisits {
+			return maxDepth, cost
+		}
+
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.FragmentSpread:
+			d, c = l.selectionSet(s.Definition.SelectionSet, depth)
+		case *ast.InlineFragment:
+			d, c = l.selectionSet(s.SelectionSet, depth)
+		case *ast.Field:
+			d, c = l.field(s, depth)
+		}
+		maxDepth = max(maxDepth, d)
+		cost += c
+	}
+	return maxDepth, cost
+}
+
+func (l *limits) field(f *ast.Field, depth int) (int, int) {
+	if strings.HasPrefix(f.Name, "__") {
+		return depth, 0
+	}
+	if len(f.SelectionSet) == 0 {
+		return depth, 1
+	}
+	childDepth, childCost := l.selectionSet(f.SelectionSet, depth+1)
+	return childDepth, 1 + l.size(f)*childCost
+}
+
+// size - pengali biaya field: argumen limit kalau field-nya list. limit null
+// dihitung dengan default-nya, sama seperti resolver memperlakukannya.
+func (l *limits) size(f *ast.Field) int {
+	if f.Definition == nil || f.Definition.Type.Elem == nil {
+		return 1
+	}
+	args := f.ArgumentMap(l.vars)
+	for _, name := range listArgs {
+		def := f.Definition.Arguments.ForName(name)
+		if def == nil {
+			continue
+		}
+		v := args[name]
+		if v == nil && def.DefaultValue != nil {
+			v, _ = def.DefaultValue.Value(nil)
+		}
+		if n, ok := toInt(v); ok && n >= 0 {
+			return n
+		}
+	}
+	return l.listSize
+}
+
+// toInt - nilai Int dari literal (int64) atau variable
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), true
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case float64:
+		return int(n), n == float64(int(n))
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDepthLimit(t *testing.T) {
+	s := newTestSchema(Config{MaxDepth: 3})
+	tests := []struct {
+		name, query string
+		ok          bool
+	}{
+		{"at limit", "{ item(id: 1) { children { id } } }", true},
+		{"over limit", "{ item(id: 1) { children { children { id } } } }", false},
+		{"through fragment", "{ item(id: 1) { ...F } } fragment F on Item { children { children { id } } }", false},
+		{"through inline fragment", "{ item(id: 1) { ... on Item { children { ... { children { id } } } } } }", false},
+		{"typename does not add depth", "{ item(id: 1) { children { __typename } } }", true},
+	}
+	for _, tt := range tests {
+		got := prepareError(t, s, Request{Query: tt.query})
+		if tt.ok && got != "" {
+			t.Errorf("%s: rejected: %s", tt.name, got)
+		}
+		if !tt.ok && got != "Query depth 4 exceeds the maximum of 3." {
+			t.Errorf("%s: error = %q", tt.name, got)
+		}
+	}
+}
+
+func TestComplexityLimit(t *testing.T) {
+	s := newTestSchema(Config{MaxComplexity: 100})
+	tests := []struct {
+		name string
+		req  Request
+		cost int // 0 = lolos
+	}{
+		// items tanpa first: ListSize 10 item x (1 + children default 5 x 1)
+		{"default list size", Request{Query: "{ items { id children { id } } }"}, 0},
+		{"list argument", Request{Query: "{ items(first: 20) { id name } }"}, 0},
+		{"list argument over limit", Request{Query: "{ items(first: 60) { id name } }"}, 121},
+		{"nested lists multiply", Request{Query: "{ items(first: 10) { children(limit: 10) { id } } }"}, 111},
+		{"variable list size", Request{Query: "query($n: Int) { items(first: $n) { id name } }", Variables: map[string]interface{}{"n": json.Number("60")}}, 121},
+		{"null limit uses default", Request{Query: "{ item(id: 1) { children(limit: null) { id } } }"}, 0},
+		{"aliases add up", Request{Query: "{ a: items(first: 30) { id } b: items(first: 30) { id } c: items(first: 30) { id } d: items(first: 30) { id } }"}, 124},
+		{"fragments counted per spread", Request{Query: "{ a: items(first: 30) { ...F } b: items(first: 30) { ...F } } fragment F on Item { id name }"}, 122},
+	}
+	for _, tt := range tests {
+		got := prepareError(t, s, tt.req)
+		want := ""
+		if tt.cost > 0 {
+			want = fmt.Sprintf("Query complexity %d exceeds the maximum of 100.", tt.cost)
+		}
+		if got != want {
+			t.Errorf("%s: error = %q, want %q", tt.name, got, want)
+		}
+	}
+}
+
+func TestLargeQueriesRejected(t *testing.T) {
+	s := newTestSchema(Config{})
+
+	// Dokumen dengan terlalu banyak field ditolak sebelum validasi
+	wide := "{ " + strings.Repeat("id ", maxFields+1) + "}"
+	if got := prepareError(t, s, Request{Query: "{ item(id: 1) " + wide + " }"}); got != "Query is too large." {
+		t.Errorf("wide query: %q", got)
+	}
+
+	// Fragment yang saling menyebar tumbuh eksponensial setelah dibuka
+	var b strings.Builder
+	b.WriteString("{ item(id: 1) { ...F0 } }\n")
+	for i := 0; i < 15; i++ {
+		fmt.Fprintf(&b, "fragment F%d on Item { ...F%d ...F%d }\n", i, i+1, i+1)
+	}
+	b.WriteString("fragment F15 on Item { id }\n")
+	if got := prepareError(t, s, Request{Query: b.String()}); got != "Query is too large." {
+		t.Errorf("fragment bomb: %q", got)
+	}
+}
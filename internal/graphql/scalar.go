@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Time - scalar Time sebagai string RFC 3339. graph-gophers juga menerima
+// angka Unix untuk graphql.Time; di sini hanya string yang diterima.
+type Time struct {
+	time.Time
+}
+
+func (Time) ImplementsGraphQLType(name string) bool { return name == "Time" }
+
+func (t *Time) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("Time must be an RFC 3339 string, got %v", input)
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("Time must be an RFC 3339 string, got %q", s)
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+// TimeOf - nil tetap nil, untuk field Time yang boleh null
+func TimeOf(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+	return &Time{*t}
+}
+
+// NullTime - Time di input yang membedakan null (Set true, Value nil) dari
+// field yang tidak dikirim (Set false)
+type NullTime struct {
+	Value *time.Time
+	Set   bool
+}
+
+func (NullTime) ImplementsGraphQLType(name string) bool { return name == "Time" }
+
+func (t *NullTime) UnmarshalGraphQL(input interface{}) error {
+	t.Set = true
+	if input == nil {
+		return nil
+	}
+	var v Time
+	if err := v.UnmarshalGraphQL(input); err != nil {
+		return err
+	}
+	t.Value = &v.Time
+	return nil
+}
+
+func (*NullTime) Nullable() {}
+
+// Enum - nama nilai enum GraphQL dan nilai Go-nya
+type Enum[T comparable] map[string]T
+
+// Value - nilai Go untuk nama enum; nama sudah divalidasi terhadap schema
+func (e Enum[T]) Value(name string) T {
+	return e[name]
+}
+
+// Name - nama enum untuk nilai Go; kosong kalau tidak ada
+func (e Enum[T]) Name(v T) string {
+	for name, value := range e {
+		if value == v {
+			return name
+		}
+	}
+	return ""
+}
@@ -1,26 +1,31 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
 	"taskflow-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
+// streamTicketTTL - cukup untuk membuka koneksi setelah ticket diminta
+const streamTicketTTL = time.Minute
+
 type AuthHandler struct {
-	db        *sql.DB
-	jwtSecret string
+	credentials repository.CredentialRepository
+	auth        *service.AuthService
 }
 
-func NewAuthHandler(db *sql.DB, jwtSecret string) *AuthHandler {
+func NewAuthHandler(credentials repository.CredentialRepository, auth *service.AuthService) *AuthHandler {
 	return &AuthHandler{
-		db:        db,
-		jwtSecret: jwtSecret,
+		credentials: credentials,
+		auth:        auth,
 	}
 }
 
@@ -31,104 +36,117 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	// Check if email already exists
-	var exists bool
-	err := h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", req.Email).Scan(&exists)
+	user, err := h.auth.Register(req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		respondTaskError(c, err)
 		return
 	}
-	if exists {
-		utils.ErrorResponse(c, http.StatusConflict, "Email already registered")
+
+	utils.SuccessResponse(c, http.StatusCreated, "User registered successfully", user)
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Hash password
-	hashedPassword, err := utils.HashPassword(req.Password)
+	response, err := h.auth.Login(req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to hash password")
+		respondTaskError(c, err)
 		return
 	}
 
-	// Insert user
-	var user models.User
-	err = h.db.QueryRow(
-		"INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING id, name, email, created_at, updated_at",
-		req.Name, req.Email, hashedPassword,
-	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", response)
+}
 
+func (h *AuthHandler) GetProfile(c *gin.Context) {
+	user, err := h.auth.Profile(c.GetInt("user_id"))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user")
+		respondTaskError(c, err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "User registered successfully", user)
+	utils.SuccessResponse(c, http.StatusOK, "Profile retrieved successfully", user)
 }
 
-func (h *AuthHandler) Login(c *gin.Context) {
-	var req models.LoginRequest
+// CreateAppPassword - password untuk client CalDAV; login dengan email dan password ini
+func (h *AuthHandler) CreateAppPassword(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.CreateAppPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Get user from database
-	var user models.User
-	var hashedPassword string
-	err := h.db.QueryRow(
-		"SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1",
-		req.Email,
-	).Scan(&user.ID, &user.Name, &user.Email, &hashedPassword, &user.CreatedAt, &user.UpdatedAt)
+	password, err := utils.GenerateToken(16)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate password")
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid email or password")
+	appPassword, err := h.credentials.CreateAppPassword(userID, req.Name, utils.HashToken(password))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create app password")
 		return
 	}
+	appPassword.Password = password
+
+	utils.SuccessResponse(c, http.StatusCreated, "App password created successfully", appPassword)
+}
+
+// CreateStreamTicket - ticket sekali pakai untuk /events, /ws dan GET /graphql.
+// Browser tidak bisa mengirim header Authorization di EventSource dan WebSocket,
+// dan JWT di query string ikut tercatat di log; ticket ini tidak berguna lagi
+// setelah dipakai atau lewat satu menit.
+func (h *AuthHandler) CreateStreamTicket(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	token, err := utils.GenerateToken(32)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate ticket")
 		return
 	}
 
-	// Check password
-	if !utils.CheckPassword(req.Password, hashedPassword) {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid email or password")
+	ticket := models.StreamTicket{Ticket: token, ExpiresAt: time.Now().Add(streamTicketTTL)}
+	if err := h.credentials.CreateStreamTicket(userID, utils.HashToken(token), ticket.ExpiresAt); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create ticket")
 		return
 	}
 
-	// Generate JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
-	})
+	utils.SuccessResponse(c, http.StatusCreated, "Stream ticket created successfully", ticket)
+}
 
-	tokenString, err := token.SignedString([]byte(h.jwtSecret))
+func (h *AuthHandler) GetAppPasswords(c *gin.Context) {
+	appPasswords, err := h.credentials.AppPasswords(c.GetInt("user_id"))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token")
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch app passwords")
 		return
 	}
 
-	response := models.LoginResponse{
-		Token: tokenString,
-		User:  user,
-	}
-
-	utils.SuccessResponse(c, http.StatusOK, "Login successful", response)
+	utils.SuccessResponse(c, http.StatusOK, "App passwords retrieved successfully", appPasswords)
 }
 
-func (h *AuthHandler) GetProfile(c *gin.Context) {
+func (h *AuthHandler) DeleteAppPassword(c *gin.Context) {
 	userID := c.GetInt("user_id")
 
-	var user models.User
-	err := h.db.QueryRow(
-		"SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1",
-		userID,
-	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "App password not found")
+		return
+	}
 
+	err = h.credentials.DeleteAppPassword(userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "App password not found")
+		return
+	}
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete app password")
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Profile retrieved successfully", user)
+	utils.SuccessResponse(c, http.StatusOK, "App password deleted successfully", nil)
 }
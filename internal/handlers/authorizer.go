@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/service"
+)
+
+// Authorizer - cek izin yang dipakai handler, dipenuhi oleh *authz.Authorizer
+type Authorizer interface {
+	service.Authorizer
+	Workspace(userID, workspaceID int, action authz.Action) (authz.Role, error)
+	Project(userID, projectID int, action authz.Action) (authz.Resource, error)
+	Webhook(userID, webhookID int, action authz.Action) (authz.Resource, error)
+}
@@ -0,0 +1,666 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/caldav"
+	"taskflow-api/internal/ical"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	caldavRoot      = "/caldav/"
+	caldavPrincipal = caldavRoot + "principal/"
+	caldavHome      = caldavRoot + "calendars/"
+	// caldavSyncPrefix - sync-token harus URI; angkanya token yang sama dengan GET /sync
+	caldavSyncPrefix = "urn:taskflow:sync:"
+	maxCalendarSize  = 1 << 20
+)
+
+// CalDAVHandler - subset CalDAV untuk sync dua arah task sebagai VTODO.
+//
+//	/caldav/                      root, menunjuk ke principal
+//	/caldav/principal/            user yang login, menunjuk ke calendar home
+//	/caldav/calendars/            satu kalender per kategori dan per project
+//	/caldav/calendars/work/       task berkategori work
+//	/caldav/calendars/project-3/  task di project 3
+//	/caldav/calendars/work/x.ics  satu task
+type CalDAVHandler struct {
+	caldav   repository.CalDAVRepository
+	projects repository.ProjectRepository
+	users    repository.UserRepository
+	authz    Authorizer
+	tasks    *service.TaskService
+}
+
+func NewCalDAVHandler(caldav repository.CalDAVRepository, projects repository.ProjectRepository, users repository.UserRepository, az Authorizer, tasks *service.TaskService) *CalDAVHandler {
+	return &CalDAVHandler{caldav: caldav, projects: projects, users: users, authz: az, tasks: tasks}
+}
+
+// davCollection - kalender: semua task satu kategori, atau satu project
+type davCollection struct {
+	Name        string
+	DisplayName string
+	Category    models.Category
+	Project     *models.Project
+	Writable    bool
+}
+
+func (coll davCollection) href() string {
+	return caldavHome + coll.Name + "/"
+}
+
+func (coll davCollection) calendar() repository.CalDAVCalendar {
+	if coll.Project != nil {
+		return repository.CalDAVCalendar{ProjectID: &coll.Project.ID}
+	}
+	return repository.CalDAVCalendar{Category: coll.Category}
+}
+
+func (coll davCollection) contains(task models.Task) bool {
+	if coll.Project != nil {
+		return task.ProjectID != nil && *task.ProjectID == coll.Project.ID
+	}
+	return task.Category == coll.Category
+}
+
+// Options - kemampuan server; tanpa login supaya client bisa mendeteksi CalDAV
+func (h *CalDAVHandler) Options(c *gin.Context) {
+	c.Header("DAV", "1, 3, calendar-access")
+	c.Header("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	c.Status(http.StatusOK)
+}
+
+// Forbidden - PROPPATCH dan MKCALENDAR: kalender berasal dari kategori dan project
+func (h *CalDAVHandler) Forbidden(c *gin.Context) {
+	c.String(http.StatusForbidden, "Calendars are created from categories and projects")
+}
+
+// davPath - bagian path setelah /caldav/
+func davPath(c *gin.Context) []string {
+	var parts []string
+	for _, p := range strings.Split(c.Param("path"), "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+func (h *CalDAVHandler) Propfind(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	pf, err := caldav.ReadPropfind(c.Request.Body)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	var names []xml.Name
+	if !pf.AllProp {
+		names = pf.Props
+	}
+	// Depth: infinity diperlakukan seperti 1
+	children := c.GetHeader("Depth") != "0"
+
+	var ms caldav.Multistatus
+	add := func(href string, props caldav.Props) {
+		ms.Add(href, props, names, pf.PropName)
+	}
+
+	path := davPath(c)
+	switch {
+	case len(path) == 0:
+		add(caldavRoot, h.baseProps(caldav.Element(caldav.NSDAV, "collection"), "TaskFlow"))
+
+	case len(path) == 1 && path[0] == "principal":
+		props, err := h.principalProps(userID)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		add(caldavPrincipal, props)
+
+	case len(path) == 1 && path[0] == "calendars":
+		add(caldavHome, h.baseProps(caldav.Element(caldav.NSDAV, "collection"), "Calendars"))
+		if children {
+			colls, err := h.collections(userID)
+			if err != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			for _, coll := range colls {
+				props, err := h.collectionProps(userID, coll)
+				if err != nil {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				add(coll.href(), props)
+			}
+		}
+
+	case len(path) == 2 && path[0] == "calendars":
+		coll, ok := h.collection(c, userID, path[1])
+		if !ok {
+			return
+		}
+		props, err := h.collectionProps(userID, coll)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		add(coll.href(), props)
+		if children {
+			tasks, err := h.caldav.Objects(userID, coll.calendar())
+			if err != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			for _, t := range tasks {
+				add(resourceHref(coll, t.Name), resourceProps(t))
+			}
+		}
+
+	case len(path) == 3 && path[0] == "calendars":
+		coll, ok := h.collection(c, userID, path[1])
+		if !ok {
+			return
+		}
+		t, err := h.caldav.Find(userID, coll.calendar(), path[2])
+		if errors.Is(err, repository.ErrNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		add(resourceHref(coll, t.Name), resourceProps(t))
+
+	default:
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	if err := ms.Write(c.Writer); err != nil {
+		log.Printf("caldav: propfind: %v", err)
+	}
+}
+
+// Report - calendar-query, calendar-multiget dan sync-collection pada satu kalender
+func (h *CalDAVHandler) Report(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	path := davPath(c)
+	if len(path) != 2 || path[0] != "calendars" {
+		c.String(http.StatusForbidden, "REPORT is only supported on calendars")
+		return
+	}
+	coll, ok := h.collection(c, userID, path[1])
+	if !ok {
+		return
+	}
+
+	q, err := caldav.ReadReport(c.Request.Body)
+	if errors.Is(err, caldav.ErrUnsupportedReport) {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var ms caldav.Multistatus
+	switch q.Type {
+	case caldav.CalendarQuery:
+		err = h.calendarQuery(&ms, userID, coll, q)
+	case caldav.CalendarMultiget:
+		err = h.multiget(&ms, userID, coll, q)
+	case caldav.SyncCollection:
+		var since int64
+		since, err = parseSyncToken(strings.TrimPrefix(q.SyncToken, caldavSyncPrefix))
+		if err != nil || (q.SyncToken != "" && !strings.HasPrefix(q.SyncToken, caldavSyncPrefix)) {
+			c.Header("Content-Type", `application/xml; charset="utf-8"`)
+			c.String(http.StatusForbidden, `<?xml version="1.0" encoding="utf-8"?><d:error xmlns:d="DAV:"><d:valid-sync-token/></d:error>`)
+			return
+		}
+		err = h.syncCollection(c, &ms, userID, coll, q, since)
+	}
+	if err != nil {
+		log.Printf("caldav: report %s: %v", q.Type, err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if err := ms.Write(c.Writer); err != nil {
+		log.Printf("caldav: report: %v", err)
+	}
+}
+
+// calendarQuery - hanya VTODO; filter time-range (DUE) dan COMPLETED is-not-defined
+func (h *CalDAVHandler) calendarQuery(ms *caldav.Multistatus, userID int, coll davCollection, q caldav.Query) error {
+	if q.Component != "" && q.Component != ical.VTODO {
+		return nil
+	}
+	tasks, err := h.caldav.Objects(userID, coll.calendar())
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if q.NotCompleted && t.IsCompleted {
+			continue
+		}
+		// VTODO tanpa DUE cocok dengan semua rentang waktu
+		if t.DueDate != nil && ((q.Start != nil && t.DueDate.Before(*q.Start)) || (q.End != nil && !t.DueDate.Before(*q.End))) {
+			continue
+		}
+		ms.Add(resourceHref(coll, t.Name), resourceProps(t), q.Props, false)
+	}
+	return nil
+}
+
+func (h *CalDAVHandler) multiget(ms *caldav.Multistatus, userID int, coll davCollection, q caldav.Query) error {
+	for _, href := range q.Hrefs {
+		name := ""
+		if u, err := url.Parse(href); err == nil && strings.HasPrefix(u.Path, coll.href()) {
+			name = strings.TrimPrefix(u.Path, coll.href())
+		}
+		t, err := h.caldav.Find(userID, coll.calendar(), name)
+		if errors.Is(err, repository.ErrNotFound) {
+			ms.AddStatus(href, http.StatusNotFound)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ms.Add(resourceHref(coll, t.Name), resourceProps(t), q.Props, false)
+	}
+	return nil
+}
+
+// syncCollection - perubahan sejak token, dengan aturan watermark yang sama
+// seperti GET /sync. Task yang berubah tapi tidak lagi ada di kalender ini
+// (kategori atau project berubah) dilaporkan sebagai 404, sama seperti yang dihapus.
+func (h *CalDAVHandler) syncCollection(c *gin.Context, ms *caldav.Multistatus, userID int, coll davCollection, q caldav.Query, since int64) error {
+	changes, err := h.caldav.Changes(c.Request.Context(), userID, coll.calendar(), since)
+	if err != nil {
+		return err
+	}
+	ms.SetSyncToken(caldavSyncPrefix + strconv.FormatInt(changes.Watermark, 10))
+
+	for _, t := range changes.Changed {
+		if coll.contains(t.Task) {
+			ms.Add(resourceHref(coll, t.Name), resourceProps(t), q.Props, false)
+		} else {
+			ms.AddStatus(resourceHref(coll, t.Name), http.StatusNotFound)
+		}
+	}
+	for _, name := range changes.Deleted {
+		ms.AddStatus(resourceHref(coll, name), http.StatusNotFound)
+	}
+	return nil
+}
+
+// Get - satu task sebagai VCALENDAR dengan satu VTODO (juga untuk HEAD)
+func (h *CalDAVHandler) Get(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	coll, name, ok := h.resourcePath(c, userID)
+	if !ok {
+		return
+	}
+	t, err := h.caldav.Find(userID, coll.calendar(), name)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if notModified(c, taskETag(t.Task)) {
+		return
+	}
+	c.Header("Last-Modified", t.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendarData(t))
+}
+
+// Put - buat atau ganti task dari VTODO. If-Match dan If-None-Match: * dihormati.
+// Di kalender kategori, kategori task mengikuti kalendernya.
+func (h *CalDAVHandler) Put(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	coll, name, ok := h.resourcePath(c, userID)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCalendarSize+1))
+	if err != nil || len(data) > maxCalendarSize {
+		c.String(http.StatusRequestEntityTooLarge, "Calendar object must be at most 1 MB")
+		return
+	}
+	todo, err := parseCalendarObject(data)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	existing, err := h.caldav.Find(userID, coll.calendar(), name)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	exists := err == nil
+
+	if exists && c.GetHeader("If-None-Match") == "*" {
+		c.Status(http.StatusPreconditionFailed)
+		return
+	}
+	if !exists && ifMatch(c) != nil {
+		c.Status(http.StatusPreconditionFailed)
+		return
+	}
+
+	if exists {
+		task, err := h.tasks.Update(userID, existing.ID, ifMatch(c), func(current models.Task) (models.TaskDocument, error) {
+			doc := taskDocument(current)
+			applyTodo(&doc.Title, &doc.Description, &doc.Priority, &doc.DueDate, todo)
+			doc.IsCompleted = &todo.Completed
+			doc.Category = todoCategory(coll, todo, current.Category)
+			return doc, nil
+		})
+		if err != nil {
+			respondDAVError(c, err)
+			return
+		}
+		c.Header("ETag", taskETag(task))
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	// Nama task-<id>.ics milik task yang ada; resource baru harus memilih nama lain
+	if repository.ReservedCalDAVName(name) {
+		c.String(http.StatusConflict, "Resource names of the form task-<id>.ics are reserved")
+		return
+	}
+
+	req := models.CreateTaskRequest{IsCompleted: &todo.Completed}
+	applyTodo(&req.Title, &req.Description, &req.Priority, &req.DueDate, todo)
+	req.Category = todoCategory(coll, todo, "")
+	if coll.Project != nil {
+		req.WorkspaceID = coll.Project.WorkspaceID
+		req.ProjectID = &coll.Project.ID
+	}
+
+	task, err := h.tasks.Create(userID, req)
+	if err != nil {
+		respondDAVError(c, err)
+		return
+	}
+
+	uid := todo.UID
+	if uid == "" {
+		uid = ical.UID(task.ID)
+	}
+	if err := h.caldav.SetName(task.ID, name, uid); err != nil {
+		log.Printf("caldav: task %d: %v", task.ID, err)
+	}
+
+	c.Header("ETag", taskETag(task))
+	c.Status(http.StatusCreated)
+}
+
+func (h *CalDAVHandler) Delete(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	coll, name, ok := h.resourcePath(c, userID)
+	if !ok {
+		return
+	}
+	t, err := h.caldav.Find(userID, coll.calendar(), name)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := h.tasks.Delete(userID, t.ID, ifMatch(c)); err != nil {
+		respondDAVError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// resourcePath - kalender dan nama resource dari /calendars/<kalender>/<nama>.ics
+func (h *CalDAVHandler) resourcePath(c *gin.Context, userID int) (davCollection, string, bool) {
+	path := davPath(c)
+	if len(path) != 3 || path[0] != "calendars" || !strings.HasSuffix(path[2], ".ics") {
+		c.Status(http.StatusNotFound)
+		return davCollection{}, "", false
+	}
+	coll, ok := h.collection(c, userID, path[1])
+	return coll, path[2], ok
+}
+
+// collections - tiga kategori, lalu project yang terlihat dan belum diarsipkan
+func (h *CalDAVHandler) collections(userID int) ([]davCollection, error) {
+	colls := []davCollection{
+		{Name: string(models.CategoryPersonal), DisplayName: "Personal", Category: models.CategoryPersonal, Writable: true},
+		{Name: string(models.CategoryWork), DisplayName: "Work", Category: models.CategoryWork, Writable: true},
+		{Name: string(models.CategoryUrgent), DisplayName: "Urgent", Category: models.CategoryUrgent, Writable: true},
+	}
+
+	projects, err := h.projects.List(userID, repository.ProjectFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		p := &projects[i]
+		colls = append(colls, davCollection{
+			Name:        "project-" + strconv.Itoa(p.ID),
+			DisplayName: p.Name,
+			Project:     p,
+			Writable:    h.authz.Scope(userID, p.WorkspaceID, authz.ActionEdit) == nil,
+		})
+	}
+	return colls, nil
+}
+
+// collection - satu kalender berdasarkan nama; 404 kalau tidak ada atau tidak terlihat
+func (h *CalDAVHandler) collection(c *gin.Context, userID int, name string) (davCollection, bool) {
+	colls, err := h.collections(userID)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return davCollection{}, false
+	}
+	for _, coll := range colls {
+		if coll.Name == name {
+			return coll, true
+		}
+	}
+	c.Status(http.StatusNotFound)
+	return davCollection{}, false
+}
+
+// syncToken - token sync-collection saat ini
+func (h *CalDAVHandler) syncToken() (string, error) {
+	watermark, err := h.caldav.Watermark()
+	return caldavSyncPrefix + strconv.FormatInt(watermark, 10), err
+}
+
+func (h *CalDAVHandler) baseProps(resourceType, displayName string) caldav.Props {
+	props := caldav.Props{}
+	props.Set(caldav.Name(caldav.NSDAV, "resourcetype"), resourceType)
+	props.Set(caldav.Name(caldav.NSDAV, "displayname"), caldav.Text(displayName))
+	props.Set(caldav.Name(caldav.NSDAV, "current-user-principal"), caldav.Href(caldavPrincipal))
+	props.Set(caldav.Name(caldav.NSDAV, "principal-URL"), caldav.Href(caldavPrincipal))
+	props.Set(caldav.Name(caldav.NSCalDAV, "calendar-home-set"), caldav.Href(caldavHome))
+	return props
+}
+
+func (h *CalDAVHandler) principalProps(userID int) (caldav.Props, error) {
+	user, err := h.users.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	props := h.baseProps(caldav.Element(caldav.NSDAV, "principal"), user.Name)
+	props.Set(caldav.Name(caldav.NSCalDAV, "calendar-user-address-set"), caldav.Href("mailto:"+user.Email))
+	return props, nil
+}
+
+func (h *CalDAVHandler) collectionProps(userID int, coll davCollection) (caldav.Props, error) {
+	tasks, err := h.caldav.Objects(userID, coll.calendar())
+	if err != nil {
+		return nil, err
+	}
+	token, err := h.syncToken()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.Task, len(tasks))
+	for i, t := range tasks {
+		versions[i] = t.Task
+	}
+	ctag := strings.TrimPrefix(tasksETag(versions), "W/")
+
+	props := h.baseProps(caldav.Element(caldav.NSDAV, "collection")+caldav.Element(caldav.NSCalDAV, "calendar"), coll.DisplayName)
+	props.Set(caldav.Name(caldav.NSCalDAV, "supported-calendar-component-set"), `<c:comp name="VTODO"/>`)
+	props.Set(caldav.Name(caldav.NSCS, "getctag"), caldav.Text(ctag))
+	props.Set(caldav.Name(caldav.NSDAV, "getetag"), caldav.Text(ctag))
+	props.Set(caldav.Name(caldav.NSDAV, "sync-token"), caldav.Text(token))
+
+	var reports strings.Builder
+	for _, r := range []string{
+		caldav.Element(caldav.NSCalDAV, caldav.CalendarQuery),
+		caldav.Element(caldav.NSCalDAV, caldav.CalendarMultiget),
+		caldav.Element(caldav.NSDAV, caldav.SyncCollection),
+	} {
+		reports.WriteString("<d:supported-report><d:report>" + r + "</d:report></d:supported-report>")
+	}
+	props.Set(caldav.Name(caldav.NSDAV, "supported-report-set"), reports.String())
+
+	privileges := []string{"read", "read-current-user-privilege-set"}
+	if coll.Writable {
+		privileges = append(privileges, "write", "write-content", "bind", "unbind")
+	}
+	var set strings.Builder
+	for _, p := range privileges {
+		set.WriteString("<d:privilege>" + caldav.Element(caldav.NSDAV, p) + "</d:privilege>")
+	}
+	props.Set(caldav.Name(caldav.NSDAV, "current-user-privilege-set"), set.String())
+
+	if coll.Project != nil && coll.Project.Color != "" {
+		props.Set(caldav.Name(caldav.NSApple, "calendar-color"), caldav.Text(coll.Project.Color))
+	}
+	return props, nil
+}
+
+func resourceHref(coll davCollection, name string) string {
+	return coll.href() + url.PathEscape(name)
+}
+
+func resourceProps(t repository.CalDAVObject) caldav.Props {
+	props := caldav.Props{}
+	props.Set(caldav.Name(caldav.NSDAV, "resourcetype"), "")
+	props.Set(caldav.Name(caldav.NSDAV, "getetag"), caldav.Text(taskETag(t.Task)))
+	props.Set(caldav.Name(caldav.NSDAV, "getcontenttype"), "text/calendar; charset=utf-8; component=VTODO")
+	props.Set(caldav.Name(caldav.NSDAV, "getlastmodified"), t.UpdatedAt.UTC().Format(http.TimeFormat))
+	props.Set(caldav.Name(caldav.NSCalDAV, "calendar-data"), caldav.Text(string(calendarData(t))))
+	return props
+}
+
+// calendarData - VCALENDAR dengan satu VTODO
+func calendarData(t repository.CalDAVObject) []byte {
+	var buf bytes.Buffer
+	enc := ical.NewEncoder(&buf)
+	enc.BeginObject()
+	enc.Task(t.Task, ical.VTODO, t.UID)
+	enc.End("VCALENDAR")
+	enc.Flush()
+	return buf.Bytes()
+}
+
+// parseCalendarObject - resource CalDAV harus berisi tepat satu VTODO
+func parseCalendarObject(data []byte) (ical.Todo, error) {
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return ical.Todo{}, err
+	}
+	todos := ical.Todos(cal)
+	if len(todos) != 1 {
+		return ical.Todo{}, errors.New("calendar object must contain exactly one VTODO")
+	}
+	todo, err := ical.ParseTodo(todos[0])
+	if err != nil {
+		return todo, err
+	}
+
+	todo.Summary = strings.TrimSpace(todo.Summary)
+	if todo.Summary == "" {
+		return todo, errors.New("SUMMARY is required")
+	}
+	if utf8.RuneCountInString(todo.Summary) > 200 {
+		return todo, errors.New("SUMMARY must be at most 200 characters")
+	}
+	return todo, nil
+}
+
+// applyTodo - field yang sama antara task baru dan task yang diganti
+func applyTodo(title *string, description **string, priority *models.Priority, due **time.Time, todo ical.Todo) {
+	*title = todo.Summary
+	*description = nil
+	if todo.Description != "" {
+		desc := todo.Description
+		*description = &desc
+	}
+	*priority = todo.Priority
+	*due = todo.Due
+}
+
+// todoCategory - kategori kalender, atau CATEGORIES pertama yang merupakan kategori task
+func todoCategory(coll davCollection, todo ical.Todo, current models.Category) models.Category {
+	if coll.Project == nil {
+		return coll.Category
+	}
+	for _, name := range todo.Categories {
+		switch c := models.Category(strings.ToLower(name)); c {
+		case models.CategoryPersonal, models.CategoryWork, models.CategoryUrgent:
+			return c
+		}
+	}
+	return current
+}
+
+// respondDAVError - error dari TaskService sebagai teks biasa
+func respondDAVError(c *gin.Context, err error) {
+	var te *service.Error
+	if !errors.As(err, &te) {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	if te.Status == http.StatusPreconditionFailed {
+		c.Header("ETag", taskETag(models.Task{Version: te.Version}))
+	}
+	c.String(te.Status, te.Message)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"taskflow-api/internal/ical"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendars repository.CalendarRepository
+}
+
+func NewCalendarHandler(calendars repository.CalendarRepository) *CalendarHandler {
+	return &CalendarHandler{calendars: calendars}
+}
+
+// GetFeed - apakah user punya URL langganan, tanpa token
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	feed, err := h.calendars.Get(userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Calendar feed not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch calendar feed")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Calendar feed retrieved successfully", feed)
+}
+
+// CreateFeed - buat URL langganan baru. URL lama langsung tidak berlaku.
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	feed, err := h.calendars.Rotate(userID, utils.HashToken(token))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create calendar feed")
+		return
+	}
+	feed.Token = token
+	feed.URL = "/api/v1/public/calendar/" + token + ".ics"
+
+	utils.SuccessResponse(c, http.StatusCreated, "Calendar feed created successfully", feed)
+}
+
+// DeleteFeed - cabut URL langganan
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	err := h.calendars.Delete(userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Calendar feed not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete calendar feed")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Calendar feed deleted successfully", nil)
+}
+
+// PublicFeed - GET /public/calendar/:token.ics untuk aplikasi kalender. Task
+// dengan due date sebagai VEVENT (default) atau VTODO (?component=vtodo); filter
+// sama dengan GET /tasks, mis. ?category=work.
+func (h *CalendarHandler) PublicFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	userID, err := h.calendars.Owner(utils.HashToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Calendar feed not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch calendar feed")
+		return
+	}
+
+	component := strings.ToUpper(c.DefaultQuery("component", ical.VEVENT))
+	if component != ical.VEVENT && component != ical.VTODO {
+		utils.ErrorResponse(c, http.StatusBadRequest, "component must be vevent or vtodo")
+		return
+	}
+
+	// Filter "me" (assignee, created_by) merujuk ke pemilik feed
+	// Filter "me" (assignee, created_by) merujuk ke pemilik feed
+	tasks, err := h.calendars.Tasks(userID, filterFromQuery(c.Query))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tasks")
+		return
+	}
+
+	h.calendars.Touch(userID)
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("X-Robots-Tag", "noindex")
+	// Komponen lain untuk task yang sama berarti isi yang berbeda
+	etag := strings.TrimSuffix(tasksETag(tasks), `"`) + "-" + strings.ToLower(component) + `"`
+	if notModified(c, etag) {
+		return
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="taskflow.ics"`)
+	c.Status(http.StatusOK)
+
+	enc := ical.NewEncoder(c.Writer)
+	enc.BeginCalendar("TaskFlow")
+	for _, task := range tasks {
+		enc.Task(task, component, ical.UID(task.ID))
+	}
+	enc.EndCalendar()
+	if err := enc.Flush(); err != nil {
+		log.Printf("calendar: feed for user %d: %v", userID, err)
+	}
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// collabPing - interval heartbeat server; sekaligus memperpanjang presence
+	collabPing = 25 * time.Second
+	// collabTimeout - koneksi ditutup kalau client diam selama ini
+	collabTimeout = 60 * time.Second
+	// collabWriteTimeout - batas waktu satu pesan keluar sebelum client dianggap macet
+	collabWriteTimeout = 10 * time.Second
+	// collabSendBuffer - balasan yang belum terkirim; penuh = client terlalu lambat
+	collabSendBuffer = 32
+	// maxCollabSubscriptions - jumlah task + project per koneksi
+	maxCollabSubscriptions = 100
+	// maxCollabMessage - ukuran maksimum pesan dari client
+	maxCollabMessage = 4096
+)
+
+// collabMessage - pesan dari client
+type collabMessage struct {
+	Type      string `json:"type"`
+	TaskID    *int   `json:"task_id"`
+	ProjectID *int   `json:"project_id"`
+}
+
+// collabOutgoing - pesan ke client
+type collabOutgoing struct {
+	Type      string          `json:"type"`
+	ID        int64           `json:"id,omitempty"`
+	TaskID    *int            `json:"task_id,omitempty"`
+	ProjectID *int            `json:"project_id,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type CollabHandler struct {
+	hub   *realtime.Hub
+	authz Authorizer
+}
+
+func NewCollabHandler(az Authorizer, hub *realtime.Hub) *CollabHandler {
+	return &CollabHandler{hub: hub, authz: az}
+}
+
+// Connect - upgrade ke WebSocket. Origin tidak dicek karena autentikasi memakai
+// token, bukan cookie.
+func (h *CollabHandler) Connect(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	connectionID, err := utils.GenerateToken(16)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to open connection")
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxCollabMessage
+			s := &collabSession{
+				handler:      h,
+				ws:           ws,
+				userID:       userID,
+				connectionID: connectionID,
+				send:         make(chan collabOutgoing, collabSendBuffer),
+				done:         make(chan struct{}),
+				tasks:        make(map[int]bool),
+				projects:     make(map[int]bool),
+			}
+			s.run()
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// collabSession - satu koneksi WebSocket. Goroutine run membaca pesan client,
+// writeLoop satu-satunya yang menulis ke koneksi.
+type collabSession struct {
+	handler      *CollabHandler
+	ws           *websocket.Conn
+	userID       int
+	connectionID string
+	client       *realtime.Client
+	send         chan collabOutgoing
+	done         chan struct{}
+
+	mu       sync.Mutex
+	tasks    map[int]bool
+	projects map[int]bool
+}
+
+func (s *collabSession) run() {
+	hub := s.handler.hub
+	s.client = hub.Subscribe(s.userID)
+	go s.writeLoop()
+
+	defer func() {
+		close(s.done)
+		hub.Unsubscribe(s.client)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := hub.LeaveAll(ctx, s.connectionID); err != nil {
+			log.Printf("collab: leave %s: %v", s.connectionID, err)
+		}
+		s.ws.Close()
+	}()
+
+	for {
+		s.ws.SetReadDeadline(time.Now().Add(collabTimeout))
+
+		var msg collabMessage
+		err := websocket.JSON.Receive(s.ws, &msg)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			if !s.enqueue(collabOutgoing{Type: "error", Error: "Invalid message"}) {
+				return
+			}
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		if !s.handle(msg) {
+			return
+		}
+	}
+}
+
+// handle - proses satu pesan client; false kalau koneksi harus ditutup
+func (s *collabSession) handle(msg collabMessage) bool {
+	hub := s.handler.hub
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch msg.Type {
+	case "subscribe":
+		if msg.TaskID == nil && msg.ProjectID == nil {
+			return s.enqueue(collabOutgoing{Type: "error", Error: "task_id or project_id is required"})
+		}
+		if errMsg := s.subscribe(msg); errMsg != "" {
+			return s.enqueue(collabOutgoing{Type: "error", TaskID: msg.TaskID, ProjectID: msg.ProjectID, Error: errMsg})
+		}
+		if !s.enqueue(collabOutgoing{Type: "subscribed", TaskID: msg.TaskID, ProjectID: msg.ProjectID}) {
+			return false
+		}
+		if msg.TaskID != nil {
+			return s.sendPresence(ctx, *msg.TaskID)
+		}
+		return true
+
+	case "unsubscribe":
+		s.mu.Lock()
+		if msg.ProjectID != nil {
+			delete(s.projects, *msg.ProjectID)
+		}
+		if msg.TaskID != nil {
+			delete(s.tasks, *msg.TaskID)
+		}
+		s.mu.Unlock()
+		if msg.TaskID != nil {
+			hub.Unwatch(s.client, *msg.TaskID)
+			if err := hub.Leave(ctx, s.connectionID, *msg.TaskID); err != nil {
+				log.Printf("collab: leave task %d: %v", *msg.TaskID, err)
+			}
+		}
+		return s.enqueue(collabOutgoing{Type: "unsubscribed", TaskID: msg.TaskID, ProjectID: msg.ProjectID})
+
+	case "view":
+		// Mulai terlihat sebagai viewer; otomatis subscribe ke task-nya
+		if msg.TaskID == nil {
+			return s.enqueue(collabOutgoing{Type: "error", Error: "task_id is required"})
+		}
+		if errMsg := s.subscribe(collabMessage{TaskID: msg.TaskID}); errMsg != "" {
+			return s.enqueue(collabOutgoing{Type: "error", TaskID: msg.TaskID, Error: errMsg})
+		}
+		if err := hub.Join(ctx, s.connectionID, s.userID, *msg.TaskID); err != nil {
+			log.Printf("collab: join task %d: %v", *msg.TaskID, err)
+			return s.enqueue(collabOutgoing{Type: "error", TaskID: msg.TaskID, Error: "Failed to join task"})
+		}
+		return true
+
+	case "leave":
+		if msg.TaskID == nil {
+			return s.enqueue(collabOutgoing{Type: "error", Error: "task_id is required"})
+		}
+		if err := hub.Leave(ctx, s.connectionID, *msg.TaskID); err != nil {
+			log.Printf("collab: leave task %d: %v", *msg.TaskID, err)
+		}
+		return true
+
+	case "ping":
+		return s.enqueue(collabOutgoing{Type: "pong"})
+
+	case "pong":
+		// Cukup memperpanjang read deadline
+		return true
+	}
+
+	return s.enqueue(collabOutgoing{Type: "error", Error: "Unknown message type"})
+}
+
+// subscribe - cek akses lalu tambahkan task/project; string kosong kalau berhasil
+func (s *collabSession) subscribe(msg collabMessage) string {
+	var err error
+	notFound := "Task not found"
+	if msg.ProjectID != nil {
+		notFound = "Project not found"
+		_, err = s.handler.authz.Project(s.userID, *msg.ProjectID, authz.ActionView)
+	} else {
+		_, err = s.handler.authz.Task(s.userID, *msg.TaskID, authz.ActionView)
+	}
+	switch {
+	case errors.Is(err, authz.ErrNotFound):
+		return notFound
+	case errors.Is(err, authz.ErrForbidden):
+		return "You don't have permission to perform this action"
+	case err != nil:
+		return "Internal server error"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.tasks)+len(s.projects) >= maxCollabSubscriptions {
+		return "Too many subscriptions"
+	}
+	if msg.ProjectID != nil {
+		s.projects[*msg.ProjectID] = true
+	} else {
+		s.tasks[*msg.TaskID] = true
+		s.handler.hub.Watch(s.client, *msg.TaskID)
+	}
+	return ""
+}
+
+// sendPresence - daftar viewer saat ini, dikirim langsung setelah subscribe
+func (s *collabSession) sendPresence(ctx context.Context, taskID int) bool {
+	e, err := s.handler.hub.PresenceEvent(ctx, taskID)
+	if err != nil {
+		log.Printf("collab: presence of task %d: %v", taskID, err)
+		return true
+	}
+	out, _ := s.forward(e)
+	return s.enqueue(out)
+}
+
+// enqueue - antrekan balasan; client yang tidak membaca cukup cepat diputus
+func (s *collabSession) enqueue(out collabOutgoing) bool {
+	select {
+	case s.send <- out:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *collabSession) writeLoop() {
+	ticker := time.NewTicker(collabPing)
+	defer ticker.Stop()
+
+	for {
+		var out collabOutgoing
+		select {
+		case <-s.done:
+			return
+		case out = <-s.send:
+		case e, ok := <-s.client.C:
+			// Channel ditutup = hub memutus client karena tertinggal
+			if !ok {
+				s.ws.Close()
+				return
+			}
+			var forward bool
+			if out, forward = s.forward(e); !forward {
+				continue
+			}
+		case <-ticker.C:
+			out = collabOutgoing{Type: "ping"}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.handler.hub.Touch(ctx, s.connectionID); err != nil {
+				log.Printf("collab: touch %s: %v", s.connectionID, err)
+			}
+			cancel()
+		}
+
+		s.ws.SetWriteDeadline(time.Now().Add(collabWriteTimeout))
+		if err := websocket.JSON.Send(s.ws, out); err != nil {
+			s.ws.Close()
+			return
+		}
+	}
+}
+
+// forward - ubah event hub jadi pesan, hanya untuk task/project yang di-subscribe
+func (s *collabSession) forward(e realtime.Event) (collabOutgoing, bool) {
+	out := collabOutgoing{Type: string(e.Type), ID: e.ID, Data: json.RawMessage(e.Data)}
+	if e.Type == realtime.Presence {
+		return out, true
+	}
+
+	var payload realtime.Payload
+	if err := json.Unmarshal([]byte(e.Data), &payload); err != nil {
+		return out, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task := payload.Task
+	out.TaskID = &task.ID
+	out.ProjectID = task.ProjectID
+	return out, s.tasks[task.ID] || (task.ProjectID != nil && s.projects[*task.ProjectID])
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/service"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// taskETag - ETag kuat dari kolom version
+func taskETag(task models.Task) string {
+	return `"` + strconv.Itoa(task.Version) + `"`
+}
+
+// tasksETag - ETag lemah untuk list, berubah kalau ada task yang berubah, masuk atau keluar
+func tasksETag(tasks []models.Task) string {
+	h := sha256.New()
+	for _, task := range tasks {
+		h.Write([]byte(strconv.Itoa(task.ID) + ":" + strconv.Itoa(task.Version) + ";"))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+// notModified - set ETag, dan balas 304 kalau If-None-Match cocok (perbandingan lemah)
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch - versi yang diterima If-Match. nil = tanpa syarat (header kosong atau "*").
+// Tag lemah atau rusak tidak pernah cocok, jadi hasilnya slice kosong.
+func ifMatch(c *gin.Context) []int64 {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int64{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if v, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// respondTaskError - tulis error dari service
+func respondTaskError(c *gin.Context, err error) {
+	var te *service.Error
+	if !errors.As(err, &te) {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if te.Status == http.StatusPreconditionFailed {
+		c.Header("ETag", taskETag(models.Task{Version: te.Version}))
+	}
+	utils.ErrorResponse(c, te.Status, te.Message)
+}
+
+// httpError - error dari dalam Transact yang langsung jadi response
+func httpError(status int, message string) error {
+	return &service.Error{Status: status, Message: message}
+}
+
+// respondTxError - error dari Transact: hasil httpError apa adanya, selain itu
+// 500 dengan message
+func respondTxError(c *gin.Context, err error, message string) {
+	var se *service.Error
+	if errors.As(err, &se) {
+		utils.ErrorResponse(c, se.Status, se.Message)
+		return
+	}
+	utils.ErrorResponse(c, http.StatusInternalServerError, message)
+}
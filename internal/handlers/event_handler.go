@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// keepAlive - komentar SSE berkala supaya proxy tidak menutup koneksi yang diam
+const keepAlive = 25 * time.Second
+
+type EventHandler struct {
+	hub *realtime.Hub
+}
+
+func NewEventHandler(hub *realtime.Hub) *EventHandler {
+	return &EventHandler{hub: hub}
+}
+
+// Stream - Server-Sent Events untuk task yang terlihat oleh user. Reconnect
+// dengan header Last-Event-ID (atau ?last_event_id=) untuk melanjutkan.
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	lastID, err := lastEventID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid Last-Event-ID")
+		return
+	}
+
+	// Subscribe sebelum replay supaya tidak ada event yang jatuh di antaranya
+	client := h.hub.Subscribe(userID)
+	defer h.hub.Unsubscribe(client)
+
+	var backlog []realtime.Event
+	resumable := true
+	if lastID > 0 {
+		backlog, resumable, err = h.hub.Since(c.Request.Context(), userID, lastID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch events")
+			return
+		}
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprint(c.Writer, "retry: 3000\n\n")
+	if !resumable {
+		// Event yang diminta sudah di-prune; client harus memuat ulang task-nya
+		fmt.Fprint(c.Writer, "event: resync\ndata: {}\n\n")
+	}
+	sent := lastID
+	for _, e := range backlog {
+		writeEvent(c.Writer, e)
+		sent = e.ID
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-client.C:
+			if !ok {
+				return
+			}
+			if e.ID <= sent {
+				continue
+			}
+			writeEvent(c.Writer, e)
+			sent = e.ID
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, e realtime.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+}
+
+func lastEventID(c *gin.Context) (int64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/export"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxStreamedExportTasks - akun dengan task lebih banyak diekspor di background
+const maxStreamedExportTasks = 5000
+
+type ExportHandler struct {
+	exports repository.ExportRepository
+	runner  *export.Runner
+}
+
+func NewExportHandler(exports repository.ExportRepository, runner *export.Runner) *ExportHandler {
+	return &ExportHandler{exports: exports, runner: runner}
+}
+
+// Export - GET /export?format=json|csv|markdown. Di-stream langsung, kecuali
+// ?async=true atau akun terlalu besar: 202 dengan job dan link download.
+func (h *ExportHandler) Export(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	format := c.DefaultQuery("format", export.JSON)
+	if !export.Valid(format) {
+		utils.ErrorResponse(c, http.StatusBadRequest, export.ErrUnknownFormat.Error())
+		return
+	}
+
+	async := c.Query("async") == "true"
+	if !async {
+		count, err := h.exports.TaskCount(userID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count tasks")
+			return
+		}
+		async = count > maxStreamedExportTasks
+	}
+	if async {
+		h.startExport(c, userID, format)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename(format, time.Now())+`"`)
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Writer, format, h.exports.Source(c.Request.Context(), userID)); err != nil {
+		// Header sudah terkirim; client melihat file yang terpotong
+		log.Printf("export: user %d: %v", userID, err)
+	}
+}
+
+// startExport - buat job background, atau kembalikan job yang sedang berjalan untuk format yang sama
+func (h *ExportHandler) startExport(c *gin.Context, userID int, format string) {
+	job, err := h.exports.Running(userID, format)
+	if err == nil {
+		c.Header("Location", "/api/v1/exports/"+strconv.Itoa(job.ID))
+		utils.SuccessResponse(c, http.StatusAccepted, "Export is already being generated", job)
+		return
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch exports")
+		return
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+	job, err = h.exports.Create(userID, format, utils.HashToken(token), time.Now().Add(export.Retention))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create export")
+		return
+	}
+	h.runner.Wake()
+
+	job.DownloadURL = "/api/v1/public/exports/" + token
+	c.Header("Location", "/api/v1/exports/"+strconv.Itoa(job.ID))
+	utils.SuccessResponse(c, http.StatusAccepted, "Export started", job)
+}
+
+// GetExports - export background milik user yang belum kadaluarsa
+func (h *ExportHandler) GetExports(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	jobs, err := h.exports.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch exports")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Exports retrieved successfully", jobs)
+}
+
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	job, ok := h.findExport(c)
+	if !ok {
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Export retrieved successfully", job)
+}
+
+// DownloadExport - unduh export milik user yang login
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	job, ok := h.findExport(c)
+	if !ok {
+		return
+	}
+	h.download(c, job)
+}
+
+// DownloadPublicExport - link download dari respons job; token menggantikan login
+func (h *ExportHandler) DownloadPublicExport(c *gin.Context) {
+	job, err := h.exports.ByToken(utils.HashToken(c.Param("token")))
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch export")
+		return
+	}
+	h.download(c, job)
+}
+
+func (h *ExportHandler) download(c *gin.Context, job models.ExportJob) {
+	switch {
+	case job.ExpiresAt.Before(time.Now()):
+		utils.ErrorResponse(c, http.StatusGone, "Export has expired")
+		return
+	case job.Status == models.ExportFailed:
+		utils.ErrorResponse(c, http.StatusGone, "Export failed; request a new one")
+		return
+	case job.Status != models.ExportCompleted:
+		c.Header("Retry-After", "5")
+		utils.ErrorResponse(c, http.StatusConflict, "Export is not ready yet")
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(job.Format))
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename(job.Format, *job.FinishedAt)+`"`)
+	c.Header("Content-Length", strconv.FormatInt(job.Size, 10))
+	c.Status(http.StatusOK)
+	if err := h.exports.Copy(c.Request.Context(), c.Writer, job.ID); err != nil {
+		log.Printf("export: download %d: %v", job.ID, err)
+	}
+}
+
+func (h *ExportHandler) findExport(c *gin.Context) (models.ExportJob, bool) {
+	userID := c.GetInt("user_id")
+
+	var job models.ExportJob
+	exportID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+		return job, false
+	}
+
+	job, err = h.exports.Get(userID, exportID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+		return job, false
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch export")
+		return job, false
+	}
+	return job, true
+}
@@ -0,0 +1,1042 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/graphql"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	gophers "github.com/graph-gophers/graphql-go"
+)
+
+// Batas /graphql
+const (
+	graphqlMaxDepth      = 8
+	graphqlMaxComplexity = 5000
+	graphqlMaxBatch      = 10
+	graphqlMaxTasks      = 500
+	graphqlDefaultLimit  = 100 // sama dengan default limit di schema
+	maxGraphQLBody       = 1 << 20
+)
+
+// graphqlSchema - schema /graphql. ID sama dengan ID di REST; mutation memakai
+// jalur yang sama dengan endpoint /tasks, termasuk syarat versi (If-Match).
+const graphqlSchema = `
+scalar Time
+
+enum Priority { HIGH MEDIUM LOW }
+
+enum Category { PERSONAL WORK URGENT }
+
+enum TaskEventType { CREATED UPDATED DELETED }
+
+type User {
+  id: ID!
+  name: String!
+  email: String!
+  createdAt: Time!
+}
+
+type Project {
+  id: ID!
+  workspaceId: ID
+  name: String!
+  description: String!
+  color: String!
+  isArchived: Boolean!
+  createdAt: Time!
+  updatedAt: Time!
+}
+
+type Task {
+  id: ID!
+  workspaceId: ID
+  title: String!
+  description: String
+  priority: Priority!
+  category: Category!
+  "Workflow status key of the task's board."
+  status: String!
+  position: String!
+  isCompleted: Boolean!
+  dueDate: Time
+  "Incremented on every change; pass it as ` + "`version`" + ` to mutations to avoid lost updates."
+  version: Int!
+  owner: User!
+  assignee: User
+  project: Project
+  createdAt: Time!
+  updatedAt: Time!
+}
+
+type AssigneeStats {
+  "Null for unassigned tasks."
+  assignee: User
+  total: Int!
+  completed: Int!
+  pending: Int!
+  overdue: Int!
+}
+
+type TaskStats {
+  total: Int!
+  completed: Int!
+  pending: Int!
+  highPriority: Int!
+  overdue: Int!
+  byAssignee: [AssigneeStats!]!
+}
+
+"Same filters as the query string of GET /api/v1/tasks."
+input TaskFilter {
+  "Workspace ID, or ` + "`personal`" + ` for tasks outside any workspace."
+  workspaceId: String
+  "User ID, ` + "`me`" + ` or ` + "`none`" + `."
+  assignee: String
+  "User ID or ` + "`me`" + `."
+  createdBy: String
+  projectId: ID
+  priority: Priority
+  category: Category
+  status: String
+  isCompleted: Boolean
+  "Searches title and description."
+  search: String
+  includeArchived: Boolean = false
+}
+
+type Query {
+  me: User!
+  task(id: ID!): Task
+  "Newest first. ` + "`limit`" + ` is at most 500."
+  tasks(filter: TaskFilter, limit: Int = 100, offset: Int = 0): [Task!]!
+  stats(filter: TaskFilter): TaskStats!
+}
+
+input CreateTaskInput {
+  title: String!
+  description: String
+  workspaceId: ID
+  projectId: ID
+  assigneeId: ID
+  priority: Priority
+  category: Category
+  status: String
+  isCompleted: Boolean
+  dueDate: Time
+}
+
+"Fields left out keep their current value; null clears a nullable field."
+input UpdateTaskInput {
+  title: String
+  description: String
+  projectId: ID
+  assigneeId: ID
+  priority: Priority
+  category: Category
+  status: String
+  isCompleted: Boolean
+  dueDate: Time
+}
+
+type Mutation {
+  createTask(input: CreateTaskInput!): Task!
+  updateTask(id: ID!, input: UpdateTaskInput!, version: Int): Task!
+  "Returns the task as it was before deletion."
+  deleteTask(id: ID!, version: Int): Task!
+  toggleComplete(id: ID!, version: Int): Task!
+  moveTask(id: ID!, status: String!, afterId: ID, beforeId: ID): Task!
+  "A null assigneeId unassigns the task."
+  assignTask(id: ID!, assigneeId: ID, version: Int): Task!
+}
+
+type TaskEvent {
+  type: TaskEventType!
+  actor: User
+  task: Task!
+  occurredAt: Time!
+}
+
+type Subscription {
+  "Changes to tasks visible to the user, optionally limited to one workspace (or ` + "`personal`" + `)."
+  taskChanged(workspaceId: String): TaskEvent!
+}
+`
+
+type GraphQLHandler struct {
+	tasks    *service.TaskService
+	users    repository.UserRepository
+	projects repository.ProjectRepository
+	hub      *realtime.Hub
+	schema   *graphql.Schema
+}
+
+func NewGraphQLHandler(tasks *service.TaskService, users repository.UserRepository, projects repository.ProjectRepository, hub *realtime.Hub) *GraphQLHandler {
+	h := &GraphQLHandler{tasks: tasks, users: users, projects: projects, hub: hub}
+	h.schema = graphql.MustSchema(graphqlSchema, &graphqlRoot{h: h}, graphql.Config{
+		MaxDepth:      graphqlMaxDepth,
+		MaxComplexity: graphqlMaxComplexity,
+	})
+	return h
+}
+
+// Nilai enum schema dan nilai model-nya
+var (
+	priorityEnum  = graphql.Enum[models.Priority]{"HIGH": models.PriorityHigh, "MEDIUM": models.PriorityMedium, "LOW": models.PriorityLow}
+	categoryEnum  = graphql.Enum[models.Category]{"PERSONAL": models.CategoryPersonal, "WORK": models.CategoryWork, "URGENT": models.CategoryUrgent}
+	taskEventEnum = graphql.Enum[events.Type]{"CREATED": events.TaskCreated, "UPDATED": events.TaskUpdated, "DELETED": events.TaskDeleted}
+)
+
+type graphqlUserKey struct{}
+
+// graphqlUser - user yang menjalankan operation
+func graphqlUser(ctx context.Context) int {
+	return ctx.Value(graphqlUserKey{}).(int)
+}
+
+func withGraphQLUser(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, graphqlUserKey{}, userID)
+}
+
+// Execute - POST: satu operation, atau array operation (batch) yang dijawab dengan
+// array respons. Subscription dijawab sebagai Server-Sent Events.
+func (h *GraphQLHandler) Execute(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxGraphQLBody+1))
+	if err != nil || len(body) > maxGraphQLBody {
+		c.JSON(http.StatusBadRequest, graphql.ErrorResponse("Request body is too large or unreadable"))
+		return
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var reqs []graphql.Request
+		if err := decodeGraphQL(trimmed, &reqs); err != nil {
+			c.JSON(http.StatusBadRequest, graphql.ErrorResponse("Invalid JSON body: "+err.Error()))
+			return
+		}
+		if len(reqs) == 0 || len(reqs) > graphqlMaxBatch {
+			c.JSON(http.StatusBadRequest, graphql.ErrorResponse(fmt.Sprintf("A batch must contain 1 to %d operations", graphqlMaxBatch)))
+			return
+		}
+
+		responses := make([]*graphql.Response, len(reqs))
+		for i, req := range reqs {
+			p, resp := h.schema.Prepare(req)
+			if resp == nil && p.Kind() == "subscription" {
+				resp = graphql.ErrorResponse("Subscriptions cannot be batched.")
+			}
+			if resp == nil {
+				resp = p.Execute(withGraphQLUser(c.Request.Context(), c.GetInt("user_id")))
+			}
+			responses[i] = resp
+		}
+		c.JSON(http.StatusOK, responses)
+		return
+	}
+
+	var req graphql.Request
+	if err := decodeGraphQL(trimmed, &req); err != nil {
+		c.JSON(http.StatusBadRequest, graphql.ErrorResponse("Invalid JSON body: "+err.Error()))
+		return
+	}
+	h.serve(c, req, true)
+}
+
+// Query - GET dengan ?query=&variables=&operationName=, untuk query dan subscription
+// (EventSource). Mutation hanya lewat POST.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	req := graphql.Request{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if raw := c.Query("variables"); raw != "" {
+		if err := decodeGraphQL(raw, &req.Variables); err != nil {
+			c.JSON(http.StatusBadRequest, graphql.ErrorResponse("Invalid variables: "+err.Error()))
+			return
+		}
+	}
+	h.serve(c, req, false)
+}
+
+func (h *GraphQLHandler) serve(c *gin.Context, req graphql.Request, allowMutation bool) {
+	p, resp := h.schema.Prepare(req)
+	if resp != nil {
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	ctx := withGraphQLUser(c.Request.Context(), c.GetInt("user_id"))
+	switch p.Kind() {
+	case "subscription":
+		h.stream(c, p.Subscribe(ctx))
+	case "mutation":
+		if !allowMutation {
+			c.Header("Allow", "POST")
+			c.JSON(http.StatusMethodNotAllowed, graphql.ErrorResponse("Mutations must be sent with POST."))
+			return
+		}
+		fallthrough
+	default:
+		c.JSON(http.StatusOK, p.Execute(ctx))
+	}
+}
+
+// stream - hasil subscription sebagai SSE: "next" per event, "complete" saat stream selesai
+func (h *GraphQLHandler) stream(c *gin.Context, results <-chan *graphql.Response) {
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result, ok := <-results:
+			if !ok {
+				fmt.Fprint(c.Writer, "event: complete\ndata:\n\n")
+				c.Writer.Flush()
+				return
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				log.Printf("graphql: failed to encode subscription result: %v", err)
+				continue
+			}
+			fmt.Fprintf(c.Writer, "event: next\ndata: %s\n\n", data)
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// decodeGraphQL - angka variable tetap json.Number supaya Int/ID tidak kehilangan presisi
+func decodeGraphQL(raw string, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// graphqlRoot - resolver field Query, Mutation dan Subscription
+type graphqlRoot struct {
+	h *GraphQLHandler
+}
+
+func (r *graphqlRoot) Me(ctx context.Context) (*userResolver, error) {
+	return newGraphQLRefs(r.h, nil).user(graphqlUser(ctx))
+}
+
+func (r *graphqlRoot) Task(ctx context.Context, args struct{ ID gophers.ID }) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.Get(graphqlUser(ctx), taskID)
+	var se *service.Error
+	if errors.As(err, &se) && se.Status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+func (r *graphqlRoot) Tasks(ctx context.Context, args struct {
+	Filter *taskFilterInput
+	// NullInt: graph-gophers tidak bisa menulis default ke *int32
+	Limit  gophers.NullInt
+	Offset gophers.NullInt
+}) ([]*taskResolver, error) {
+	limit, offset := intArg(args.Limit.Value, graphqlDefaultLimit), intArg(args.Offset.Value, 0)
+	if limit < 1 || limit > graphqlMaxTasks || offset < 0 {
+		return nil, graphqlError(&service.Error{Status: http.StatusBadRequest, Message: fmt.Sprintf("limit must be between 1 and %d and offset must not be negative", graphqlMaxTasks)})
+	}
+	get, err := taskFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	f := filterFromQuery(get)
+	f.Limit, f.Offset = limit, offset
+	tasks, err := r.h.tasks.List(graphqlUser(ctx), f)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+
+	refs := newGraphQLRefs(r.h, tasks)
+	resolvers := make([]*taskResolver, len(tasks))
+	for i, task := range tasks {
+		resolvers[i] = &taskResolver{task: task, refs: refs}
+	}
+	return resolvers, nil
+}
+
+func (r *graphqlRoot) Stats(ctx context.Context, args struct{ Filter *taskFilterInput }) (*statsResolver, error) {
+	get, err := taskFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := r.h.tasks.Stats(graphqlUser(ctx), filterFromQuery(get))
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+
+	var assignees []int
+	for _, s := range stats.ByAssignee {
+		if s.AssigneeID != nil {
+			assignees = append(assignees, *s.AssigneeID)
+		}
+	}
+	return &statsResolver{stats: stats, refs: newGraphQLRefs(r.h, nil, assignees...)}, nil
+}
+
+// createTaskInput - input CreateTaskInput
+type createTaskInput struct {
+	Title       string
+	Description *string
+	WorkspaceID *gophers.ID
+	ProjectID   *gophers.ID
+	AssigneeID  *gophers.ID
+	Priority    *string
+	Category    *string
+	Status      *string
+	IsCompleted *bool
+	DueDate     *graphql.Time
+}
+
+func (r *graphqlRoot) CreateTask(ctx context.Context, args struct{ Input createTaskInput }) (*taskResolver, error) {
+	input := args.Input
+	req := models.CreateTaskRequest{
+		Title:       input.Title,
+		Description: input.Description,
+		IsCompleted: input.IsCompleted,
+	}
+	var err error
+	if req.WorkspaceID, err = optionalID(input.WorkspaceID); err != nil {
+		return nil, err
+	}
+	if req.ProjectID, err = optionalID(input.ProjectID); err != nil {
+		return nil, err
+	}
+	if req.AssigneeID, err = optionalID(input.AssigneeID); err != nil {
+		return nil, err
+	}
+	if input.Priority != nil {
+		req.Priority = priorityEnum.Value(*input.Priority)
+	}
+	if input.Category != nil {
+		req.Category = categoryEnum.Value(*input.Category)
+	}
+	if input.Status != nil {
+		req.Status = *input.Status
+	}
+	if input.DueDate != nil {
+		req.DueDate = &input.DueDate.Time
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, graphqlError(&service.Error{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	task, err := r.h.tasks.Create(graphqlUser(ctx), req)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+// updateTaskInput - input UpdateTaskInput. Field yang tidak dikirim punya Set
+// false; null berarti Set true tanpa Value.
+type updateTaskInput struct {
+	Title       gophers.NullString
+	Description gophers.NullString
+	ProjectID   gophers.NullID
+	AssigneeID  gophers.NullID
+	Priority    nullPriority
+	Category    nullCategory
+	Status      gophers.NullString
+	IsCompleted gophers.NullBool
+	DueDate     graphql.NullTime
+}
+
+func (r *graphqlRoot) UpdateTask(ctx context.Context, args struct {
+	ID      gophers.ID
+	Input   updateTaskInput
+	Version *int32
+}) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.Update(graphqlUser(ctx), taskID, graphqlVersion(args.Version), func(current models.Task) (models.TaskDocument, error) {
+		doc := taskDocument(current)
+		if err := applyTaskInput(&doc, args.Input); err != nil {
+			return doc, err
+		}
+		return doc, binding.Validator.ValidateStruct(doc)
+	})
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+func (r *graphqlRoot) DeleteTask(ctx context.Context, args struct {
+	ID      gophers.ID
+	Version *int32
+}) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.Delete(graphqlUser(ctx), taskID, graphqlVersion(args.Version))
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+func (r *graphqlRoot) ToggleComplete(ctx context.Context, args struct {
+	ID      gophers.ID
+	Version *int32
+}) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.ToggleComplete(graphqlUser(ctx), taskID, graphqlVersion(args.Version))
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+func (r *graphqlRoot) MoveTask(ctx context.Context, args struct {
+	ID       gophers.ID
+	Status   string
+	AfterID  *gophers.ID
+	BeforeID *gophers.ID
+}) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	req := models.MoveTaskRequest{Status: args.Status}
+	if req.AfterID, err = optionalID(args.AfterID); err != nil {
+		return nil, err
+	}
+	if req.BeforeID, err = optionalID(args.BeforeID); err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.Move(graphqlUser(ctx), taskID, req)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+func (r *graphqlRoot) AssignTask(ctx context.Context, args struct {
+	ID         gophers.ID
+	AssigneeID *gophers.ID
+	Version    *int32
+}) (*taskResolver, error) {
+	taskID, err := graphqlID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	assigneeID, err := optionalID(args.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.h.tasks.Assign(graphqlUser(ctx), taskID, assigneeID, graphqlVersion(args.Version))
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return r.h.taskResolver(task), nil
+}
+
+// TaskChanged - event task dari hub realtime (sudah disaring sesuai izin user)
+func (r *graphqlRoot) TaskChanged(ctx context.Context, args struct{ WorkspaceID *string }) (<-chan *taskEventResolver, error) {
+	var workspace string
+	if args.WorkspaceID != nil {
+		workspace = *args.WorkspaceID
+	}
+	if workspace != "" && workspace != "personal" {
+		if _, err := strconv.Atoi(workspace); err != nil {
+			return nil, graphqlError(&service.Error{Status: http.StatusBadRequest, Message: "workspaceId must be a workspace ID or personal"})
+		}
+	}
+
+	client := r.h.hub.Subscribe(graphqlUser(ctx))
+	out := make(chan *taskEventResolver)
+	go func() {
+		defer close(out)
+		defer r.h.hub.Unsubscribe(client)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-client.C:
+				if !ok {
+					return
+				}
+				var payload realtime.Payload
+				if err := json.Unmarshal([]byte(e.Data), &payload); err != nil || !inWorkspace(payload.Task, workspace) {
+					continue
+				}
+				if taskEventEnum.Name(payload.Type) == "" {
+					continue
+				}
+				// Loader baru per event supaya nama user tidak basi selama stream terbuka
+				event := &taskEventResolver{payload: payload, refs: newGraphQLRefs(r.h, []models.Task{payload.Task}, payload.ActorID)}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// inWorkspace - filter workspaceId subscription ("" = semua, "personal" = di luar workspace)
+func inWorkspace(task models.Task, workspace string) bool {
+	switch workspace {
+	case "":
+		return true
+	case "personal":
+		return task.WorkspaceID == nil
+	}
+	return task.WorkspaceID != nil && strconv.Itoa(*task.WorkspaceID) == workspace
+}
+
+// graphqlRefs - owner, assignee dan project task dalam satu hasil, dimuat dengan
+// satu query per jenis saat field pertama memintanya
+type graphqlRefs struct {
+	users    *graphql.Batch[int, models.User]
+	projects *graphql.Batch[int, models.Project]
+}
+
+// newGraphQLRefs - refs untuk tasks ditambah user lain yang mungkin diminta
+func newGraphQLRefs(h *GraphQLHandler, tasks []models.Task, userIDs ...int) *graphqlRefs {
+	var projectIDs []int
+	for _, task := range tasks {
+		userIDs = append(userIDs, task.UserID)
+		if task.AssigneeID != nil {
+			userIDs = append(userIDs, *task.AssigneeID)
+		}
+		if task.ProjectID != nil {
+			projectIDs = append(projectIDs, *task.ProjectID)
+		}
+	}
+	return &graphqlRefs{
+		users:    graphql.NewBatch(h.loadUsers, userIDs...),
+		projects: graphql.NewBatch(h.loadProjects, projectIDs...),
+	}
+}
+
+func (r *graphqlRefs) user(id int) (*userResolver, error) {
+	user, ok, err := r.users.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, graphqlError(&service.Error{Status: http.StatusNotFound, Message: "User not found"})
+	}
+	return &userResolver{user: user}, nil
+}
+
+// optionalUser - user untuk foreign key yang boleh null
+func (r *graphqlRefs) optionalUser(id *int) (*userResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+	return r.user(*id)
+}
+
+func (r *graphqlRefs) project(id *int) (*projectResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+	project, ok, err := r.projects.Get(*id)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &projectResolver{project: project}, nil
+}
+
+func (h *GraphQLHandler) loadUsers(ids []int) (map[int]models.User, error) {
+	users, err := h.users.GetMany(ids)
+	if err != nil {
+		log.Printf("graphql: failed to load users: %v", err)
+		return nil, errors.New("Failed to fetch users")
+	}
+	return users, nil
+}
+
+// loadProjects - hanya dipanggil untuk project milik task yang terlihat, jadi tanpa cek izin lagi
+func (h *GraphQLHandler) loadProjects(ids []int) (map[int]models.Project, error) {
+	projects, err := h.projects.GetMany(ids)
+	if err != nil {
+		log.Printf("graphql: failed to load projects: %v", err)
+		return nil, errors.New("Failed to fetch projects")
+	}
+	return projects, nil
+}
+
+// taskResolver - hasil mutation atau Query.task: satu task dengan refs-nya sendiri
+func (h *GraphQLHandler) taskResolver(task models.Task) *taskResolver {
+	return &taskResolver{task: task, refs: newGraphQLRefs(h, []models.Task{task})}
+}
+
+type userResolver struct {
+	user models.User
+}
+
+func (r *userResolver) ID() gophers.ID          { return toGraphQLID(r.user.ID) }
+func (r *userResolver) Name() string            { return r.user.Name }
+func (r *userResolver) Email() string           { return r.user.Email }
+func (r *userResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.user.CreatedAt} }
+
+type projectResolver struct {
+	project models.Project
+}
+
+func (r *projectResolver) ID() gophers.ID           { return toGraphQLID(r.project.ID) }
+func (r *projectResolver) WorkspaceID() *gophers.ID { return optionalGraphQLID(r.project.WorkspaceID) }
+func (r *projectResolver) Name() string             { return r.project.Name }
+func (r *projectResolver) Description() string      { return r.project.Description }
+func (r *projectResolver) Color() string            { return r.project.Color }
+func (r *projectResolver) IsArchived() bool         { return r.project.IsArchived }
+func (r *projectResolver) CreatedAt() graphql.Time  { return graphql.Time{Time: r.project.CreatedAt} }
+func (r *projectResolver) UpdatedAt() graphql.Time  { return graphql.Time{Time: r.project.UpdatedAt} }
+
+type taskResolver struct {
+	task models.Task
+	refs *graphqlRefs
+}
+
+func (r *taskResolver) ID() gophers.ID           { return toGraphQLID(r.task.ID) }
+func (r *taskResolver) WorkspaceID() *gophers.ID { return optionalGraphQLID(r.task.WorkspaceID) }
+func (r *taskResolver) Title() string            { return r.task.Title }
+func (r *taskResolver) Description() *string     { return r.task.Description }
+func (r *taskResolver) Priority() string         { return priorityEnum.Name(r.task.Priority) }
+func (r *taskResolver) Category() string         { return categoryEnum.Name(r.task.Category) }
+func (r *taskResolver) Status() string           { return r.task.Status }
+func (r *taskResolver) Position() string         { return r.task.Position }
+func (r *taskResolver) IsCompleted() bool        { return r.task.IsCompleted }
+func (r *taskResolver) DueDate() *graphql.Time   { return graphql.TimeOf(r.task.DueDate) }
+func (r *taskResolver) Version() int32           { return int32(r.task.Version) }
+func (r *taskResolver) CreatedAt() graphql.Time  { return graphql.Time{Time: r.task.CreatedAt} }
+func (r *taskResolver) UpdatedAt() graphql.Time  { return graphql.Time{Time: r.task.UpdatedAt} }
+
+func (r *taskResolver) Owner() (*userResolver, error) { return r.refs.user(r.task.UserID) }
+
+func (r *taskResolver) Assignee() (*userResolver, error) {
+	return r.refs.optionalUser(r.task.AssigneeID)
+}
+
+func (r *taskResolver) Project() (*projectResolver, error) { return r.refs.project(r.task.ProjectID) }
+
+type statsResolver struct {
+	stats models.TaskStats
+	refs  *graphqlRefs
+}
+
+func (r *statsResolver) Total() int32        { return int32(r.stats.Total) }
+func (r *statsResolver) Completed() int32    { return int32(r.stats.Completed) }
+func (r *statsResolver) Pending() int32      { return int32(r.stats.Pending) }
+func (r *statsResolver) HighPriority() int32 { return int32(r.stats.HighPriority) }
+func (r *statsResolver) Overdue() int32      { return int32(r.stats.Overdue) }
+
+func (r *statsResolver) ByAssignee() []*assigneeStatsResolver {
+	resolvers := make([]*assigneeStatsResolver, len(r.stats.ByAssignee))
+	for i, s := range r.stats.ByAssignee {
+		resolvers[i] = &assigneeStatsResolver{stats: s, refs: r.refs}
+	}
+	return resolvers
+}
+
+type assigneeStatsResolver struct {
+	stats models.AssigneeStats
+	refs  *graphqlRefs
+}
+
+func (r *assigneeStatsResolver) Assignee() (*userResolver, error) {
+	return r.refs.optionalUser(r.stats.AssigneeID)
+}
+func (r *assigneeStatsResolver) Total() int32     { return int32(r.stats.Total) }
+func (r *assigneeStatsResolver) Completed() int32 { return int32(r.stats.Completed) }
+func (r *assigneeStatsResolver) Pending() int32   { return int32(r.stats.Pending) }
+func (r *assigneeStatsResolver) Overdue() int32   { return int32(r.stats.Overdue) }
+
+type taskEventResolver struct {
+	payload realtime.Payload
+	refs    *graphqlRefs
+}
+
+func (r *taskEventResolver) Type() string { return taskEventEnum.Name(r.payload.Type) }
+
+func (r *taskEventResolver) Actor() (*userResolver, error) {
+	if r.payload.ActorID == 0 {
+		return nil, nil
+	}
+	return r.refs.user(r.payload.ActorID)
+}
+
+func (r *taskEventResolver) Task() *taskResolver {
+	return &taskResolver{task: r.payload.Task, refs: r.refs}
+}
+
+func (r *taskEventResolver) OccurredAt() graphql.Time {
+	return graphql.Time{Time: r.payload.OccurredAt}
+}
+
+// nullPriority - Priority di UpdateTaskInput yang membedakan null dari tidak dikirim
+type nullPriority struct {
+	Value *models.Priority
+	Set   bool
+}
+
+func (nullPriority) ImplementsGraphQLType(name string) bool { return name == "Priority" }
+
+func (p *nullPriority) UnmarshalGraphQL(input interface{}) error {
+	p.Set = true
+	if name, ok := input.(string); ok {
+		v := priorityEnum.Value(name)
+		p.Value = &v
+	}
+	return nil
+}
+
+func (*nullPriority) Nullable() {}
+
+// nullCategory - Category di UpdateTaskInput yang membedakan null dari tidak dikirim
+type nullCategory struct {
+	Value *models.Category
+	Set   bool
+}
+
+func (nullCategory) ImplementsGraphQLType(name string) bool { return name == "Category" }
+
+func (c *nullCategory) UnmarshalGraphQL(input interface{}) error {
+	c.Set = true
+	if name, ok := input.(string); ok {
+		v := categoryEnum.Value(name)
+		c.Value = &v
+	}
+	return nil
+}
+
+func (*nullCategory) Nullable() {}
+
+// taskFilterInput - input TaskFilter
+type taskFilterInput struct {
+	WorkspaceID *string
+	Assignee    *string
+	CreatedBy   *string
+	ProjectID   *gophers.ID
+	Priority    *string
+	Category    *string
+	Status      *string
+	IsCompleted *bool
+	Search      *string
+	// NullBool: graph-gophers tidak bisa menulis default false ke *bool
+	IncludeArchived gophers.NullBool
+}
+
+// taskFilter - TaskFilter sebagai getter untuk filterFromQuery, dengan key dan
+// format nilai yang sama seperti query string GET /tasks
+func taskFilter(filter *taskFilterInput) (func(key string) string, error) {
+	values := map[string]string{}
+	if filter == nil {
+		filter = &taskFilterInput{}
+	}
+
+	// ID, atau kata kunci yang juga diterima query string
+	var projectID *string
+	if filter.ProjectID != nil {
+		s := string(*filter.ProjectID)
+		projectID = &s
+	}
+	ids := []struct {
+		key, name string
+		value     *string
+		keywords  []string
+	}{
+		{"workspace_id", "workspaceId", filter.WorkspaceID, []string{"personal"}},
+		{"assignee", "assignee", filter.Assignee, []string{"me", "none"}},
+		{"created_by", "createdBy", filter.CreatedBy, []string{"me"}},
+		{"project_id", "projectId", projectID, nil},
+	}
+	for _, f := range ids {
+		if f.value == nil {
+			continue
+		}
+		v := *f.value
+		if _, err := strconv.Atoi(v); err != nil && v != "" && !slices.Contains(f.keywords, v) {
+			return nil, graphqlError(&service.Error{Status: http.StatusBadRequest, Message: "Invalid filter value for " + f.name})
+		}
+		values[f.key] = v
+	}
+
+	if filter.Priority != nil {
+		values["priority"] = string(priorityEnum.Value(*filter.Priority))
+	}
+	if filter.Category != nil {
+		values["category"] = string(categoryEnum.Value(*filter.Category))
+	}
+	if filter.Status != nil {
+		values["status"] = *filter.Status
+	}
+	if filter.Search != nil {
+		values["search"] = *filter.Search
+	}
+	if filter.IsCompleted != nil {
+		values["is_completed"] = strconv.FormatBool(*filter.IsCompleted)
+	}
+	if v := filter.IncludeArchived.Value; v != nil && *v {
+		values["include_archived"] = "true"
+	}
+
+	return func(key string) string { return values[key] }, nil
+}
+
+// applyTaskInput - UpdateTaskInput di atas dokumen task sekarang (seperti merge patch)
+func applyTaskInput(doc *models.TaskDocument, input updateTaskInput) error {
+	if input.Title.Set {
+		if input.Title.Value == nil {
+			return errors.New("title cannot be null")
+		}
+		doc.Title = *input.Title.Value
+	}
+	if input.Description.Set {
+		doc.Description = input.Description.Value
+	}
+	if input.ProjectID.Set {
+		id, err := optionalID(input.ProjectID.Value)
+		if err != nil {
+			return err
+		}
+		doc.ProjectID = id
+	}
+	if input.AssigneeID.Set {
+		id, err := optionalID(input.AssigneeID.Value)
+		if err != nil {
+			return err
+		}
+		doc.AssigneeID = id
+	}
+	if input.Priority.Set {
+		doc.Priority = ""
+		if input.Priority.Value != nil {
+			doc.Priority = *input.Priority.Value
+		}
+	}
+	if input.Category.Set {
+		doc.Category = ""
+		if input.Category.Value != nil {
+			doc.Category = *input.Category.Value
+		}
+	}
+	if input.Status.Set {
+		doc.Status = ""
+		if input.Status.Value != nil {
+			doc.Status = *input.Status.Value
+		}
+	}
+	if input.IsCompleted.Set {
+		doc.IsCompleted = input.IsCompleted.Value
+	}
+	if input.DueDate.Set {
+		doc.DueDate = input.DueDate.Value
+	}
+	return nil
+}
+
+// graphqlID - argumen ID sebagai ID numerik di database
+func graphqlID(id gophers.ID) (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, graphqlError(&service.Error{Status: http.StatusBadRequest, Message: fmt.Sprintf("Invalid ID %q", string(id))})
+	}
+	return n, nil
+}
+
+// optionalID - ID yang boleh null atau tidak dikirim
+func optionalID(id *gophers.ID) (*int, error) {
+	if id == nil {
+		return nil, nil
+	}
+	n, err := graphqlID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func toGraphQLID(id int) gophers.ID {
+	return gophers.ID(strconv.Itoa(id))
+}
+
+func optionalGraphQLID(id *int) *gophers.ID {
+	if id == nil {
+		return nil
+	}
+	v := toGraphQLID(*id)
+	return &v
+}
+
+// intArg - argumen Int; null berarti fallback
+func intArg(v *int32, fallback int) int {
+	if v == nil {
+		return fallback
+	}
+	return int(*v)
+}
+
+// graphqlVersion - argumen version sebagai syarat versi (nil = tanpa syarat, seperti tanpa If-Match)
+func graphqlVersion(version *int32) []int64 {
+	if version == nil {
+		return nil
+	}
+	return []int64{int64(*version)}
+}
+
+// graphqlError - taskError sebagai error GraphQL; status HTTP dan kodenya di
+// extensions, ditambah versi terbaru untuk konflik versi
+func graphqlError(err error) error {
+	var te *service.Error
+	if !errors.As(err, &te) {
+		log.Printf("graphql: %v", err)
+		te = &service.Error{Status: http.StatusInternalServerError, Message: "Internal server error"}
+	}
+
+	extensions := map[string]interface{}{"code": te.Code(), "status": te.Status}
+	if te.Status == http.StatusPreconditionFailed {
+		extensions["version"] = te.Version
+	}
+	return graphql.NewError(te.Message, extensions)
+}
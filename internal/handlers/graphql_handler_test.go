@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// graphqlServer - /graphql di atas database SQLite dengan hub realtime yang berjalan
+type graphqlServer struct {
+	router     *gin.Engine
+	alice, bob int
+}
+
+func newGraphQLServer(t *testing.T) *graphqlServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := dbtest.Open(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	hub := realtime.NewHub(db, "")
+	go hub.Run(ctx)
+	bus := events.NewBus()
+	bus.Subscribe(hub.HandleEvent)
+
+	users := repository.NewUserRepository(db)
+	tasks := service.NewTaskService(repository.NewTaskRepository(db), users, authz.New(db), bus)
+	h := NewGraphQLHandler(tasks, users, repository.NewProjectRepository(db), hub)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.GetHeader("X-User"))
+		c.Set("user_id", id)
+	})
+	router.POST("/graphql", h.Execute)
+	router.GET("/graphql", h.Query)
+	s := &graphqlServer{router: router}
+	for _, u := range []struct {
+		id   *int
+		name string
+	}{{&s.alice, "alice"}, {&s.bob, "bob"}} {
+		user, err := users.Create(u.name, u.name+"@example.com", "hash")
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		*u.id = user.ID
+	}
+	return s
+}
+
+// graphqlResult - respons satu operation
+type graphqlResult struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func (s *graphqlServer) post(t *testing.T, userID int, body string) (*httptest.ResponseRecorder, graphqlResult) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("X-User", strconv.Itoa(userID))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var result graphqlResult
+	if !strings.HasPrefix(strings.TrimSpace(w.Body.String()), "[") {
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("decode %s: %v", w.Body, err)
+		}
+	}
+	return w, result
+}
+
+// run - kirim query dengan variables dan decode data ke v; gagal kalau ada error
+func (s *graphqlServer) run(t *testing.T, userID int, query string, vars map[string]interface{}, v interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": vars})
+	w, result := s.post(t, userID, string(body))
+	if w.Code != http.StatusOK || len(result.Errors) > 0 {
+		t.Fatalf("%s: %d %s", query, w.Code, w.Body)
+	}
+	data, _ := json.Marshal(result.Data)
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decode data %s: %v", data, err)
+	}
+}
+
+type graphqlTask struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Priority    string  `json:"priority"`
+	Category    string  `json:"category"`
+	DueDate     *string `json:"dueDate"`
+	Version     int     `json:"version"`
+	Owner       struct {
+		Email string `json:"email"`
+	} `json:"owner"`
+	Assignee *struct {
+		ID string `json:"id"`
+	} `json:"assignee"`
+}
+
+func (s *graphqlServer) createTask(t *testing.T, userID int, input map[string]interface{}) graphqlTask {
+	t.Helper()
+	var out struct{ CreateTask graphqlTask }
+	s.run(t, userID, `mutation($input: CreateTaskInput!) {
+		createTask(input: $input) { id title description priority category dueDate version owner { email } assignee { id } }
+	}`, map[string]interface{}{"input": input}, &out)
+	return out.CreateTask
+}
+
+func TestGraphQLCreateAndQueryTasks(t *testing.T) {
+	s := newGraphQLServer(t)
+	created := s.createTask(t, s.alice, map[string]interface{}{
+		"title": "Write spec", "description": "draft", "priority": "HIGH", "dueDate": "2024-05-01T17:00:00Z",
+	})
+	if created.Title != "Write spec" || created.Priority != "HIGH" || created.Category != "PERSONAL" ||
+		created.DueDate == nil || *created.DueDate != "2024-05-01T17:00:00Z" || created.Owner.Email != "alice@example.com" {
+		t.Errorf("created = %+v", created)
+	}
+	s.createTask(t, s.alice, map[string]interface{}{"title": "Second"})
+	s.createTask(t, s.bob, map[string]interface{}{"title": "Not alice's"})
+
+	var list struct {
+		Tasks []graphqlTask
+		Me    struct{ Email string }
+		Stats struct{ Total, HighPriority int }
+	}
+	s.run(t, s.alice, `query($limit: Int) {
+		tasks(limit: $limit) { id title owner { email } }
+		me { email }
+		stats(filter: {isCompleted: false}) { total highPriority }
+	}`, map[string]interface{}{"limit": 10}, &list)
+	if len(list.Tasks) != 2 || list.Me.Email != "alice@example.com" || list.Stats.Total != 2 || list.Stats.HighPriority != 1 {
+		t.Errorf("list = %+v", list)
+	}
+	for _, task := range list.Tasks {
+		if task.Owner.Email != "alice@example.com" {
+			t.Errorf("task %s owner = %q", task.ID, task.Owner.Email)
+		}
+	}
+
+	var one struct{ Task *graphqlTask }
+	s.run(t, s.alice, `query($id: ID!) { task(id: $id) { title } }`, map[string]interface{}{"id": created.ID}, &one)
+	if one.Task == nil || one.Task.Title != "Write spec" {
+		t.Errorf("task = %+v", one.Task)
+	}
+	s.run(t, s.alice, `{ task(id: 99999) { title } }`, nil, &one)
+	if one.Task != nil {
+		t.Errorf("missing task = %+v, want null", one.Task)
+	}
+}
+
+func TestGraphQLUpdateTaskMergeSemantics(t *testing.T) {
+	s := newGraphQLServer(t)
+	created := s.createTask(t, s.alice, map[string]interface{}{
+		"title": "Plan", "description": "keep?", "priority": "HIGH", "category": "WORK", "dueDate": "2024-05-01T17:00:00Z",
+	})
+
+	// Field yang tidak dikirim tetap; null mengosongkan atau kembali ke default
+	var out struct{ UpdateTask graphqlTask }
+	s.run(t, s.alice, `mutation($id: ID!) {
+		updateTask(id: $id, input: {description: null, dueDate: null, priority: null}) {
+			title description priority category dueDate version
+		}
+	}`, map[string]interface{}{"id": created.ID}, &out)
+	got := out.UpdateTask
+	if got.Title != "Plan" || got.Description != nil || got.DueDate != nil || got.Priority != "MEDIUM" || got.Category != "WORK" || got.Version != created.Version+1 {
+		t.Errorf("updated = %+v", got)
+	}
+
+	_, result := s.post(t, s.alice, `{"query": "mutation { updateTask(id: `+created.ID+`, input: {title: null}) { id } }"}`)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "title cannot be null" {
+		t.Errorf("null title errors = %+v", result.Errors)
+	}
+}
+
+func TestGraphQLErrorExtensions(t *testing.T) {
+	s := newGraphQLServer(t)
+	created := s.createTask(t, s.alice, map[string]interface{}{"title": "Plan"})
+
+	_, result := s.post(t, s.alice, `{"query": "mutation { toggleComplete(id: `+created.ID+`, version: 99) { id } }"}`)
+	if len(result.Errors) != 1 {
+		t.Fatalf("errors = %+v", result.Errors)
+	}
+	ext := result.Errors[0].Extensions
+	if ext["status"] != float64(http.StatusPreconditionFailed) || ext["version"] != float64(created.Version) || ext["code"] == nil {
+		t.Errorf("extensions = %v", ext)
+	}
+	if string(result.Data["toggleComplete"]) != "" {
+		t.Errorf("data = %s, want null", result.Data)
+	}
+
+	// Task orang lain tidak terlihat
+	_, result = s.post(t, s.bob, `{"query": "mutation { deleteTask(id: `+created.ID+`) { id } }"}`)
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["status"] != float64(http.StatusNotFound) {
+		t.Errorf("bob delete errors = %+v", result.Errors)
+	}
+
+	_, result = s.post(t, s.alice, `{"query": "{ tasks(limit: 501) { id } }"}`)
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["status"] != float64(http.StatusBadRequest) {
+		t.Errorf("limit errors = %+v", result.Errors)
+	}
+	_, result = s.post(t, s.alice, `{"query": "{ tasks(filter: {assignee: \"everyone\"}) { id } }"}`)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Invalid filter value for assignee" {
+		t.Errorf("filter errors = %+v", result.Errors)
+	}
+}
+
+func TestGraphQLRequestErrors(t *testing.T) {
+	s := newGraphQLServer(t)
+	tests := []struct {
+		name, body string
+		status     int
+		message    string
+	}{
+		{"invalid JSON", `{"query": `, http.StatusBadRequest, "Invalid JSON body"},
+		{"empty query", `{"query": ""}`, http.StatusBadRequest, "Must provide query string."},
+		{"syntax error", `{"query": "{ tasks { id "}`, http.StatusBadRequest, "Expected"},
+		{"unknown field", `{"query": "{ tasks { nope } }"}`, http.StatusBadRequest, `Cannot query field "nope"`},
+		{"too complex", `{"query": "{ tasks(limit: 500) { id title description status position owner { id name email } assignee { id name email } project { id name } } }"}`, http.StatusBadRequest, "Query complexity"},
+		{"variable type", `{"query": "query($l: Int) { tasks(limit: $l) { id } }", "variables": {"l": "ten"}}`, http.StatusBadRequest, "cannot use string as Int"},
+	}
+	for _, tt := range tests {
+		w, result := s.post(t, s.alice, tt.body)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, w.Code, tt.status, w.Body)
+			continue
+		}
+		if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, tt.message) {
+			t.Errorf("%s: errors = %+v, want %q", tt.name, result.Errors, tt.message)
+		}
+	}
+}
+
+func TestGraphQLBatchAndGet(t *testing.T) {
+	s := newGraphQLServer(t)
+
+	w, _ := s.post(t, s.alice, `[{"query": "{ me { email } }"}, {"query": "{ nope }"}, {"query": "subscription { taskChanged { type } }"}]`)
+	var batch []graphqlResult
+	if err := json.Unmarshal(w.Body.Bytes(), &batch); err != nil || w.Code != http.StatusOK || len(batch) != 3 {
+		t.Fatalf("batch = %d %s", w.Code, w.Body)
+	}
+	if len(batch[0].Errors) != 0 || len(batch[1].Errors) == 0 || len(batch[2].Errors) != 1 || batch[2].Errors[0].Message != "Subscriptions cannot be batched." {
+		t.Errorf("batch = %s", w.Body)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil)
+		req.Header.Set("X-User", strconv.Itoa(s.alice))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+	if w := get(`{ me { email } }`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "alice@example.com") {
+		t.Errorf("GET query = %d %s", w.Code, w.Body)
+	}
+	if w := get(`mutation { createTask(input: {title: "x"}) { id } }`); w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "POST" {
+		t.Errorf("GET mutation = %d %s", w.Code, w.Body)
+	}
+}
+
+func TestGraphQLSubscription(t *testing.T) {
+	s := newGraphQLServer(t)
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	query := url.QueryEscape(`subscription { taskChanged(workspaceId: "personal") { type actor { email } task { title owner { email } } } }`)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/graphql?query="+query, nil)
+	req.Header.Set("X-User", strconv.Itoa(s.alice))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+
+	// Subscribe terdaftar sebelum header dikirim; event bob tidak terlihat oleh alice
+	s.createTask(t, s.bob, map[string]interface{}{"title": "Hidden"})
+	s.createTask(t, s.alice, map[string]interface{}{"title": "Visible"})
+
+	lines := bufio.NewScanner(resp.Body)
+	for lines.Scan() {
+		data, ok := strings.CutPrefix(lines.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event struct {
+			Data struct {
+				TaskChanged struct {
+					Type  string
+					Actor struct{ Email string }
+					Task  graphqlTask
+				}
+			}
+		}
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+		got := event.Data.TaskChanged
+		if got.Type != "CREATED" || got.Actor.Email != "alice@example.com" || got.Task.Title != "Visible" || got.Task.Owner.Email != "alice@example.com" {
+			t.Errorf("event = %s", data)
+		}
+		return
+	}
+	t.Fatalf("stream ended without an event: %v", lines.Err())
+}
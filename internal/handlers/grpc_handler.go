@@ -0,0 +1,506 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	taskflowv1 "taskflow-api/api/taskflow/v1"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/realtime"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCAuthServer - taskflow.v1.AuthService di atas logika AuthHandler
+type GRPCAuthServer struct {
+	taskflowv1.UnimplementedAuthServiceServer
+	auth *service.AuthService
+}
+
+func NewGRPCAuthServer(auth *service.AuthService) *GRPCAuthServer {
+	return &GRPCAuthServer{auth: auth}
+}
+
+// GRPCPublicMethods - method yang boleh dipanggil tanpa token
+var GRPCPublicMethods = []string{
+	taskflowv1.AuthService_Register_FullMethodName,
+	taskflowv1.AuthService_Login_FullMethodName,
+}
+
+func (s *GRPCAuthServer) Register(ctx context.Context, in *taskflowv1.RegisterRequest) (*taskflowv1.User, error) {
+	req := models.RegisterRequest{Name: in.GetName(), Email: in.GetEmail(), Password: in.GetPassword()}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, grpcStatus(&service.Error{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	user, err := s.auth.Register(req)
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbUser(user), nil
+}
+
+func (s *GRPCAuthServer) Login(ctx context.Context, in *taskflowv1.LoginRequest) (*taskflowv1.LoginResponse, error) {
+	req := models.LoginRequest{Email: in.GetEmail(), Password: in.GetPassword()}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, grpcStatus(&service.Error{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	resp, err := s.auth.Login(req)
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return &taskflowv1.LoginResponse{Token: resp.Token, User: pbUser(resp.User)}, nil
+}
+
+func (s *GRPCAuthServer) GetProfile(ctx context.Context, in *taskflowv1.GetProfileRequest) (*taskflowv1.User, error) {
+	user, err := s.auth.Profile(middleware.GRPCUserID(ctx))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbUser(user), nil
+}
+
+// GRPCTaskServer - taskflow.v1.TaskService; perubahan lewat method inti
+// TaskHandler, jadi izin, event dan webhook sama dengan REST
+type GRPCTaskServer struct {
+	taskflowv1.UnimplementedTaskServiceServer
+	tasks *service.TaskService
+	hub   *realtime.Hub
+}
+
+func NewGRPCTaskServer(tasks *service.TaskService, hub *realtime.Hub) *GRPCTaskServer {
+	return &GRPCTaskServer{tasks: tasks, hub: hub}
+}
+
+func (s *GRPCTaskServer) CreateTask(ctx context.Context, in *taskflowv1.CreateTaskRequest) (*taskflowv1.Task, error) {
+	req := models.CreateTaskRequest{
+		Title:       in.GetTitle(),
+		Description: in.Description,
+		WorkspaceID: intPtr(in.WorkspaceId),
+		ProjectID:   intPtr(in.ProjectId),
+		AssigneeID:  intPtr(in.AssigneeId),
+		Priority:    priorityFromPB[in.GetPriority()],
+		Category:    categoryFromPB[in.GetCategory()],
+		Status:      in.GetStatus(),
+		IsCompleted: in.IsCompleted,
+		DueDate:     timePtr(in.GetDueDate()),
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, grpcStatus(&service.Error{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	task, err := s.tasks.Create(middleware.GRPCUserID(ctx), req)
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+// ListTasks - seperti GET /tasks, dikirim satu task per pesan
+func (s *GRPCTaskServer) ListTasks(in *taskflowv1.ListTasksRequest, stream taskflowv1.TaskService_ListTasksServer) error {
+	get, err := grpcTaskFilter(in.GetFilter())
+	if err != nil {
+		return grpcStatus(err)
+	}
+
+	tasks, err := s.tasks.List(middleware.GRPCUserID(stream.Context()), filterFromQuery(get))
+	if err != nil {
+		return grpcStatus(err)
+	}
+	for _, task := range tasks {
+		if err := stream.Send(pbTask(task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GRPCTaskServer) GetTask(ctx context.Context, in *taskflowv1.GetTaskRequest) (*taskflowv1.Task, error) {
+	task, err := s.tasks.Get(middleware.GRPCUserID(ctx), int(in.GetId()))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+// UpdateTask - update_mask kosong = PUT (semua field diganti), selain itu hanya
+// field di mask yang diubah seperti PATCH
+func (s *GRPCTaskServer) UpdateTask(ctx context.Context, in *taskflowv1.UpdateTaskRequest) (*taskflowv1.Task, error) {
+	input := in.GetTask()
+	if input == nil {
+		input = &taskflowv1.TaskInput{}
+	}
+
+	task, err := s.tasks.Update(middleware.GRPCUserID(ctx), int(in.GetId()), expectedVersion(in.ExpectedVersion), func(current models.Task) (models.TaskDocument, error) {
+		doc, paths := taskDocument(current), in.GetUpdateMask().GetPaths()
+		if len(paths) == 0 {
+			doc, paths = models.TaskDocument{}, taskInputFields
+		}
+		if err := applyTaskMask(&doc, input, paths); err != nil {
+			return doc, err
+		}
+		return doc, binding.Validator.ValidateStruct(doc)
+	})
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+func (s *GRPCTaskServer) DeleteTask(ctx context.Context, in *taskflowv1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	if _, err := s.tasks.Delete(middleware.GRPCUserID(ctx), int(in.GetId()), expectedVersion(in.ExpectedVersion)); err != nil {
+		return nil, grpcStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *GRPCTaskServer) ToggleComplete(ctx context.Context, in *taskflowv1.ToggleCompleteRequest) (*taskflowv1.Task, error) {
+	task, err := s.tasks.ToggleComplete(middleware.GRPCUserID(ctx), int(in.GetId()), expectedVersion(in.ExpectedVersion))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+func (s *GRPCTaskServer) MoveTask(ctx context.Context, in *taskflowv1.MoveTaskRequest) (*taskflowv1.Task, error) {
+	req := models.MoveTaskRequest{Status: in.GetStatus(), AfterID: intPtr(in.AfterId), BeforeID: intPtr(in.BeforeId)}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, grpcStatus(&service.Error{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	task, err := s.tasks.Move(middleware.GRPCUserID(ctx), int(in.GetId()), req)
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+func (s *GRPCTaskServer) AssignTask(ctx context.Context, in *taskflowv1.AssignTaskRequest) (*taskflowv1.Task, error) {
+	task, err := s.tasks.Assign(middleware.GRPCUserID(ctx), int(in.GetId()), intPtr(in.AssigneeId), expectedVersion(in.ExpectedVersion))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return pbTask(task), nil
+}
+
+func (s *GRPCTaskServer) GetStats(ctx context.Context, in *taskflowv1.GetStatsRequest) (*taskflowv1.TaskStats, error) {
+	get, err := grpcTaskFilter(in.GetFilter())
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+
+	stats, err := s.tasks.Stats(middleware.GRPCUserID(ctx), filterFromQuery(get))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+
+	out := &taskflowv1.TaskStats{
+		Total:        int64(stats.Total),
+		Completed:    int64(stats.Completed),
+		Pending:      int64(stats.Pending),
+		HighPriority: int64(stats.HighPriority),
+		Overdue:      int64(stats.Overdue),
+	}
+	for _, a := range stats.ByAssignee {
+		out.ByAssignee = append(out.ByAssignee, &taskflowv1.AssigneeStats{
+			AssigneeId: int64Ptr(a.AssigneeID),
+			Name:       a.Name,
+			Total:      int64(a.Total),
+			Completed:  int64(a.Completed),
+			Pending:    int64(a.Pending),
+			Overdue:    int64(a.Overdue),
+		})
+	}
+	return out, nil
+}
+
+// WatchTasks - padanan GET /events: replay setelah after_event_id, lalu event
+// baru sampai client berhenti
+func (s *GRPCTaskServer) WatchTasks(in *taskflowv1.WatchTasksRequest, stream taskflowv1.TaskService_WatchTasksServer) error {
+	workspace := in.GetWorkspaceId()
+	if workspace != "" && workspace != "personal" {
+		if _, err := strconv.Atoi(workspace); err != nil {
+			return grpcStatus(&service.Error{Status: http.StatusBadRequest, Message: "workspace_id must be a workspace ID or personal"})
+		}
+	}
+
+	ctx := stream.Context()
+	userID := middleware.GRPCUserID(ctx)
+
+	// Subscribe sebelum replay supaya tidak ada event yang jatuh di antaranya
+	client := s.hub.Subscribe(userID)
+	defer s.hub.Unsubscribe(client)
+
+	sent := in.GetAfterEventId()
+	if sent > 0 {
+		backlog, resumable, err := s.hub.Since(ctx, userID, sent)
+		if err != nil {
+			return grpcStatus(&service.Error{Status: http.StatusInternalServerError, Message: "Failed to fetch events"})
+		}
+		if !resumable {
+			return status.Error(codes.OutOfRange, "Events after this ID were pruned; reload the tasks and watch again")
+		}
+		for _, e := range backlog {
+			if err := sendTaskEvent(stream, e, workspace); err != nil {
+				return err
+			}
+			sent = e.ID
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-client.C:
+			if !ok {
+				// Hub memutus client yang terlalu lambat membaca
+				return status.Error(codes.Unavailable, "Stream closed; watch again with after_event_id")
+			}
+			if e.ID <= sent {
+				continue
+			}
+			if err := sendTaskEvent(stream, e, workspace); err != nil {
+				return err
+			}
+			sent = e.ID
+		}
+	}
+}
+
+func sendTaskEvent(stream taskflowv1.TaskService_WatchTasksServer, e realtime.Event, workspace string) error {
+	var payload realtime.Payload
+	if err := json.Unmarshal([]byte(e.Data), &payload); err != nil || !inWorkspace(payload.Task, workspace) {
+		return nil
+	}
+	eventType, ok := taskEventTypes[payload.Type]
+	if !ok {
+		return nil
+	}
+	return stream.Send(&taskflowv1.TaskEvent{
+		Id:         e.ID,
+		Type:       eventType,
+		ActorId:    int64(payload.ActorID),
+		Task:       pbTask(payload.Task),
+		OccurredAt: timestamppb.New(payload.OccurredAt),
+	})
+}
+
+// grpcStatus - taskError sebagai status gRPC. Detail ErrorInfo membawa kode yang
+// sama dengan GraphQL dan status HTTP route REST-nya (grpc-gateway memetakan
+// kode gRPC ke status HTTP, tapi 412 tidak punya padanan).
+func grpcStatus(err error) error {
+	var te *service.Error
+	if !errors.As(err, &te) {
+		log.Printf("grpc: %v", err)
+		te = &service.Error{Status: http.StatusInternalServerError, Message: "Internal server error"}
+	}
+
+	code := codes.Internal
+	switch te.Status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		code = codes.Aborted
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason:   te.Code(),
+		Domain:   "taskflow",
+		Metadata: map[string]string{"http_status": strconv.Itoa(te.Status)},
+	}
+	if te.Status == http.StatusPreconditionFailed {
+		info.Metadata["version"] = strconv.Itoa(te.Version)
+	}
+
+	st, detailErr := status.New(code, te.Message).WithDetails(info)
+	if detailErr != nil {
+		return status.Error(code, te.Message)
+	}
+	return st.Err()
+}
+
+// grpcTaskFilter - TaskFilter sebagai getter untuk filterFromQuery,
+// dengan key dan format nilai yang sama seperti query string GET /tasks
+func grpcTaskFilter(filter *taskflowv1.TaskFilter) (func(key string) string, error) {
+	if filter == nil {
+		filter = &taskflowv1.TaskFilter{}
+	}
+	values := map[string]string{
+		"workspace_id": filter.GetWorkspaceId(),
+		"assignee":     filter.GetAssignee(),
+		"created_by":   filter.GetCreatedBy(),
+		"priority":     string(priorityFromPB[filter.GetPriority()]),
+		"category":     string(categoryFromPB[filter.GetCategory()]),
+		"status":       filter.GetStatus(),
+		"search":       filter.GetSearch(),
+	}
+
+	keywords := map[string][]string{"workspace_id": {"personal"}, "assignee": {"me", "none"}, "created_by": {"me"}}
+	for key, allowed := range keywords {
+		v := values[key]
+		if _, err := strconv.Atoi(v); err != nil && v != "" && !slices.Contains(allowed, v) {
+			return nil, &service.Error{Status: http.StatusBadRequest, Message: "Invalid filter value for " + key}
+		}
+	}
+
+	if filter.ProjectId != nil {
+		values["project_id"] = strconv.FormatInt(filter.GetProjectId(), 10)
+	}
+	if filter.IsCompleted != nil {
+		values["is_completed"] = strconv.FormatBool(filter.GetIsCompleted())
+	}
+	if filter.GetIncludeArchived() {
+		values["include_archived"] = "true"
+	}
+
+	return func(key string) string { return values[key] }, nil
+}
+
+// applyTaskMask - salin field TaskInput yang ada di paths ke dokumen task;
+// field yang tidak di-set di input dikosongkan
+func applyTaskMask(doc *models.TaskDocument, input *taskflowv1.TaskInput, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "title":
+			doc.Title = input.GetTitle()
+		case "description":
+			doc.Description = input.Description
+		case "project_id":
+			doc.ProjectID = intPtr(input.ProjectId)
+		case "assignee_id":
+			doc.AssigneeID = intPtr(input.AssigneeId)
+		case "priority":
+			doc.Priority = priorityFromPB[input.GetPriority()]
+		case "category":
+			doc.Category = categoryFromPB[input.GetCategory()]
+		case "status":
+			doc.Status = input.GetStatus()
+		case "is_completed":
+			doc.IsCompleted = input.IsCompleted
+		case "due_date":
+			doc.DueDate = timePtr(input.GetDueDate())
+		default:
+			return fmt.Errorf("Unknown update_mask path %q", path)
+		}
+	}
+	return nil
+}
+
+// expectedVersion - expected_version sebagai syarat versi (nil = tanpa syarat, seperti tanpa If-Match)
+func expectedVersion(version *int64) []int64 {
+	if version == nil {
+		return nil
+	}
+	return []int64{*version}
+}
+
+// taskInputFields - semua path update_mask, dipakai saat mask kosong
+var taskInputFields = []string{"title", "description", "project_id", "assignee_id", "priority", "category", "status", "is_completed", "due_date"}
+
+var (
+	priorityFromPB = map[taskflowv1.Priority]models.Priority{
+		taskflowv1.Priority_PRIORITY_LOW:    models.PriorityLow,
+		taskflowv1.Priority_PRIORITY_MEDIUM: models.PriorityMedium,
+		taskflowv1.Priority_PRIORITY_HIGH:   models.PriorityHigh,
+	}
+	categoryFromPB = map[taskflowv1.Category]models.Category{
+		taskflowv1.Category_CATEGORY_PERSONAL: models.CategoryPersonal,
+		taskflowv1.Category_CATEGORY_WORK:     models.CategoryWork,
+		taskflowv1.Category_CATEGORY_URGENT:   models.CategoryUrgent,
+	}
+	taskEventTypes = map[events.Type]taskflowv1.TaskEventType{
+		events.TaskCreated: taskflowv1.TaskEventType_TASK_EVENT_TYPE_CREATED,
+		events.TaskUpdated: taskflowv1.TaskEventType_TASK_EVENT_TYPE_UPDATED,
+		events.TaskDeleted: taskflowv1.TaskEventType_TASK_EVENT_TYPE_DELETED,
+	}
+)
+
+func pbTask(task models.Task) *taskflowv1.Task {
+	out := &taskflowv1.Task{
+		Id:          int64(task.ID),
+		UserId:      int64(task.UserID),
+		WorkspaceId: int64Ptr(task.WorkspaceID),
+		ProjectId:   int64Ptr(task.ProjectID),
+		AssigneeId:  int64Ptr(task.AssigneeID),
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Position:    task.Position,
+		IsCompleted: task.IsCompleted,
+		Version:     int64(task.Version),
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+	}
+	for pb, p := range priorityFromPB {
+		if p == task.Priority {
+			out.Priority = pb
+		}
+	}
+	for pb, c := range categoryFromPB {
+		if c == task.Category {
+			out.Category = pb
+		}
+	}
+	if task.DueDate != nil {
+		out.DueDate = timestamppb.New(*task.DueDate)
+	}
+	return out
+}
+
+func pbUser(user models.User) *taskflowv1.User {
+	return &taskflowv1.User{
+		Id:        int64(user.ID),
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func intPtr(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func int64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	i := int64(*v)
+	return &i
+}
+
+// timePtr - Timestamp yang tidak di-set berarti null
+func timePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/importer"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize - ukuran maksimum file yang diunggah
+const maxImportSize = 10 << 20
+
+type ImportHandler struct {
+	imports repository.ImportRepository
+	authz   Authorizer
+	runner  *importer.Runner
+}
+
+func NewImportHandler(imports repository.ImportRepository, az Authorizer, runner *importer.Runner) *ImportHandler {
+	return &ImportHandler{imports: imports, authz: az, runner: runner}
+}
+
+// CreateImport - unggah file (multipart: file, format, workspace_id, mapping).
+// File diperiksa sekarang, task dibuat di background; pantau lewat GET /imports/:id.
+func (h *ImportHandler) CreateImport(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "file is required (multipart/form-data, at most 10 MB)")
+		return
+	}
+	if header.Size > maxImportSize {
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "file must be at most 10 MB")
+		return
+	}
+
+	var workspaceID *int
+	if raw := c.PostForm("workspace_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid workspace_id")
+			return
+		}
+		workspaceID = &id
+	}
+	if err := h.authz.Scope(userID, workspaceID, authz.ActionEdit); err != nil {
+		respondAuthzError(c, err, "Workspace not found")
+		return
+	}
+
+	format := c.PostForm("format")
+	var mapping map[string]string
+	var mappingColumn *string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if format != models.ImportCSV {
+			utils.ErrorResponse(c, http.StatusBadRequest, "mapping is only supported for csv")
+			return
+		}
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, `mapping must be a JSON object like {"title": "Task Name"}`)
+			return
+		}
+		mappingColumn = &raw
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+
+	// File yang tidak bisa dibaca sama sekali langsung ditolak
+	rows, err := importer.Parse(format, data, mapping)
+	if err != nil {
+		status := http.StatusBadRequest
+		if !errors.Is(err, importer.ErrUnknownFormat) {
+			status = http.StatusUnprocessableEntity
+		}
+		utils.ErrorResponse(c, status, err.Error())
+		return
+	}
+
+	h.startImport(c, userID, workspaceID, format, header.Filename, data, mappingColumn, len(rows))
+}
+
+// ImportCalendar - POST /calendar/import: file .ics sebagai body (text/calendar)
+// atau multipart "file". Setiap VTODO jadi task lewat job import biasa.
+func (h *ImportHandler) ImportCalendar(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var workspaceID *int
+	if raw := c.Query("workspace_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid workspace_id")
+			return
+		}
+		workspaceID = &id
+	}
+	if err := h.authz.Scope(userID, workspaceID, authz.ActionEdit); err != nil {
+		respondAuthzError(c, err, "Workspace not found")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+1<<20)
+	filename := "calendar.ics"
+	var data []byte
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "file is required")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file")
+			return
+		}
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read file")
+			return
+		}
+		filename = header.Filename
+	} else {
+		var err error
+		if data, err = io.ReadAll(c.Request.Body); err != nil {
+			utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "file must be at most 10 MB")
+			return
+		}
+	}
+	if len(data) > maxImportSize {
+		utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "file must be at most 10 MB")
+		return
+	}
+
+	rows, err := importer.Parse(models.ImportICS, data, nil)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	h.startImport(c, userID, workspaceID, models.ImportICS, filename, data, nil, len(rows))
+}
+
+// startImport - simpan job dan bangunkan runner; 202 dengan Location job
+func (h *ImportHandler) startImport(c *gin.Context, userID int, workspaceID *int, format, filename string, data []byte, mapping *string, total int) {
+	filename = filepath.Base(filename)
+	if len(filename) > 255 {
+		filename = filename[:255]
+	}
+
+	job, err := h.imports.Create(models.ImportJob{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Format:      format,
+		Filename:    filename,
+		TotalRows:   total,
+	}, data, mapping)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create import")
+		return
+	}
+	h.runner.Wake()
+
+	c.Header("Location", "/api/v1/imports/"+strconv.Itoa(job.ID))
+	utils.SuccessResponse(c, http.StatusAccepted, "Import started", job)
+}
+
+// GetImports - job import milik user, terbaru dulu
+func (h *ImportHandler) GetImports(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	jobs, err := h.imports.List(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch imports")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Imports retrieved successfully", jobs)
+}
+
+// GetImport - progress dan error per baris
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	jobID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Import not found")
+		return
+	}
+
+	job, err := h.imports.Get(userID, jobID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Import not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch import")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Import retrieved successfully", job)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Batas jumlah notifikasi per halaman
+const (
+	defaultNotificationLimit = 50
+	maxNotificationLimit     = 100
+)
+
+type NotificationHandler struct {
+	notifications repository.NotificationRepository
+}
+
+func NewNotificationHandler(notifications repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications}
+}
+
+// GetNotifications - terbaru dulu; ?unread=true, ?type=, ?limit=, ?offset=
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	filter := repository.NotificationFilter{
+		Unread: c.Query("unread") == "true",
+		Type:   c.Query("type"),
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultNotificationLimit)))
+	if err != nil || limit < 1 || limit > maxNotificationLimit {
+		limit = defaultNotificationLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	filter.Limit, filter.Offset = limit, offset
+
+	var list models.NotificationList
+	if list.Notifications, err = h.notifications.List(userID, filter); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch notifications")
+		return
+	}
+
+	if list.UnreadCount, err = h.notifications.UnreadCount(userID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count notifications")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifications retrieved successfully", list)
+}
+
+// GetUnreadCount - ringan, untuk badge yang di-poll
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	count, err := h.notifications.UnreadCount(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to count notifications")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Unread count retrieved successfully", gin.H{"unread_count": count})
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Notification not found")
+		return
+	}
+
+	n, err := h.notifications.MarkRead(userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Notification not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update notification")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification marked as read", n)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	updated, err := h.notifications.MarkAllRead(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update notifications")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "All notifications marked as read", gin.H{"updated": updated})
+}
+
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Notification not found")
+		return
+	}
+
+	err = h.notifications.Delete(userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Notification not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete notification")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notification deleted successfully", nil)
+}
+
+// GetPreferences - satu entry per jenis notifikasi, default semua channel aktif
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	prefs, err := h.notifications.Preferences(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch preferences")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Preferences retrieved successfully", prefs)
+}
+
+// UpdatePreferences - field yang tidak dikirim tetap seperti sebelumnya
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.notifications.UpdatePreferences(userID, req.Preferences); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update preferences")
+		return
+	}
+
+	prefs, err := h.notifications.Preferences(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch preferences")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Preferences updated successfully", prefs)
+}
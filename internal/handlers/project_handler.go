@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	projects repository.ProjectRepository
+	authz    Authorizer
+}
+
+func NewProjectHandler(projects repository.ProjectRepository, az Authorizer) *ProjectHandler {
+	return &ProjectHandler{projects: projects, authz: az}
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Project workspace hanya bisa dibuat oleh owner/admin
+	if err := h.authz.Scope(userID, req.WorkspaceID, authz.ActionManage); err != nil {
+		respondAuthzError(c, err, "Workspace not found")
+		return
+	}
+
+	// Set defaults
+	if req.Color == "" {
+		req.Color = "#6366f1"
+	}
+
+	b := repository.Board{UserID: userID, WorkspaceID: req.WorkspaceID}
+	project, err := h.projects.Create(b, req.Name, req.Description, req.Color)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create project")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Project created successfully", project)
+}
+
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	projects, err := h.projects.List(c.GetInt("user_id"), repository.ProjectFilter{
+		WorkspaceID:     c.Query("workspace_id"),
+		IncludeArchived: c.Query("include_archived") == "true",
+	})
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Projects retrieved successfully", projects)
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeProject(c, userID, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	project, err := h.projects.Get(res.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Project retrieved successfully", project)
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, ok := h.authorizeProject(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	project, err := h.projects.Update(res.ID, req)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update project")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Project updated successfully", project)
+}
+
+// DeleteProject - task di dalamnya tidak dihapus, project_id-nya jadi NULL
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeProject(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	err := h.projects.Delete(res.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Project not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete project")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Project deleted successfully", nil)
+}
+
+// GetProjectTasks - task dalam satu project, mendukung filter yang sama dengan GetTasks
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeProject(c, userID, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.projects.Tasks(res.ID, userID, filterFromQuery(c.Query))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tasks")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tasks retrieved successfully", tasks)
+}
+
+func (h *ProjectHandler) GetProjectStats(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeProject(c, userID, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	stats, err := h.projects.Stats(res.ID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch statistics")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Statistics retrieved successfully", stats)
+}
+
+// authorizeProject - cek izin user atas project di path :id, tulis error kalau ditolak
+func (h *ProjectHandler) authorizeProject(c *gin.Context, userID int, action authz.Action) (authz.Resource, bool) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Project not found")
+		return authz.Resource{}, false
+	}
+
+	res, err := h.authz.Project(userID, projectID, action)
+	if err != nil {
+		respondAuthzError(c, err, "Project not found")
+		return res, false
+	}
+	return res, true
+}
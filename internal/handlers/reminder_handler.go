@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	reminders repository.ReminderRepository
+	authz     Authorizer
+}
+
+func NewReminderHandler(reminders repository.ReminderRepository, az Authorizer) *ReminderHandler {
+	return &ReminderHandler{reminders: reminders, authz: az}
+}
+
+// CreateReminder - reminder milik user yang membuat; cukup punya akses lihat ke task
+func (h *ReminderHandler) CreateReminder(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.CreateReminderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if (req.RemindAt == nil) == (req.OffsetMinutes == nil) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Set either remind_at or offset_minutes")
+		return
+	}
+	if req.RemindAt != nil && !req.RemindAt.After(time.Now()) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "remind_at must be in the future")
+		return
+	}
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	if req.OffsetMinutes != nil {
+		dueDate, err := h.reminders.DueDate(res.ID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch task")
+			return
+		}
+		if dueDate == nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Task has no due date")
+			return
+		}
+	}
+
+	reminder, err := h.reminders.Create(res.ID, userID, req.RemindAt, req.OffsetMinutes, req.Channels)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create reminder")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reminder created successfully", reminder)
+}
+
+// GetTaskReminders - reminder user sendiri untuk satu task, termasuk yang sudah terkirim
+func (h *ReminderHandler) GetTaskReminders(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	reminders, err := h.reminders.ForTask(res.ID, userID)
+	respondReminders(c, reminders, err)
+}
+
+// GetReminders - semua reminder user yang belum terkirim, yang paling dekat dulu
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	reminders, err := h.reminders.Pending(userID)
+	respondReminders(c, reminders, err)
+}
+
+func (h *ReminderHandler) DeleteReminder(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	reminderID, err := strconv.Atoi(c.Param("reminderId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Reminder not found")
+		return
+	}
+
+	err = h.reminders.Delete(res.ID, userID, reminderID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Reminder not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete reminder")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reminder deleted successfully", nil)
+}
+
+func respondReminders(c *gin.Context, reminders []models.TaskReminder, err error) {
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch reminders")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reminders retrieved successfully", reminders)
+}
+
+func (h *ReminderHandler) authorizeTask(c *gin.Context, userID int) (authz.Resource, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
+		return authz.Resource{}, false
+	}
+
+	res, err := h.authz.Task(userID, taskID, authz.ActionView)
+	if err != nil {
+		respondAuthzError(c, err, "Task not found")
+		return res, false
+	}
+	return res, true
+}
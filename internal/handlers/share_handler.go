@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Halaman sederhana untuk membuka share link di browser
+var publicTaskPage = template.Must(template.New("task").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}} · TaskFlow</title>
+<style>
+body{font-family:system-ui,sans-serif;background:#f5f7fb;margin:0;padding:2rem;color:#1f2937}
+.card{max-width:640px;margin:0 auto;background:#fff;border-radius:12px;padding:2rem;box-shadow:0 4px 20px rgba(0,0,0,.06)}
+.badges span{display:inline-block;font-size:.75rem;padding:.2rem .6rem;border-radius:999px;background:#eef2ff;margin-right:.4rem}
+.done h1{text-decoration:line-through;color:#6b7280}
+p.meta{color:#6b7280;font-size:.875rem}
+</style>
+</head>
+<body>
+<div class="card{{if .IsCompleted}} done{{end}}">
+<div class="badges"><span>{{.Priority}}</span><span>{{.Category}}</span><span>{{.Status}}</span></div>
+<h1>{{.Title}}</h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+<p class="meta">{{if .DueDate}}Due {{.DueDate.Format "Mon, 02 Jan 2006 15:04"}} · {{end}}Updated {{.UpdatedAt.Format "Mon, 02 Jan 2006 15:04"}}</p>
+</div>
+</body>
+</html>`))
+
+type ShareHandler struct {
+	shares repository.ShareRepository
+	authz  Authorizer
+	tasks  *service.TaskService
+}
+
+func NewShareHandler(shares repository.ShareRepository, az Authorizer, tasks *service.TaskService) *ShareHandler {
+	return &ShareHandler{shares: shares, authz: az, tasks: tasks}
+}
+
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.CreateShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "expires_at must be in the future")
+		return
+	}
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	// Default read-only
+	readOnly := true
+	if req.ReadOnly != nil {
+		readOnly = *req.ReadOnly
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	share, err := h.shares.Create(res.ID, userID, utils.HashToken(token), readOnly, req.ExpiresAt)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create share link")
+		return
+	}
+	share.Token = token
+	share.URL = "/api/v1/public/tasks/" + token
+
+	utils.SuccessResponse(c, http.StatusCreated, "Share link created successfully", share)
+}
+
+// GetShares - share link yang masih aktif (belum dicabut dan belum expired)
+func (h *ShareHandler) GetShares(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	shares, err := h.shares.Active(res.ID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch share links")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Share links retrieved successfully", shares)
+}
+
+func (h *ShareHandler) RevokeShare(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeTask(c, userID)
+	if !ok {
+		return
+	}
+
+	shareID, err := strconv.Atoi(c.Param("shareId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Share link not found")
+		return
+	}
+
+	err = h.shares.Revoke(res.ID, shareID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Share link not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke share link")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Share link revoked successfully", nil)
+}
+
+// GetPublicTask - endpoint publik tanpa login. Browser (Accept: text/html)
+// mendapat halaman HTML, client lain mendapat JSON.
+func (h *ShareHandler) GetPublicTask(c *gin.Context) {
+	share, ok := h.activeShare(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.shares.PublicTask(share)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch task")
+		return
+	}
+
+	h.shares.Touch(share.ID)
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		publicTaskPage.Execute(c.Writer, task)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Task retrieved successfully", task)
+}
+
+// UpdatePublicTask - hanya untuk share link yang tidak read-only;
+// yang boleh diubah hanya deskripsi dan status selesai
+func (h *ShareHandler) UpdatePublicTask(c *gin.Context) {
+	share, ok := h.activeShare(c)
+	if !ok {
+		return
+	}
+	if share.ReadOnly {
+		utils.ErrorResponse(c, http.StatusForbidden, "This share link is read-only")
+		return
+	}
+
+	var req models.PublicTaskUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := h.tasks.UpdateShared(share.TaskID, req); err != nil {
+		respondTaskError(c, err)
+		return
+	}
+
+	task, err := h.shares.PublicTask(share)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch task")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Task updated successfully", task)
+}
+
+// activeShare - cari share dari token di path, tulis 404 kalau tidak aktif
+func (h *ShareHandler) activeShare(c *gin.Context) (models.TaskShare, bool) {
+	share, err := h.shares.ByToken(utils.HashToken(c.Param("token")))
+
+	// Token salah, dicabut, atau expired diperlakukan sama
+	if err != nil || share.RevokedAt != nil || (share.ExpiresAt != nil && time.Now().After(*share.ExpiresAt)) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Share link not found or expired")
+		return share, false
+	}
+	return share, true
+}
+
+// authorizeTask - mengelola share link butuh izin edit atas task
+func (h *ShareHandler) authorizeTask(c *gin.Context, userID int) (authz.Resource, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
+		return authz.Resource{}, false
+	}
+
+	res, err := h.authz.Task(userID, taskID, authz.ActionEdit)
+	if err != nil {
+		respondAuthzError(c, err, "Task not found")
+		return res, false
+	}
+	return res, true
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var statusKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type StatusHandler struct {
+	statuses repository.StatusRepository
+	authz    Authorizer
+}
+
+func NewStatusHandler(statuses repository.StatusRepository, az Authorizer) *StatusHandler {
+	return &StatusHandler{statuses: statuses, authz: az}
+}
+
+func (h *StatusHandler) GetStatuses(c *gin.Context) {
+	b, ok := h.requestBoard(c, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	statuses, err := h.statuses.List(b)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch statuses")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Statuses retrieved successfully", statuses)
+}
+
+func (h *StatusHandler) CreateStatus(c *gin.Context) {
+	b, ok := h.requestBoard(c, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	var req models.CreateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !statusKeyPattern.MatchString(req.Key) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Status key may only contain lowercase letters, digits and underscores")
+		return
+	}
+
+	var status models.TaskStatus
+	err := h.statuses.Transact(func(tx repository.StatusRepository) error {
+		// Check if key already exists
+		statuses, _, err := editableStatuses(tx, b, "")
+		if err != nil {
+			return httpError(http.StatusInternalServerError, "Database error")
+		}
+		if _, exists := models.FindStatus(statuses, req.Key); exists {
+			return httpError(http.StatusConflict, "Status key already exists")
+		}
+
+		// Default: taruh kolom baru di paling kanan
+		position := 0
+		if req.Position != nil {
+			position = *req.Position
+		} else if len(statuses) > 0 {
+			position = statuses[len(statuses)-1].Position + 1
+		}
+
+		status, err = tx.Create(b, models.TaskStatus{
+			Key:        req.Key,
+			Name:       req.Name,
+			Position:   position,
+			IsTerminal: req.IsTerminal,
+		})
+		return err
+	})
+	if err != nil {
+		respondTxError(c, err, "Failed to create status")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Status created successfully", status)
+}
+
+func (h *StatusHandler) UpdateStatus(c *gin.Context) {
+	b, ok := h.requestBoard(c, authz.ActionManage)
+	if !ok {
+		return
+	}
+	statusID := c.Param("id")
+
+	var req models.UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var status models.TaskStatus
+	err := h.statuses.Transact(func(tx repository.StatusRepository) error {
+		statuses, current, err := editableStatuses(tx, b, statusID)
+		if err != nil {
+			return httpError(http.StatusInternalServerError, "Failed to fetch statuses")
+		}
+		if current == nil {
+			return httpError(http.StatusNotFound, "Status not found")
+		}
+
+		// Board harus tetap punya minimal satu kolom open dan satu kolom terminal
+		if req.IsTerminal != nil && *req.IsTerminal != current.IsTerminal {
+			current.IsTerminal = *req.IsTerminal
+			if !models.HasOpenAndTerminal(statuses) {
+				return httpError(http.StatusConflict, "At least one open and one terminal status are required")
+			}
+		}
+		if req.Name != nil {
+			current.Name = *req.Name
+		}
+		if req.Position != nil {
+			current.Position = *req.Position
+		}
+
+		status, err = tx.Update(b, *current)
+		return err
+	})
+	if err != nil {
+		respondTxError(c, err, "Failed to update status")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Status updated successfully", status)
+}
+
+func (h *StatusHandler) DeleteStatus(c *gin.Context) {
+	b, ok := h.requestBoard(c, authz.ActionManage)
+	if !ok {
+		return
+	}
+	statusID := c.Param("id")
+	moveTo := c.Query("move_to")
+
+	err := h.statuses.Transact(func(tx repository.StatusRepository) error {
+		statuses, current, err := editableStatuses(tx, b, statusID)
+		if err != nil {
+			return httpError(http.StatusInternalServerError, "Failed to fetch statuses")
+		}
+		if current == nil {
+			return httpError(http.StatusNotFound, "Status not found")
+		}
+		remaining := []models.TaskStatus{}
+		for _, s := range statuses {
+			if s.ID != current.ID {
+				remaining = append(remaining, s)
+			}
+		}
+		if !models.HasOpenAndTerminal(remaining) {
+			return httpError(http.StatusConflict, "At least one open and one terminal status are required")
+		}
+
+		// Task yang masih memakai status ini harus dipindah dulu
+		taskIDs, err := tx.ColumnTasks(b, current.Key)
+		if err != nil {
+			return httpError(http.StatusInternalServerError, "Database error")
+		}
+		if len(taskIDs) > 0 {
+			target, ok := models.FindStatus(remaining, moveTo)
+			if !ok {
+				return httpError(http.StatusConflict, "Status is in use; pass move_to with another status key")
+			}
+			if err := tx.MoveTasks(b, taskIDs, target); err != nil {
+				return httpError(http.StatusInternalServerError, "Failed to move tasks")
+			}
+		}
+
+		return tx.Delete(current.ID)
+	})
+	if err != nil {
+		respondTxError(c, err, "Failed to delete status")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Status deleted successfully", nil)
+}
+
+// editableStatuses - kolom board b yang akan diubah, beserta kolom dengan ID id
+// (nil kalau tidak ada). Project yang masih memakai kolom board mendapat
+// salinannya dulu supaya perubahannya tidak mengenai board; id kolom board
+// dicari lewat key-nya di salinan itu.
+func editableStatuses(tx repository.StatusRepository, b repository.Board, id string) ([]models.TaskStatus, *models.TaskStatus, error) {
+	statuses, err := tx.List(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := findStatusByID(statuses, id)
+	if b.ProjectID == nil || (len(statuses) > 0 && statuses[0].ProjectID != nil) {
+		return statuses, current, nil
+	}
+
+	if statuses, err = tx.Customize(b); err != nil {
+		return nil, nil, err
+	}
+	if current != nil {
+		key := current.Key
+		current = nil
+		for i := range statuses {
+			if statuses[i].Key == key {
+				current = &statuses[i]
+			}
+		}
+	}
+	return statuses, current, nil
+}
+
+// findStatusByID - kolom dengan ID dari path, nil kalau tidak ada di board
+func findStatusByID(statuses []models.TaskStatus, id string) *models.TaskStatus {
+	for i := range statuses {
+		if strconv.Itoa(statuses[i].ID) == id {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// requestBoard - board dari query ?project_id= atau ?workspace_id=, default board personal user
+func (h *StatusHandler) requestBoard(c *gin.Context, action authz.Action) (repository.Board, bool) {
+	return boardFromQuery(c, h.authz, action)
+}
+
+// boardFromQuery - ?project_id= memilih board project itu (di board
+// user/workspace tempat project berada), ?workspace_id= board workspace
+func boardFromQuery(c *gin.Context, az Authorizer, action authz.Action) (repository.Board, bool) {
+	userID := c.GetInt("user_id")
+	b := repository.Board{UserID: userID}
+
+	if raw := c.Query("project_id"); raw != "" {
+		projectID, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid project_id")
+			return b, false
+		}
+		res, err := az.Project(userID, projectID, action)
+		if err != nil {
+			respondAuthzError(c, err, "Project not found")
+			return b, false
+		}
+		b = repository.BoardOf(res)
+		b.ProjectID = &res.ID
+		return b, true
+	}
+
+	if raw := c.Query("workspace_id"); raw != "" {
+		workspaceID, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid workspace_id")
+			return b, false
+		}
+		if err := az.Scope(userID, &workspaceID, action); err != nil {
+			respondAuthzError(c, err, "Workspace not found")
+			return b, false
+		}
+		b.WorkspaceID = &workspaceID
+	}
+	return b, true
+}
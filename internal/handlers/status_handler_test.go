@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusServer - route status dan board di atas database SQLite; repository
+// memori tidak punya StatusRepository
+type statusServer struct {
+	router   *gin.Engine
+	users    repository.UserRepository
+	projects repository.ProjectRepository
+	tasks    *service.TaskService
+}
+
+func newStatusServer(t *testing.T) *statusServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := dbtest.Open(t)
+	az := authz.New(db)
+	users := repository.NewUserRepository(db)
+	tasks := service.NewTaskService(repository.NewTaskRepository(db), users, az, events.NewBus())
+	sh := NewStatusHandler(repository.NewStatusRepository(db), az)
+	th := NewTaskHandler(tasks, az)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.GetHeader("X-User"))
+		c.Set("user_id", id)
+	})
+	router.GET("/statuses", sh.GetStatuses)
+	router.POST("/statuses", sh.CreateStatus)
+	router.PUT("/statuses/:id", sh.UpdateStatus)
+	router.DELETE("/statuses/:id", sh.DeleteStatus)
+	router.GET("/tasks/board", th.GetBoard)
+	return &statusServer{router: router, users: users, projects: repository.NewProjectRepository(db), tasks: tasks}
+}
+
+func (s *statusServer) user(t *testing.T, name string) int {
+	t.Helper()
+	u, err := s.users.Create(name, name+"@example.com", "hash")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return u.ID
+}
+
+func (s *statusServer) do(userID int, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-User", strconv.Itoa(userID))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeData - field data dari response sukses ke v
+func decodeData(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	resp := struct {
+		Data interface{} `json:"data"`
+	}{Data: v}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", w.Body.String(), err)
+	}
+}
+
+// statusKeys - "key=name" per kolom, urut
+func statusKeys(statuses []models.TaskStatus) []string {
+	var out []string
+	for _, st := range statuses {
+		out = append(out, st.Key+"="+st.Name)
+	}
+	return out
+}
+
+func TestProjectStatuses(t *testing.T) {
+	s := newStatusServer(t)
+	alice, bob := s.user(t, "alice"), s.user(t, "bob")
+
+	project, err := s.projects.Create(repository.Board{UserID: alice}, "Launch", "", "#6366f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectQuery := "?project_id=" + strconv.Itoa(project.ID)
+
+	// Sebelum diubah, project memakai kolom board
+	var board []models.TaskStatus
+	decodeData(t, s.do(alice, "GET", "/statuses", ""), &board)
+	var inherited []models.TaskStatus
+	decodeData(t, s.do(alice, "GET", "/statuses"+projectQuery, ""), &inherited)
+	if len(inherited) != 4 || inherited[0].ID != board[0].ID || inherited[0].ProjectID != nil {
+		t.Fatalf("project statuses before customizing = %+v, want the board's", inherited)
+	}
+
+	// Mengubah kolom board lewat project_id hanya mengubah salinan project
+	w := s.do(alice, "PUT", "/statuses/"+strconv.Itoa(board[0].ID)+projectQuery, `{"name":"Backlog"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("rename through project = %d %s", w.Code, w.Body)
+	}
+	var renamed models.TaskStatus
+	decodeData(t, w, &renamed)
+	if renamed.ID == board[0].ID || renamed.ProjectID == nil || *renamed.ProjectID != project.ID || renamed.Name != "Backlog" {
+		t.Errorf("renamed = %+v, want a project copy named Backlog", renamed)
+	}
+	decodeData(t, s.do(alice, "GET", "/statuses", ""), &board)
+	if board[0].Name != "To Do" {
+		t.Errorf("board status renamed to %q", board[0].Name)
+	}
+
+	if w := s.do(alice, "POST", "/statuses"+projectQuery, `{"key":"qa","name":"QA"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create project status = %d %s", w.Code, w.Body)
+	}
+	var statuses []models.TaskStatus
+	decodeData(t, s.do(alice, "GET", "/statuses"+projectQuery, ""), &statuses)
+	want := []string{"todo=Backlog", "in_progress=In Progress", "review=Review", "done=Done", "qa=QA"}
+	if got := statusKeys(statuses); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("project statuses = %v, want %v", got, want)
+	}
+
+	// Task di project memakai kolom project, task lain kolom board
+	task, err := s.tasks.Create(alice, models.CreateTaskRequest{Title: "Check", ProjectID: &project.ID, Status: "qa"})
+	if err != nil {
+		t.Fatalf("create in project: %v", err)
+	}
+	if _, err := s.tasks.Create(alice, models.CreateTaskRequest{Title: "Loose", Status: "qa"}); err == nil {
+		t.Error("board task accepted the project-only status")
+	}
+	s.tasks.Create(alice, models.CreateTaskRequest{Title: "Loose"})
+
+	var columns []models.BoardColumn
+	decodeData(t, s.do(alice, "GET", "/tasks/board"+projectQuery, ""), &columns)
+	if len(columns) != 5 || columns[4].Status.Key != "qa" || len(columns[4].Tasks) != 1 || columns[4].Tasks[0].ID != task.ID ||
+		len(columns[0].Tasks) != 0 {
+		t.Errorf("project board = %+v", columns)
+	}
+	decodeData(t, s.do(alice, "GET", "/tasks/board", ""), &columns)
+	if len(columns) != 4 || len(columns[0].Tasks) != 1 || columns[0].Tasks[0].Title != "Loose" {
+		t.Errorf("personal board = %+v, want only the task outside the project", columns)
+	}
+
+	// Status project yang masih dipakai harus dipindah dulu
+	qa := statuses[4]
+	if w := s.do(alice, "DELETE", "/statuses/"+strconv.Itoa(qa.ID)+projectQuery, ""); w.Code != http.StatusConflict {
+		t.Errorf("delete used status without move_to = %d, want 409", w.Code)
+	}
+	if w := s.do(alice, "DELETE", "/statuses/"+strconv.Itoa(qa.ID)+projectQuery+"&move_to=done", ""); w.Code != http.StatusOK {
+		t.Fatalf("delete with move_to = %d %s", w.Code, w.Body)
+	}
+	moved, err := s.tasks.Get(alice, task.ID)
+	if err != nil || moved.Status != models.StatusDone || !moved.IsCompleted {
+		t.Errorf("task after delete = %+v, %v; want done", moved, err)
+	}
+
+	if w := s.do(bob, "GET", "/statuses"+projectQuery, ""); w.Code != http.StatusNotFound {
+		t.Errorf("other user's project statuses = %d, want 404", w.Code)
+	}
+	if w := s.do(alice, "GET", "/statuses?project_id=abc", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid project_id = %d, want 400", w.Code)
+	}
+}
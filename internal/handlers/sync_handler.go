@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"taskflow-api/internal/jsonpatch"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSyncLimit = 500
+	maxSyncLimit     = 1000
+)
+
+type SyncHandler struct {
+	sync  repository.SyncRepository
+	tasks *service.TaskService
+}
+
+func NewSyncHandler(sync repository.SyncRepository, tasks *service.TaskService) *SyncHandler {
+	return &SyncHandler{sync: sync, tasks: tasks}
+}
+
+// Pull - GET /sync?since=<token>: task yang dibuat/diubah dan tombstone task yang
+// dihapus sejak token. Tanpa since = semua task yang terlihat (sync awal).
+func (h *SyncHandler) Pull(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	since, err := parseSyncToken(c.Query("since"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid sync token")
+		return
+	}
+	limit := defaultSyncLimit
+	if raw := c.Query("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxSyncLimit {
+			utils.ErrorResponse(c, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+	}
+
+	changes, err := h.sync.Changes(c.Request.Context(), userID, since, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch changes")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Changes retrieved successfully", changes)
+}
+
+// Push - POST /sync: terapkan mutasi offline berurutan. Setiap mutasi berdiri
+// sendiri; yang gagal tidak membatalkan yang lain. Kirim Idempotency-Key supaya
+// retry setelah koneksi putus tidak membuat task dobel.
+func (h *SyncHandler) Push(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.SyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results := make([]models.SyncResult, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		results = append(results, h.apply(userID, m))
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Mutations processed", results)
+}
+
+// apply - jalankan satu mutasi lewat jalur yang sama dengan POST/PATCH/DELETE /tasks
+func (h *SyncHandler) apply(userID int, m models.SyncMutation) models.SyncResult {
+	result := models.SyncResult{ClientID: m.ClientID}
+
+	var versions []int64
+	if m.BaseVersion != nil {
+		versions = []int64{*m.BaseVersion}
+	}
+	if m.Op != "create" && m.TaskID == nil {
+		result.Status, result.Error = models.SyncRejected, "task_id is required"
+		return result
+	}
+	if m.Op != "delete" && len(m.Task) == 0 {
+		result.Status, result.Error = models.SyncRejected, "task is required"
+		return result
+	}
+
+	var task models.Task
+	var err error
+	switch m.Op {
+	case "create":
+		var req models.CreateTaskRequest
+		if err := decodeStrict(m.Task, &req); err != nil {
+			result.Status, result.Error = models.SyncRejected, err.Error()
+			return result
+		}
+		task, err = h.tasks.Create(userID, req)
+	case "update":
+		task, err = h.tasks.Update(userID, *m.TaskID, versions, patchDocument(jsonpatch.Merge, m.Task))
+	case "delete":
+		_, err = h.tasks.Delete(userID, *m.TaskID, versions)
+	}
+
+	if err == nil {
+		result.Status = models.SyncApplied
+		if m.Op != "delete" {
+			result.Task = &task
+		}
+		return result
+	}
+	return h.failure(userID, m, result, err)
+}
+
+// failure - petakan error operasi task ke hasil sync. Versi yang berubah dan task
+// yang sudah dihapus orang lain adalah conflict; menghapus task yang sudah
+// terhapus dianggap berhasil.
+func (h *SyncHandler) failure(userID int, m models.SyncMutation, result models.SyncResult, err error) models.SyncResult {
+	var te *service.Error
+	if !errors.As(err, &te) {
+		result.Status, result.Error = models.SyncFailed, "Internal server error"
+		return result
+	}
+	result.Error = te.Message
+
+	switch {
+	case te.Status >= http.StatusInternalServerError:
+		result.Status = models.SyncFailed
+
+	case te.Status == http.StatusPreconditionFailed || te.Status == http.StatusConflict:
+		result.Status = models.SyncConflict
+		current, err := h.sync.Current(userID, *m.TaskID)
+		if err != nil {
+			result.Status, result.Error = models.SyncFailed, "Failed to fetch task"
+		} else {
+			result.Task = current
+		}
+
+	case te.Status == http.StatusNotFound && m.Op != "create":
+		deleted, err := h.sync.WasDeleted(userID, *m.TaskID)
+		switch {
+		case err != nil:
+			result.Status, result.Error = models.SyncFailed, "Failed to fetch task"
+		case deleted && m.Op == "delete":
+			result.Status, result.Error = models.SyncApplied, ""
+		case deleted:
+			result.Status, result.Error = models.SyncConflict, "Task was deleted"
+		default:
+			result.Status = models.SyncRejected
+		}
+
+	default:
+		result.Status = models.SyncRejected
+	}
+	return result
+}
+
+// parseSyncToken - token kosong = sync awal
+func parseSyncToken(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	token, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || token < 0 {
+		return 0, errors.New("invalid sync token")
+	}
+	return token, nil
+}
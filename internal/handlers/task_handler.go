@@ -1,23 +1,37 @@
 package handlers
 
 import (
-	"database/sql"
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
-	"time"
 
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/jsonpatch"
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/service"
 	"taskflow-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Media type PATCH /tasks/:id
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+	maxPatchSize   = 1 << 20
 )
 
 type TaskHandler struct {
-	db *sql.DB
+	tasks *service.TaskService
+	authz Authorizer
 }
 
-func NewTaskHandler(db *sql.DB) *TaskHandler {
-	return &TaskHandler{db: db}
+func NewTaskHandler(tasks *service.TaskService, az Authorizer) *TaskHandler {
+	return &TaskHandler{tasks: tasks, authz: az}
 }
 
 func (h *TaskHandler) CreateTask(c *gin.Context) {
@@ -29,203 +43,184 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	// Set defaults
-	if req.Priority == "" {
-		req.Priority = models.PriorityMedium
-	}
-	if req.Category == "" {
-		req.Category = models.CategoryPersonal
-	}
-
-	var task models.Task
-	err := h.db.QueryRow(
-		`INSERT INTO tasks (user_id, title, description, priority, category, due_date) 
-		 VALUES ($1, $2, $3, $4, $5, $6) 
-		 RETURNING id, user_id, title, description, priority, category, is_completed, due_date, created_at, updated_at`,
-		userID, req.Title, req.Description, req.Priority, req.Category, req.DueDate,
-	).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Priority,
-		&task.Category, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt)
-
+	task, err := h.tasks.Create(userID, req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create task")
+		respondTaskError(c, err)
 		return
 	}
 
+	c.Header("ETag", taskETag(task))
 	utils.SuccessResponse(c, http.StatusCreated, "Task created successfully", task)
 }
 
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	userID := c.GetInt("user_id")
-
-	// Build query with filters
-	query := `SELECT id, user_id, title, description, priority, category, is_completed, due_date, created_at, updated_at 
-			  FROM tasks WHERE user_id = $1`
-	args := []interface{}{userID}
-	argCount := 1
-
-	// Filter by priority
-	if priority := c.Query("priority"); priority != "" {
-		argCount++
-		query += " AND priority = $" + strconv.Itoa(argCount)
-		args = append(args, priority)
+	tasks, err := h.tasks.List(c.GetInt("user_id"), filterFromQuery(c.Query))
+	if err != nil {
+		respondTaskError(c, err)
+		return
 	}
-
-	// Filter by category
-	if category := c.Query("category"); category != "" {
-		argCount++
-		query += " AND category = $" + strconv.Itoa(argCount)
-		args = append(args, category)
+	if notModified(c, tasksETag(tasks)) {
+		return
 	}
 
-	// Filter by completion status
-	if isCompleted := c.Query("is_completed"); isCompleted != "" {
-		argCount++
-		query += " AND is_completed = $" + strconv.Itoa(argCount)
-		args = append(args, isCompleted == "true")
-	}
+	utils.SuccessResponse(c, http.StatusOK, "Tasks retrieved successfully", tasks)
+}
 
-	// Search in title and description
-	if search := c.Query("search"); search != "" {
-		argCount++
-		query += " AND (title ILIKE $" + strconv.Itoa(argCount) + " OR description ILIKE $" + strconv.Itoa(argCount) + ")"
-		args = append(args, "%"+search+"%")
+// GetBoard - task dikelompokkan per status, urut sesuai position.
+// Default board personal, board workspace dengan ?workspace_id=, atau board
+// project dengan ?project_id=
+func (h *TaskHandler) GetBoard(c *gin.Context) {
+	b, ok := boardFromQuery(c, h.authz, authz.ActionView)
+	if !ok {
+		return
 	}
 
-	query += " ORDER BY created_at DESC"
+	// workspace_id dan project_id sudah menentukan board
+	filter := filterFromQuery(c.Query)
+	filter.WorkspaceID = ""
+	filter.ProjectID = ""
 
-	rows, err := h.db.Query(query, args...)
+	columns, err := h.tasks.Board(b, filter)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tasks")
+		respondTaskError(c, err)
 		return
 	}
-	defer rows.Close()
-
-	tasks := []models.Task{}
-	for rows.Next() {
-		var task models.Task
-		err := rows.Scan(&task.ID, &task.UserID, &task.Title, &task.Description,
-			&task.Priority, &task.Category, &task.IsCompleted, &task.DueDate,
-			&task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
-			continue
-		}
-		tasks = append(tasks, task)
-	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Tasks retrieved successfully", tasks)
+	utils.SuccessResponse(c, http.StatusOK, "Board retrieved successfully", columns)
 }
 
 func (h *TaskHandler) GetTask(c *gin.Context) {
-	userID := c.GetInt("user_id")
-	taskID := c.Param("id")
-
-	var task models.Task
-	err := h.db.QueryRow(
-		`SELECT id, user_id, title, description, priority, category, is_completed, due_date, created_at, updated_at 
-		 FROM tasks WHERE id = $1 AND user_id = $2`,
-		taskID, userID,
-	).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Priority,
-		&task.Category, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt)
-
-	if err == sql.ErrNoRows {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
 		return
 	}
+
+	task, err := h.tasks.Get(c.GetInt("user_id"), taskID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch task")
+		respondTaskError(c, err)
+		return
+	}
+	if notModified(c, taskETag(task)) {
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Task retrieved successfully", task)
 }
 
-func (h *TaskHandler) UpdateTask(c *gin.Context) {
-	userID := c.GetInt("user_id")
-	taskID := c.Param("id")
-
-	var req models.UpdateTaskRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+// ReplaceTask - PUT: ganti semua field yang bisa diedit. Field yang tidak dikirim
+// kembali ke default seperti saat membuat task (null, medium, personal, kolom open pertama).
+func (h *TaskHandler) ReplaceTask(c *gin.Context) {
+	var doc models.TaskDocument
+	if err := c.ShouldBindJSON(&doc); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Build dynamic update query
-	query := "UPDATE tasks SET updated_at = CURRENT_TIMESTAMP"
-	args := []interface{}{}
-	argCount := 0
+	h.saveTask(c, func(models.Task) (models.TaskDocument, error) {
+		return doc, nil
+	})
+}
 
-	if req.Title != nil {
-		argCount++
-		query += ", title = $" + strconv.Itoa(argCount)
-		args = append(args, *req.Title)
-	}
-	if req.Description != nil {
-		argCount++
-		query += ", description = $" + strconv.Itoa(argCount)
-		args = append(args, *req.Description)
+// PatchTask - PATCH dengan JSON Merge Patch (RFC 7396, juga untuk application/json)
+// atau JSON Patch (RFC 6902), diterapkan pada dokumen task saat ini
+func (h *TaskHandler) PatchTask(c *gin.Context) {
+	var apply func(doc, patch []byte) ([]byte, error)
+	switch c.ContentType() {
+	case mergePatchType, "application/json", "":
+		apply = jsonpatch.Merge
+	case jsonPatchType:
+		apply = jsonpatch.Apply
+	default:
+		c.Header("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		utils.ErrorResponse(c, http.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchType+" or "+jsonPatchType)
+		return
 	}
-	if req.Priority != nil {
-		argCount++
-		query += ", priority = $" + strconv.Itoa(argCount)
-		args = append(args, *req.Priority)
+
+	patch, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPatchSize))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
 	}
-	if req.Category != nil {
-		argCount++
-		query += ", category = $" + strconv.Itoa(argCount)
-		args = append(args, *req.Category)
+
+	h.saveTask(c, patchDocument(apply, patch))
+}
+
+// saveTask - PUT/PATCH: simpan dokumen baru dengan syarat If-Match
+func (h *TaskHandler) saveTask(c *gin.Context, build func(current models.Task) (models.TaskDocument, error)) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
+		return
 	}
-	if req.IsCompleted != nil {
-		argCount++
-		query += ", is_completed = $" + strconv.Itoa(argCount)
-		args = append(args, *req.IsCompleted)
+
+	task, err := h.tasks.Update(c.GetInt("user_id"), taskID, ifMatch(c), build)
+	if err != nil {
+		respondTaskError(c, err)
+		return
 	}
-	if req.DueDate != nil {
-		argCount++
-		query += ", due_date = $" + strconv.Itoa(argCount)
-		args = append(args, *req.DueDate)
+
+	c.Header("ETag", taskETag(task))
+	utils.SuccessResponse(c, http.StatusOK, "Task updated successfully", task)
+}
+
+// AssignTask - set atau hapus (assignee_id: null) assignee task
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	var req models.AssignTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	argCount++
-	query += " WHERE id = $" + strconv.Itoa(argCount)
-	args = append(args, taskID)
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
+		return
+	}
 
-	argCount++
-	query += " AND user_id = $" + strconv.Itoa(argCount)
-	args = append(args, userID)
+	task, err := h.tasks.Assign(c.GetInt("user_id"), taskID, req.AssigneeID, ifMatch(c))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
 
-	query += " RETURNING id, user_id, title, description, priority, category, is_completed, due_date, created_at, updated_at"
+	c.Header("ETag", taskETag(task))
+	utils.SuccessResponse(c, http.StatusOK, "Task assigned successfully", task)
+}
 
-	var task models.Task
-	err := h.db.QueryRow(query, args...).Scan(
-		&task.ID, &task.UserID, &task.Title, &task.Description, &task.Priority,
-		&task.Category, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt,
-	)
+// MoveTask - pindah task ke kolom lain dan/atau ubah urutannya (drag-and-drop)
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	var req models.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if err == sql.ErrNoRows {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
 		return
 	}
+
+	task, err := h.tasks.Move(c.GetInt("user_id"), taskID, req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update task")
+		respondTaskError(c, err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Task updated successfully", task)
+	c.Header("ETag", taskETag(task))
+	utils.SuccessResponse(c, http.StatusOK, "Task moved successfully", task)
 }
 
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
-	userID := c.GetInt("user_id")
-	taskID := c.Param("id")
-
-	result, err := h.db.Exec("DELETE FROM tasks WHERE id = $1 AND user_id = $2", taskID, userID)
+	taskID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete task")
+		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
+	if _, err := h.tasks.Delete(c.GetInt("user_id"), taskID, ifMatch(c)); err != nil {
+		respondTaskError(c, err)
 		return
 	}
 
@@ -233,52 +228,98 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 }
 
 func (h *TaskHandler) ToggleComplete(c *gin.Context) {
-	userID := c.GetInt("user_id")
-	taskID := c.Param("id")
-
-	var task models.Task
-	err := h.db.QueryRow(
-		`UPDATE tasks SET is_completed = NOT is_completed, updated_at = CURRENT_TIMESTAMP 
-		 WHERE id = $1 AND user_id = $2 
-		 RETURNING id, user_id, title, description, priority, category, is_completed, due_date, created_at, updated_at`,
-		taskID, userID,
-	).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Priority,
-		&task.Category, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt)
-
-	if err == sql.ErrNoRows {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "Task not found")
 		return
 	}
+
+	task, err := h.tasks.ToggleComplete(c.GetInt("user_id"), taskID, ifMatch(c))
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to toggle task")
+		respondTaskError(c, err)
 		return
 	}
 
+	c.Header("ETag", taskETag(task))
 	utils.SuccessResponse(c, http.StatusOK, "Task toggled successfully", task)
 }
 
 func (h *TaskHandler) GetStats(c *gin.Context) {
-	userID := c.GetInt("user_id")
-
-	var stats models.TaskStats
+	stats, err := h.tasks.Stats(c.GetInt("user_id"), filterFromQuery(c.Query))
+	if err != nil {
+		respondTaskError(c, err)
+		return
+	}
 
-	// Get total tasks
-	h.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE user_id = $1", userID).Scan(&stats.Total)
+	utils.SuccessResponse(c, http.StatusOK, "Statistics retrieved successfully", stats)
+}
 
-	// Get completed tasks
-	h.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND is_completed = true", userID).Scan(&stats.Completed)
+// filterFromQuery - filter list/stats dari get (key query string), juga dipakai
+// argumen filter GraphQL. Task di project yang diarsipkan tidak muncul kecuali
+// include_archived=true.
+func filterFromQuery(get func(key string) string) repository.TaskFilter {
+	f := repository.TaskFilter{
+		WorkspaceID:     get("workspace_id"),
+		Assignee:        get("assignee"),
+		CreatedBy:       get("created_by"),
+		ProjectID:       get("project_id"),
+		Priority:        models.Priority(get("priority")),
+		Category:        models.Category(get("category")),
+		Status:          get("status"),
+		Search:          get("search"),
+		IncludeArchived: get("include_archived") == "true",
+	}
+	if isCompleted := get("is_completed"); isCompleted != "" {
+		completed := isCompleted == "true"
+		f.IsCompleted = &completed
+	}
+	return f
+}
 
-	// Get pending tasks
-	h.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND is_completed = false", userID).Scan(&stats.Pending)
+// taskDocument - field task yang bisa diedit, titik awal PATCH
+func taskDocument(task models.Task) models.TaskDocument {
+	isCompleted := task.IsCompleted
+	return models.TaskDocument{
+		Title:       task.Title,
+		Description: task.Description,
+		ProjectID:   task.ProjectID,
+		AssigneeID:  task.AssigneeID,
+		Priority:    task.Priority,
+		Category:    task.Category,
+		Status:      task.Status,
+		IsCompleted: &isCompleted,
+		DueDate:     task.DueDate,
+	}
+}
 
-	// Get high priority tasks
-	h.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND priority = 'high'", userID).Scan(&stats.HighPriority)
+// patchDocument - builder saveTask/TaskService.Update yang menerapkan patch pada dokumen task saat ini
+func patchDocument(apply func(doc, patch []byte) ([]byte, error), patch []byte) func(models.Task) (models.TaskDocument, error) {
+	return func(current models.Task) (models.TaskDocument, error) {
+		doc, err := json.Marshal(taskDocument(current))
+		if err != nil {
+			return models.TaskDocument{}, err
+		}
+		patched, err := apply(doc, patch)
+		if err != nil {
+			return models.TaskDocument{}, err
+		}
+		return decodeTaskDocument(patched)
+	}
+}
 
-	// Get overdue tasks
-	h.db.QueryRow(
-		"SELECT COUNT(*) FROM tasks WHERE user_id = $1 AND is_completed = false AND due_date < $2",
-		userID, time.Now(),
-	).Scan(&stats.Overdue)
+// decodeTaskDocument - hasil patch harus tetap dokumen task yang valid
+func decodeTaskDocument(raw []byte) (models.TaskDocument, error) {
+	var doc models.TaskDocument
+	err := decodeStrict(raw, &doc)
+	return doc, err
+}
 
-	utils.SuccessResponse(c, http.StatusOK, "Statistics retrieved successfully", stats)
+// decodeStrict - decode JSON tanpa field asing lalu validasi tag binding
+func decodeStrict(raw []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(v)
 }
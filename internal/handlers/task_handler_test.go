@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository/memory"
+	"taskflow-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ Authorizer = (*memory.Authorizer)(nil)
+
+// taskServer - route task di atas repository memori; user diambil dari header X-User
+type taskServer struct {
+	store  *memory.Store
+	router *gin.Engine
+}
+
+func newTaskServer(t *testing.T) *taskServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	store := memory.NewStore()
+	az := memory.NewAuthorizer(store)
+	tasks := service.NewTaskService(memory.NewTaskRepository(store), memory.NewUserRepository(store), az, events.NewBus())
+	h := NewTaskHandler(tasks, az)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.GetHeader("X-User"))
+		c.Set("user_id", id)
+	})
+	router.POST("/tasks", h.CreateTask)
+	router.GET("/tasks", h.GetTasks)
+	router.GET("/tasks/board", h.GetBoard)
+	router.GET("/tasks/:id", h.GetTask)
+	router.PUT("/tasks/:id", h.ReplaceTask)
+	router.PATCH("/tasks/:id", h.PatchTask)
+	router.DELETE("/tasks/:id", h.DeleteTask)
+	return &taskServer{store: store, router: router}
+}
+
+func (s *taskServer) user(t *testing.T, name string) int {
+	t.Helper()
+	u, err := memory.NewUserRepository(s.store).Create(name, name+"@example.com", "hash")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return u.ID
+}
+
+// do - kirim request sebagai userID; headers berpasangan nama, nilai
+func (s *taskServer) do(userID int, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-User", strconv.Itoa(userID))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeTask - field data dari response sukses
+func decodeTask(t *testing.T, w *httptest.ResponseRecorder) models.Task {
+	t.Helper()
+	var resp struct {
+		Data models.Task `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", w.Body.String(), err)
+	}
+	return resp.Data
+}
+
+func TestTaskETags(t *testing.T) {
+	s := newTaskServer(t)
+	alice := s.user(t, "alice")
+
+	w := s.do(alice, "POST", "/tasks", `{"title":"Write tests"}`)
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("create = %d %s, ETag %s", w.Code, w.Body.String(), w.Header().Get("ETag"))
+	}
+	path := "/tasks/" + strconv.Itoa(decodeTask(t, w).ID)
+
+	if w := s.do(alice, "GET", path, "", "If-None-Match", `"1"`); w.Code != http.StatusNotModified {
+		t.Errorf("conditional GET = %d, want 304", w.Code)
+	}
+
+	w = s.do(alice, "PATCH", path, `{"title":"Stale"}`, "If-Match", `"7"`)
+	if w.Code != http.StatusPreconditionFailed || w.Header().Get("ETag") != `"1"` {
+		t.Errorf("stale PATCH = %d, ETag %s; want 412 with the current ETag", w.Code, w.Header().Get("ETag"))
+	}
+
+	w = s.do(alice, "PATCH", path, `{"title":"Fresh","priority":"high"}`, "If-Match", `"1"`)
+	task := decodeTask(t, w)
+	if w.Code != http.StatusOK || task.Title != "Fresh" || task.Priority != models.PriorityHigh || w.Header().Get("ETag") != `"2"` {
+		t.Errorf("PATCH = %d %s", w.Code, w.Body.String())
+	}
+
+	if w := s.do(alice, "GET", "/tasks", ""); w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("ETag"), `W/"`) {
+		t.Errorf("list = %d, ETag %s", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	s := newTaskServer(t)
+	alice := s.user(t, "alice")
+	w := s.do(alice, "POST", "/tasks", `{"title":"Patch me","description":"old"}`)
+	path := "/tasks/" + strconv.Itoa(decodeTask(t, w).ID)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"merge patch null", mergePatchType, `{"description":null}`, http.StatusOK},
+		{"json patch", jsonPatchType, `[{"op":"replace","path":"/status","value":"done"}]`, http.StatusOK},
+		{"failed test", jsonPatchType, `[{"op":"test","path":"/title","value":"Other"}]`, http.StatusConflict},
+		{"unknown field", mergePatchType, `{"color":"red"}`, http.StatusBadRequest},
+		{"invalid priority", mergePatchType, `{"priority":"urgent"}`, http.StatusBadRequest},
+		{"unsupported media type", "text/plain", `title`, http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := s.do(alice, "PATCH", path, tt.body, "Content-Type", tt.contentType)
+			if w.Code != tt.status {
+				t.Errorf("status = %d %s, want %d", w.Code, w.Body.String(), tt.status)
+			}
+		})
+	}
+
+	task := decodeTask(t, s.do(alice, "GET", path, ""))
+	if task.Description != nil || task.Status != models.StatusDone || !task.IsCompleted || task.Title != "Patch me" {
+		t.Errorf("task after patches = %+v", task)
+	}
+}
+
+func TestTaskAccess(t *testing.T) {
+	s := newTaskServer(t)
+	alice, bob := s.user(t, "alice"), s.user(t, "bob")
+	ws := s.store.AddWorkspace(alice)
+
+	w := s.do(alice, "POST", "/tasks", `{"title":"Private"}`)
+	path := "/tasks/" + strconv.Itoa(decodeTask(t, w).ID)
+
+	if w := s.do(bob, "GET", path, ""); w.Code != http.StatusNotFound {
+		t.Errorf("other user's task = %d, want 404", w.Code)
+	}
+	if w := s.do(bob, "DELETE", path, ""); w.Code != http.StatusNotFound {
+		t.Errorf("delete other user's task = %d, want 404", w.Code)
+	}
+	if w := s.do(alice, "GET", "/tasks/abc", ""); w.Code != http.StatusNotFound {
+		t.Errorf("non-numeric id = %d, want 404", w.Code)
+	}
+
+	board := "/tasks/board?workspace_id=" + strconv.Itoa(ws)
+	if w := s.do(bob, "GET", board, ""); w.Code != http.StatusNotFound {
+		t.Errorf("board of foreign workspace = %d, want 404", w.Code)
+	}
+	if w := s.do(alice, "GET", board, ""); w.Code != http.StatusOK {
+		t.Errorf("own workspace board = %d %s", w.Code, w.Body.String())
+	}
+	if w := s.do(alice, "GET", "/tasks/board?workspace_id=x", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid workspace_id = %d, want 400", w.Code)
+	}
+
+	if w := s.do(alice, "DELETE", path, "", "If-Match", `"1"`); w.Code != http.StatusOK {
+		t.Errorf("delete = %d %s", w.Code, w.Body.String())
+	}
+	if w := s.do(alice, "GET", path, ""); w.Code != http.StatusNotFound {
+		t.Errorf("deleted task = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+	"taskflow-api/internal/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhooks repository.WebhookRepository
+	authz    Authorizer
+	policy   webhook.Policy
+}
+
+func NewWebhookHandler(webhooks repository.WebhookRepository, az Authorizer, policy webhook.Policy) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks, authz: az, policy: policy}
+}
+
+// CreateWebhook - webhook workspace hanya bisa dibuat oleh owner/admin
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.policy.CheckURL(req.URL); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.authz.Scope(userID, req.WorkspaceID, authz.ActionManage); err != nil {
+		respondAuthzError(c, err, "Workspace not found")
+		return
+	}
+
+	secret, err := utils.GenerateToken(32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate secret")
+		return
+	}
+
+	webhook, err := h.webhooks.Create(repository.Board{UserID: userID, WorkspaceID: req.WorkspaceID}, req, secret)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create webhook")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Webhook created successfully", webhook)
+}
+
+// GetWebhooks - webhook personal milik user plus webhook workspace yang dia kelola
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	webhooks, err := h.webhooks.List(userID, c.Query("workspace_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch webhooks")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhooks retrieved successfully", webhooks)
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.Get(res.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch webhook")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook retrieved successfully", webhook)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.URL != nil {
+		if err := h.policy.CheckURL(*req.URL); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.Update(res.ID, req)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update webhook")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook updated successfully", webhook)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	err := h.webhooks.Delete(res.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete webhook")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GetDeliveries - log pengiriman terbaru dulu; ?status=pending|succeeded|failed
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhooks.Deliveries(res.ID, c.Query("status"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch deliveries")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Deliveries retrieved successfully", deliveries)
+}
+
+// GetDelivery - detail termasuk payload dan response body
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := deliveryParam(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhooks.Delivery(res.ID, deliveryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Delivery not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch delivery")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delivery retrieved successfully", delivery)
+}
+
+// Redeliver - antrekan ulang payload yang sama sebagai delivery baru
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	res, ok := h.authorizeWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := deliveryParam(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhooks.Redeliver(res.ID, deliveryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Delivery not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to queue redelivery")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Redelivery queued", delivery)
+}
+
+// authorizeWebhook - webhook personal hanya untuk pemiliknya, webhook workspace untuk owner/admin
+func (h *WebhookHandler) authorizeWebhook(c *gin.Context, userID int) (authz.Resource, bool) {
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Webhook not found")
+		return authz.Resource{}, false
+	}
+
+	res, err := h.authz.Webhook(userID, webhookID, authz.ActionManage)
+	if err != nil {
+		respondAuthzError(c, err, "Webhook not found")
+		return res, false
+	}
+	return res, true
+}
+
+// deliveryParam - ID delivery di path, tulis 404 kalau bukan angka
+func deliveryParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Delivery not found")
+		return 0, false
+	}
+	return id, true
+}
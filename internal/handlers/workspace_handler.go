@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Masa berlaku link undangan
+const invitationTTL = 7 * 24 * time.Hour
+
+type WorkspaceHandler struct {
+	workspaces repository.WorkspaceRepository
+	users      repository.UserRepository
+	authz      Authorizer
+}
+
+func NewWorkspaceHandler(workspaces repository.WorkspaceRepository, users repository.UserRepository, az Authorizer) *WorkspaceHandler {
+	return &WorkspaceHandler{workspaces: workspaces, users: users, authz: az}
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspace, err := h.workspaces.Create(req.Name, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create workspace")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Workspace created successfully", workspace)
+}
+
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	workspaces, err := h.workspaces.List(c.GetInt("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch workspaces")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Workspaces retrieved successfully", workspaces)
+}
+
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	workspaceID, role, ok := h.authorizeWorkspace(c, userID, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaces.Get(workspaceID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		return
+	}
+	workspace.Role = string(role)
+
+	utils.SuccessResponse(c, http.StatusOK, "Workspace retrieved successfully", workspace)
+}
+
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspaceID, role, ok := h.authorizeWorkspace(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	workspace, err := h.workspaces.Rename(workspaceID, req.Name)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update workspace")
+		return
+	}
+	workspace.Role = string(role)
+
+	utils.SuccessResponse(c, http.StatusOK, "Workspace updated successfully", workspace)
+}
+
+// DeleteWorkspace - hanya owner; semua task dan project di workspace ikut terhapus
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionOwn)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaces.Delete(workspaceID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete workspace")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Workspace deleted successfully", nil)
+}
+
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionView)
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaces.Members(workspaceID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Members retrieved successfully", members)
+}
+
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Member not found or is the owner")
+		return
+	}
+
+	err = h.workspaces.SetMemberRole(workspaceID, memberID, req.Role)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Member not found or is the owner")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Member updated successfully", nil)
+}
+
+// RemoveMember - owner/admin bisa mengeluarkan anggota, anggota bisa keluar sendiri
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	memberID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Member not found")
+		return
+	}
+
+	action := authz.ActionManage
+	if memberID == userID {
+		action = authz.ActionView
+	}
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, action)
+	if !ok {
+		return
+	}
+
+	err = h.workspaces.RemoveMember(workspaceID, memberID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Member not found or is the owner")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Member removed successfully", nil)
+}
+
+func (h *WorkspaceHandler) CreateInvitation(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	var req models.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	exists, err := h.workspaces.IsMemberEmail(workspaceID, req.Email)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		return
+	}
+	if exists {
+		utils.ErrorResponse(c, http.StatusConflict, "User is already a member")
+		return
+	}
+
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	invitation, err := h.workspaces.CreateInvitation(models.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       req.Email,
+		Role:        req.Role,
+		Token:       token,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(invitationTTL),
+	}, utils.HashToken(token))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Invitation created successfully", invitation)
+}
+
+func (h *WorkspaceHandler) GetInvitations(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.workspaces.Invitations(workspaceID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invitations retrieved successfully", invitations)
+}
+
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	workspaceID, _, ok := h.authorizeWorkspace(c, userID, authz.ActionManage)
+	if !ok {
+		return
+	}
+
+	invitationID, err := strconv.Atoi(c.Param("invitationId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Invitation not found")
+		return
+	}
+
+	err = h.workspaces.RevokeInvitation(workspaceID, invitationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Invitation not found")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke invitation")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invitation revoked successfully", nil)
+}
+
+// GetMyInvitations - undangan yang masih berlaku untuk email user yang login
+func (h *WorkspaceHandler) GetMyInvitations(c *gin.Context) {
+	invitations, err := h.workspaces.InvitationsFor(c.GetInt("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation - token hanya bisa dipakai oleh user dengan email yang diundang
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	userID := c.GetInt("user_id")
+
+	user, err := h.users.Get(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		return
+	}
+
+	var invitation models.WorkspaceInvitation
+	err = h.workspaces.Transact(func(tx repository.WorkspaceRepository) error {
+		var err error
+		invitation, err = tx.LockInvitation(utils.HashToken(c.Param("token")))
+		if errors.Is(err, repository.ErrNotFound) {
+			return httpError(http.StatusNotFound, "Invitation not found")
+		}
+		if err != nil {
+			return err
+		}
+		if invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+			return httpError(http.StatusGone, "Invitation has expired or was already used")
+		}
+		if !strings.EqualFold(user.Email, invitation.Email) {
+			return httpError(http.StatusForbidden, "Invitation was sent to a different email")
+		}
+		return tx.AcceptInvitation(invitation, userID)
+	})
+	if err != nil {
+		respondTxError(c, err, "Failed to join workspace")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invitation accepted successfully",
+		gin.H{"workspace_id": invitation.WorkspaceID, "role": invitation.Role})
+}
+
+// authorizeWorkspace - cek izin user atas workspace di path :id, tulis error kalau ditolak
+func (h *WorkspaceHandler) authorizeWorkspace(c *gin.Context, userID int, action authz.Action) (int, authz.Role, bool) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Workspace not found")
+		return 0, "", false
+	}
+
+	role, err := h.authz.Workspace(userID, workspaceID, action)
+	if err != nil {
+		respondAuthzError(c, err, "Workspace not found")
+		return 0, "", false
+	}
+	return workspaceID, role, true
+}
+
+// respondAuthzError - ErrNotFound jadi 404 (tidak membocorkan keberadaan
+// resource), ErrForbidden jadi 403
+func respondAuthzError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, authz.ErrNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, notFound)
+	case errors.Is(err, authz.ErrForbidden):
+		utils.ErrorResponse(c, http.StatusForbidden, "You don't have permission to perform this action")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+	}
+}
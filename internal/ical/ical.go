@@ -0,0 +1,314 @@
+// Package ical membaca dan menulis iCalendar (RFC 5545) untuk feed kalender
+// dan import file VTODO.
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLine - panjang baris maksimum dalam octet sebelum dilipat
+const maxLine = 75
+
+// Component - VCALENDAR, VTODO, VEVENT, ... beserta property dan komponen di dalamnya
+type Component struct {
+	Name       string
+	Line       int // baris BEGIN di file, mulai 1
+	Props      []Property
+	Components []*Component
+}
+
+// Property - satu baris content; Value masih dalam bentuk escape iCalendar
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Get - property pertama dengan nama tersebut, atau nil
+func (c *Component) Get(name string) *Property {
+	for i := range c.Props {
+		if c.Props[i].Name == name {
+			return &c.Props[i]
+		}
+	}
+	return nil
+}
+
+// All - semua property dengan nama tersebut (mis. CATEGORIES bisa berulang)
+func (c *Component) All(name string) []Property {
+	var props []Property
+	for _, p := range c.Props {
+		if p.Name == name {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// Text - nilai TEXT yang sudah di-unescape, "" kalau property tidak ada
+func (c *Component) Text(name string) string {
+	if p := c.Get(name); p != nil {
+		return Unescape(p.Value)
+	}
+	return ""
+}
+
+// Parse - baca satu VCALENDAR. Baris yang dilipat digabung dan nama property/parameter
+// dijadikan huruf besar; property yang tidak dikenal tetap disimpan.
+func Parse(data []byte) (*Component, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	lines := unfold(data)
+
+	var root *Component
+	var stack []*Component
+	for _, l := range lines {
+		if strings.TrimSpace(l.text) == "" {
+			continue
+		}
+		prop, err := parseLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", l.number, err)
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(strings.TrimSpace(prop.Value)), Line: l.number}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("line %d: only one VCALENDAR is supported", l.number)
+				}
+				root = c
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			name := strings.ToUpper(strings.TrimSpace(prop.Value))
+			if len(stack) == 0 || stack[len(stack)-1].Name != name {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", l.number, name)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s outside of BEGIN:VCALENDAR", l.number, prop.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Props = append(c.Props, prop)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no BEGIN:VCALENDAR found")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	if root.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("expected VCALENDAR, got %s", root.Name)
+	}
+	return root, nil
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+// unfold - gabungkan baris lanjutan (diawali spasi atau tab) ke baris sebelumnya
+func unfold(data []byte) []line {
+	var lines []line
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimSuffix(raw, "\r")
+		if len(lines) > 0 && raw != "" && (raw[0] == ' ' || raw[0] == '\t') {
+			lines[len(lines)-1].text += raw[1:]
+			continue
+		}
+		lines = append(lines, line{number: i + 1, text: raw})
+	}
+	return lines
+}
+
+// parseLine - NAME;PARAM=value;PARAM="quoted":VALUE
+func parseLine(s string) (Property, error) {
+	var p Property
+	i := strings.IndexAny(s, ";:")
+	if i <= 0 {
+		return p, errors.New("invalid content line")
+	}
+	p.Name = strings.ToUpper(s[:i])
+
+	for s[i] == ';' {
+		s = s[i+1:]
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return p, errors.New("invalid parameter")
+		}
+		name := strings.ToUpper(s[:eq])
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				return p, errors.New("unterminated quoted parameter")
+			}
+			value = s[1 : end+1]
+			s = s[end+2:]
+		} else {
+			end := strings.IndexAny(s, ";:")
+			if end < 0 {
+				return p, errors.New("invalid content line")
+			}
+			value = s[:end]
+			s = s[end:]
+		}
+		if p.Params == nil {
+			p.Params = make(map[string]string)
+		}
+		p.Params[name] = value
+
+		if s == "" {
+			return p, errors.New("invalid content line")
+		}
+		i = 0
+	}
+	if s[i] != ':' {
+		return p, errors.New("invalid content line")
+	}
+	p.Value = s[i+1:]
+	return p, nil
+}
+
+// Escape - escape nilai TEXT (backslash, titik koma, koma, baris baru)
+func Escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', ';', ',':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Unescape - kebalikan Escape
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// SplitList - nilai TEXT dengan beberapa item dipisah koma (mis. CATEGORIES)
+func SplitList(s string) []string {
+	var items []string
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i < len(s)-1:
+			b.WriteByte('\\')
+			b.WriteByte(s[i+1])
+			i++
+		case s[i] == ',':
+			items = append(items, Unescape(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return append(items, Unescape(b.String()))
+}
+
+// Encoder - menulis content line dengan CRLF dan melipat baris di 75 octet.
+// Error pertama disimpan dan dikembalikan oleh Flush.
+type Encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *Encoder) Begin(name string) { e.line("BEGIN:" + name) }
+
+func (e *Encoder) End(name string) { e.line("END:" + name) }
+
+// Prop - tulis property dengan nilai apa adanya (sudah dalam format iCalendar).
+// params berpasangan: nama, nilai, nama, nilai, ...
+func (e *Encoder) Prop(name, value string, params ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	for i := 0; i+1 < len(params); i += 2 {
+		b.WriteString(";" + params[i] + "=")
+		if strings.ContainsAny(params[i+1], ";:,") {
+			b.WriteString(`"` + params[i+1] + `"`)
+		} else {
+			b.WriteString(params[i+1])
+		}
+	}
+	b.WriteString(":" + value)
+	e.line(b.String())
+}
+
+// Text - tulis property TEXT, di-escape
+func (e *Encoder) Text(name, value string) { e.Prop(name, Escape(value)) }
+
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// line - lipat tanpa memotong karakter UTF-8
+func (e *Encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Spasi di awal baris lanjutan ikut dihitung
+		limit = maxLine - 1
+	}
+	e.write(s + "\r\n")
+}
+
+func (e *Encoder) write(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
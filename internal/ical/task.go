@@ -0,0 +1,220 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+// Komponen yang bisa dipakai untuk task di feed
+const (
+	VTODO  = "VTODO"
+	VEVENT = "VEVENT"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	localLayout    = "20060102T150405"
+)
+
+// ProdID - identitas pembuat kalender
+const ProdID = "-//TaskFlow//TaskFlow API//EN"
+
+// priorities - PRIORITY iCalendar: 1 tertinggi, 9 terendah, 0 tidak ada
+var priorities = map[models.Priority]string{
+	models.PriorityHigh:   "1",
+	models.PriorityMedium: "5",
+	models.PriorityLow:    "9",
+}
+
+// UID - UID stabil untuk satu task
+func UID(taskID int) string {
+	return "task-" + strconv.Itoa(taskID) + "@taskflow"
+}
+
+// BeginObject - header VCALENDAR untuk satu resource CalDAV (tanpa METHOD)
+func (e *Encoder) BeginObject() {
+	e.Begin("VCALENDAR")
+	e.Prop("VERSION", "2.0")
+	e.Prop("PRODID", ProdID)
+}
+
+// BeginCalendar - header VCALENDAR untuk feed. name tampil sebagai nama kalender di aplikasi.
+func (e *Encoder) BeginCalendar(name string) {
+	e.BeginObject()
+	e.Prop("CALSCALE", "GREGORIAN")
+	e.Prop("METHOD", "PUBLISH")
+	e.Text("X-WR-CALNAME", name)
+	e.Prop("X-PUBLISHED-TTL", "PT1H")
+	e.Prop("REFRESH-INTERVAL", "PT1H", "VALUE", "DURATION")
+}
+
+func (e *Encoder) EndCalendar() { e.End("VCALENDAR") }
+
+// Task - tulis task sebagai VTODO atau VEVENT dengan UID tersebut. Due date tepat
+// tengah malam UTC dianggap tanggal saja (acara seharian). VEVENT tanpa due date
+// tidak ditulis.
+func (e *Encoder) Task(task models.Task, component, uid string) {
+	if component == VEVENT && task.DueDate == nil {
+		return
+	}
+
+	e.Begin(component)
+	e.Text("UID", uid)
+	e.Prop("DTSTAMP", task.UpdatedAt.UTC().Format(dateTimeLayout))
+	e.Prop("CREATED", task.CreatedAt.UTC().Format(dateTimeLayout))
+	e.Prop("LAST-MODIFIED", task.UpdatedAt.UTC().Format(dateTimeLayout))
+	e.Prop("SEQUENCE", strconv.Itoa(task.Version))
+	summary := task.Title
+	if component == VEVENT && task.IsCompleted {
+		// Acara tidak punya status selesai; yang selesai ditandai di judul
+		summary = "✓ " + summary
+	}
+	e.Text("SUMMARY", summary)
+	if task.Description != nil && *task.Description != "" {
+		e.Text("DESCRIPTION", *task.Description)
+	}
+	if p, ok := priorities[task.Priority]; ok {
+		e.Prop("PRIORITY", p)
+	}
+	if task.Category != "" {
+		e.Text("CATEGORIES", string(task.Category))
+	}
+
+	if component == VTODO {
+		if task.DueDate != nil {
+			e.date("DUE", *task.DueDate)
+		}
+		if task.IsCompleted {
+			e.Prop("STATUS", "COMPLETED")
+			e.Prop("PERCENT-COMPLETE", "100")
+			e.Prop("COMPLETED", task.UpdatedAt.UTC().Format(dateTimeLayout))
+		} else {
+			e.Prop("STATUS", "NEEDS-ACTION")
+		}
+	} else {
+		due := *task.DueDate
+		e.date("DTSTART", due)
+		if allDay(due) {
+			e.Prop("DTEND", due.AddDate(0, 0, 1).UTC().Format(dateLayout), "VALUE", "DATE")
+		}
+		e.Prop("TRANSP", "TRANSPARENT")
+		e.Prop("STATUS", "CONFIRMED")
+	}
+	e.End(component)
+}
+
+// date - DATE untuk acara seharian, DATE-TIME UTC untuk yang lain
+func (e *Encoder) date(name string, t time.Time) {
+	if allDay(t) {
+		e.Prop(name, t.UTC().Format(dateLayout), "VALUE", "DATE")
+		return
+	}
+	e.Prop(name, t.UTC().Format(dateTimeLayout))
+}
+
+func allDay(t time.Time) bool {
+	t = t.UTC()
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// Todo - isi VTODO yang dipakai untuk membuat task
+type Todo struct {
+	UID         string
+	Summary     string
+	Description string
+	Priority    models.Priority
+	Categories  []string
+	Completed   bool
+	Due         *time.Time
+	AllDay      bool   // DUE berupa DATE tanpa jam
+	RRule       string // task belum bisa berulang; aturan ini hanya dicatat
+}
+
+// Todos - semua VTODO di kalender, urut seperti di file
+func Todos(cal *Component) []*Component {
+	var todos []*Component
+	for _, c := range cal.Components {
+		if c.Name == VTODO {
+			todos = append(todos, c)
+		}
+	}
+	return todos
+}
+
+// ParseTodo - baca field task dari satu VTODO
+func ParseTodo(c *Component) (Todo, error) {
+	todo := Todo{
+		UID:         strings.TrimSpace(c.Text("UID")),
+		Summary:     c.Text("SUMMARY"),
+		Description: c.Text("DESCRIPTION"),
+	}
+
+	if p := c.Get("PRIORITY"); p != nil {
+		n, err := strconv.Atoi(strings.TrimSpace(p.Value))
+		if err != nil || n < 0 || n > 9 {
+			return todo, fmt.Errorf("invalid PRIORITY %q", p.Value)
+		}
+		switch {
+		case n == 0:
+		case n <= 4:
+			todo.Priority = models.PriorityHigh
+		case n == 5:
+			todo.Priority = models.PriorityMedium
+		default:
+			todo.Priority = models.PriorityLow
+		}
+	}
+
+	for _, p := range c.All("CATEGORIES") {
+		for _, category := range SplitList(p.Value) {
+			if category = strings.TrimSpace(category); category != "" {
+				todo.Categories = append(todo.Categories, category)
+			}
+		}
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(c.Text("STATUS")))
+	todo.Completed = status == "COMPLETED" || (c.Get("COMPLETED") != nil && status != "NEEDS-ACTION" && status != "IN-PROCESS")
+
+	if p := c.Get("DUE"); p != nil {
+		due, err := ParseTime(*p)
+		if err != nil {
+			return todo, fmt.Errorf("invalid DUE: %v", err)
+		}
+		todo.Due = &due
+		todo.AllDay = p.Params["VALUE"] == "DATE" || len(strings.TrimSpace(p.Value)) == len(dateLayout)
+	}
+	if p := c.Get("RRULE"); p != nil {
+		todo.RRule = strings.TrimSpace(p.Value)
+	}
+	return todo, nil
+}
+
+// ParseTime - DATE, DATE-TIME UTC, DATE-TIME dengan TZID, atau waktu lokal
+// mengambang (dianggap UTC)
+func ParseTime(p Property) (time.Time, error) {
+	value := strings.TrimSpace(p.Value)
+	if p.Params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		return time.Parse(dateLayout, value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(dateTimeLayout, value)
+	}
+
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(localLayout, value, loc)
+	if err != nil {
+		return t, err
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,245 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"taskflow-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Header - header yang dikirim client
+	Header = "Idempotency-Key"
+	// maxKeyLength - sama dengan kolom idempotency_key
+	maxKeyLength = 255
+	// pruneInterval - seberapa sering key kadaluarsa dihapus
+	pruneInterval = time.Hour
+)
+
+// replayedHeaders - header response yang ikut disimpan dan diputar ulang
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Store - menyimpan response POST per (user, Idempotency-Key) selama window
+type Store struct {
+	db     *sql.DB
+	window time.Duration
+}
+
+func New(db *sql.DB, window time.Duration) *Store {
+	return &Store{db: db, window: window}
+}
+
+// Middleware - untuk route POST yang sudah melewati AuthMiddleware. Request
+// tanpa header Idempotency-Key, atau tanpa user, diproses seperti biasa.
+// Response disimpan apa adanya, jadi jangan dipasang di route yang
+// mengembalikan secret sekali tampil (app password, token share/feed/undangan).
+func (s *Store) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		userID := c.GetInt("user_id")
+		if c.Request.Method != http.MethodPost || key == "" || userID == 0 {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := fingerprint(c.Request, body)
+
+		claimed, err := s.claim(c.Request.Context(), userID, key, fingerprint)
+		if err != nil {
+			log.Printf("idempotency: claim: %v", err)
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+			c.Abort()
+			return
+		}
+		if !claimed {
+			s.replay(c, userID, key, fingerprint)
+			c.Abort()
+			return
+		}
+
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+
+		completed := false
+		defer func() {
+			// Gagal di server (atau panic): lepas key supaya retry bisa dicoba lagi
+			if !completed {
+				s.release(userID, key)
+			}
+		}()
+
+		c.Next()
+
+		if rec.Status() >= http.StatusInternalServerError {
+			return
+		}
+		if err := s.complete(userID, key, rec); err != nil {
+			log.Printf("idempotency: save response: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// Run - hapus key kadaluarsa secara berkala sampai ctx dibatalkan
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at < $1", time.Now()); err != nil {
+			log.Printf("idempotency: prune: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim - ambil key untuk request ini; key yang sudah kadaluarsa boleh dipakai ulang.
+// false kalau key masih dipegang request lain (selesai atau belum).
+func (s *Store) claim(ctx context.Context, userID int, key, fingerprint string) (bool, error) {
+	now := time.Now()
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (user_id, idempotency_key, fingerprint, created_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		 SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, response_headers = NULL,
+		     response_body = NULL, created_at = EXCLUDED.created_at, completed_at = NULL,
+		     expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at < $4`,
+		userID, key, fingerprint, now, now.Add(s.window),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// replay - key sudah ada: putar ulang response, atau tolak kalau request-nya beda
+func (s *Store) replay(c *gin.Context, userID int, key, fingerprint string) {
+	var storedFingerprint string
+	var status sql.NullInt64
+	var headers sql.NullString
+	var body []byte
+	err := s.db.QueryRowContext(c.Request.Context(),
+		`SELECT fingerprint, status_code, response_headers, response_body
+		 FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2`,
+		userID, key,
+	).Scan(&storedFingerprint, &status, &headers, &body)
+	if err == sql.ErrNoRows {
+		// Dilepas oleh request pertama di antara claim dan select
+		utils.ErrorResponse(c, http.StatusConflict, "Request with this Idempotency-Key failed; retry it")
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Database error")
+		return
+	}
+
+	if storedFingerprint != fingerprint {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+		return
+	}
+	if !status.Valid {
+		c.Header("Retry-After", "1")
+		utils.ErrorResponse(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+		return
+	}
+
+	var stored map[string]string
+	if headers.Valid {
+		json.Unmarshal([]byte(headers.String), &stored)
+	}
+	for name, value := range stored {
+		c.Header(name, value)
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Status(int(status.Int64))
+	c.Writer.Write(body)
+}
+
+func (s *Store) complete(userID int, key string, rec *recorder) error {
+	headers := map[string]string{}
+	for _, name := range replayedHeaders {
+		if value := rec.Header().Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE idempotency_keys
+		 SET status_code = $1, response_headers = $2, response_body = $3, completed_at = CURRENT_TIMESTAMP
+		 WHERE user_id = $4 AND idempotency_key = $5`,
+		rec.Status(), string(encoded), rec.body.Bytes(), userID, key,
+	)
+	return err
+}
+
+func (s *Store) release(userID int, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND completed_at IS NULL",
+		userID, key,
+	)
+	if err != nil {
+		log.Printf("idempotency: release: %v", err)
+	}
+}
+
+// fingerprint - hash method, path (termasuk query) dan body
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder - salin body response sambil tetap menulis ke client
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,169 @@
+package idempotency
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/database/dbtest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idempotencyServer - satu route POST di belakang Middleware; user dari header
+// X-User, status response dari header X-Status, calls menghitung eksekusi handler
+type idempotencyServer struct {
+	router *gin.Engine
+	calls  int
+}
+
+func newIdempotencyServer(t *testing.T) (*idempotencyServer, *sql.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := dbtest.Open(t)
+
+	s := &idempotencyServer{router: gin.New()}
+	s.router.Use(func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.GetHeader("X-User"))
+		c.Set("user_id", id)
+	})
+	s.router.Use(New(db, time.Hour).Middleware())
+	handler := func(c *gin.Context) {
+		s.calls++
+		status, _ := strconv.Atoi(c.GetHeader("X-Status"))
+		if status == 0 {
+			status = http.StatusCreated
+		}
+		c.Header("Location", "/tasks/"+strconv.Itoa(s.calls))
+		c.JSON(status, gin.H{"call": s.calls})
+	}
+	s.router.POST("/tasks", handler)
+	s.router.GET("/tasks", handler)
+	return s, db
+}
+
+// do - kirim request; headers berpasangan nama, nilai
+func (s *idempotencyServer) do(method, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/tasks", strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareReplaysStoredResponse(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	user := strconv.Itoa(dbtest.User(t, db, "a@example.com"))
+
+	first := s.do(http.MethodPost, `{"title":"a"}`, "X-User", user, Header, "k1")
+	retry := s.do(http.MethodPost, `{"title":"a"}`, "X-User", user, Header, "k1")
+
+	if s.calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", s.calls)
+	}
+	if retry.Code != first.Code || retry.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", retry.Code, retry.Body, first.Code, first.Body)
+	}
+	if got := retry.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("Idempotent-Replayed = %q, want true", got)
+	}
+	if got := retry.Header().Get("Location"); got != "/tasks/1" {
+		t.Errorf("Location = %q, want /tasks/1", got)
+	}
+}
+
+func TestMiddlewareRejectsReusedKey(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	user := strconv.Itoa(dbtest.User(t, db, "a@example.com"))
+
+	s.do(http.MethodPost, `{"title":"a"}`, "X-User", user, Header, "k1")
+	w := s.do(http.MethodPost, `{"title":"b"}`, "X-User", user, Header, "k1")
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", w.Code)
+	}
+	if s.calls != 1 {
+		t.Errorf("handler ran %d times, want 1", s.calls)
+	}
+}
+
+func TestMiddlewarePassesThrough(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	user := strconv.Itoa(dbtest.User(t, db, "a@example.com"))
+
+	tests := []struct {
+		name    string
+		method  string
+		headers []string
+	}{
+		{name: "no key", method: http.MethodPost, headers: []string{"X-User", user}},
+		{name: "no user", method: http.MethodPost, headers: []string{Header, "k1"}},
+		{name: "not a POST", method: http.MethodGet, headers: []string{"X-User", user, Header, "k1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := s.calls
+			for i := 0; i < 2; i++ {
+				if w := s.do(tt.method, `{}`, tt.headers...); w.Header().Get("Idempotent-Replayed") != "" {
+					t.Fatalf("request %d was replayed", i+1)
+				}
+			}
+			if got := s.calls - before; got != 2 {
+				t.Errorf("handler ran %d times, want 2", got)
+			}
+		})
+	}
+}
+
+func TestMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	user := strconv.Itoa(dbtest.User(t, db, "a@example.com"))
+
+	failed := s.do(http.MethodPost, `{}`, "X-User", user, Header, "k1", "X-Status", "503")
+	retry := s.do(http.MethodPost, `{}`, "X-User", user, Header, "k1")
+
+	if failed.Code != http.StatusServiceUnavailable || retry.Code != http.StatusCreated {
+		t.Errorf("statuses = %d, %d, want 503, 201", failed.Code, retry.Code)
+	}
+	if s.calls != 2 {
+		t.Errorf("handler ran %d times, want 2", s.calls)
+	}
+}
+
+func TestMiddlewareKeysBelongToUser(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	alice := strconv.Itoa(dbtest.User(t, db, "alice@example.com"))
+	bob := strconv.Itoa(dbtest.User(t, db, "bob@example.com"))
+
+	s.do(http.MethodPost, `{}`, "X-User", alice, Header, "k1")
+	// User lain dengan key yang sama tidak mendapat response user pertama
+	w := s.do(http.MethodPost, `{}`, "X-User", bob, Header, "k1")
+
+	if w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Errorf("status = %d, replayed = %q, want 201 and not replayed", w.Code, w.Header().Get("Idempotent-Replayed"))
+	}
+	if s.calls != 2 {
+		t.Errorf("handler ran %d times, want 2", s.calls)
+	}
+}
+
+func TestMiddlewareRejectsLongKey(t *testing.T) {
+	s, db := newIdempotencyServer(t)
+	user := strconv.Itoa(dbtest.User(t, db, "a@example.com"))
+
+	w := s.do(http.MethodPost, `{}`, "X-User", user, Header, strings.Repeat("k", maxKeyLength+1))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+	if s.calls != 0 {
+		t.Errorf("handler ran %d times, want 0", s.calls)
+	}
+}
@@ -0,0 +1,210 @@
+// Package importer menjalankan import task dari CSV, export JSON TaskFlow,
+// Todoist, Trello dan iCalendar (VTODO) sebagai job background.
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+)
+
+const (
+	// pollInterval - seberapa sering antrean dicek kalau tidak dibangunkan
+	pollInterval = 5 * time.Second
+	// lease - job running yang tidak melapor progress selama ini diambil alih instance lain
+	lease = 2 * time.Minute
+	// MaxRowErrors - error baris yang disimpan per job; sisanya hanya dihitung
+	MaxRowErrors = 1000
+)
+
+// CreateFunc - buat satu task atas nama user dengan aturan yang sama seperti POST /tasks
+type CreateFunc func(userID int, req models.CreateTaskRequest) (models.Task, error)
+
+// Runner - mengerjakan import_jobs satu per satu. Job diklaim dengan FOR UPDATE
+// SKIP LOCKED dan lease, jadi aman di beberapa instance dan dilanjutkan dari
+// processed_rows setelah restart.
+type Runner struct {
+	db      *sql.DB
+	dialect database.Dialect
+	create  CreateFunc
+	wake    chan struct{}
+}
+
+func New(db *sql.DB, create CreateFunc) *Runner {
+	return &Runner{db: db, dialect: database.DialectOf(db), create: create, wake: make(chan struct{}, 1)}
+}
+
+// Wake - ada job baru; mulai tanpa menunggu poll berikutnya
+func (r *Runner) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run - kerjakan job sampai ctx dibatalkan
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, ok, err := r.claim(ctx)
+			if err != nil {
+				log.Printf("importer: claim: %v", err)
+				break
+			}
+			if !ok {
+				break
+			}
+			r.process(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+type claimedJob struct {
+	id          int
+	userID      int
+	workspaceID *int
+	format      string
+	data        []byte
+	mapping     map[string]string
+	processed   int
+	errors      int
+}
+
+// claim - ambil job pending, atau job running yang lease-nya habis
+func (r *Runner) claim(ctx context.Context) (claimedJob, bool, error) {
+	now := time.Now()
+	var job claimedJob
+	var mapping sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE import_jobs
+		 SET status = $1, started_at = COALESCE(started_at, $3), lease_until = $4
+		 WHERE id = (
+		     SELECT id FROM import_jobs
+		     WHERE status = $2 OR (status = $1 AND lease_until < $3)
+		     ORDER BY id
+		     LIMIT 1`+r.dialect.LockRows("FOR UPDATE SKIP LOCKED")+`)
+		 RETURNING id, user_id, workspace_id, format, data, mapping, processed_rows,
+		     (SELECT COUNT(*) FROM import_errors WHERE job_id = import_jobs.id)`,
+		models.ImportRunning, models.ImportPending, now, now.Add(lease),
+	).Scan(&job.id, &job.userID, &job.workspaceID, &job.format, &job.data, &mapping, &job.processed, &job.errors)
+	if err == sql.ErrNoRows {
+		return job, false, nil
+	}
+	if err != nil {
+		return job, false, err
+	}
+	if mapping.Valid {
+		if err := json.Unmarshal([]byte(mapping.String), &job.mapping); err != nil {
+			r.fail(job.id, "invalid column mapping")
+			return job, false, nil
+		}
+	}
+	return job, true, nil
+}
+
+// process - buat task baris demi baris, progress disimpan setiap baris. Kalau
+// proses mati di antara membuat task dan menyimpan progress, baris itu diimpor dua kali.
+func (r *Runner) process(ctx context.Context, job claimedJob) {
+	rows, err := Parse(job.format, job.data, job.mapping)
+	if err != nil {
+		r.fail(job.id, err.Error())
+		return
+	}
+
+	for i := job.processed; i < len(rows); i++ {
+		if ctx.Err() != nil {
+			// Lease habis dan job dilanjutkan oleh instance berikutnya
+			return
+		}
+
+		row := rows[i]
+		message := row.Err
+		if message == "" {
+			row.Task.WorkspaceID = job.workspaceID
+			if _, err := r.create(job.userID, row.Task); err != nil {
+				message = err.Error()
+			}
+		}
+
+		if err := r.progress(job, i+1, row.Line, message); err != nil {
+			log.Printf("importer: job %d progress: %v", job.id, err)
+			return
+		}
+		if message != "" {
+			job.errors++
+		}
+	}
+
+	_, err = r.db.Exec(
+		`UPDATE import_jobs SET status = $1, total_rows = $2, finished_at = CURRENT_TIMESTAMP,
+		     lease_until = NULL, data = NULL
+		 WHERE id = $3`,
+		models.ImportCompleted, len(rows), job.id,
+	)
+	if err != nil {
+		log.Printf("importer: job %d finish: %v", job.id, err)
+	}
+}
+
+// progress - catat satu baris selesai (berhasil atau error) dan perpanjang lease
+func (r *Runner) progress(job claimedJob, processed, line int, message string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	failed := 0
+	if message != "" {
+		failed = 1
+		if job.errors < MaxRowErrors {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO import_errors (job_id, row_number, message) VALUES ($1, $2, $3)",
+				job.id, line, message,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE import_jobs
+		 SET processed_rows = $1, imported_count = imported_count + $2, failed_count = failed_count + $3,
+		     lease_until = $4
+		 WHERE id = $5`,
+		processed, 1-failed, failed, time.Now().Add(lease), job.id,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Runner) fail(jobID int, message string) {
+	_, err := r.db.Exec(
+		`UPDATE import_jobs SET status = $1, error = $2, finished_at = CURRENT_TIMESTAMP,
+		     lease_until = NULL, data = NULL
+		 WHERE id = $3`,
+		models.ImportFailed, message, jobID,
+	)
+	if err != nil {
+		log.Printf("importer: job %d fail: %v", jobID, err)
+	}
+}
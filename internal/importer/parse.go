@@ -0,0 +1,560 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"taskflow-api/internal/ical"
+	"taskflow-api/internal/models"
+)
+
+const (
+	// MaxRows - jumlah baris maksimum per file
+	MaxRows = 10000
+	// maxTitle - sama dengan kolom tasks.title
+	maxTitle = 200
+)
+
+// ErrUnknownFormat - format bukan csv, json, todoist, trello atau ics
+var ErrUnknownFormat = errors.New("format must be csv, json, todoist, trello or ics")
+
+// Fields - field task yang bisa dipetakan dari kolom CSV
+var Fields = []string{"title", "description", "priority", "category", "status", "due_date", "completed", "labels"}
+
+// columnAliases - header CSV yang dikenali tanpa mapping (tidak peka huruf besar)
+var columnAliases = map[string][]string{
+	"title":       {"title", "name", "task", "content"},
+	"description": {"description", "desc", "notes"},
+	"priority":    {"priority"},
+	"category":    {"category"},
+	"status":      {"status", "list", "column"},
+	"due_date":    {"due_date", "due", "due date"},
+	"completed":   {"completed", "is_completed", "done"},
+	"labels":      {"labels", "tags"},
+}
+
+// Row - satu task hasil parse. Err diisi kalau barisnya tidak valid.
+type Row struct {
+	Line int
+	Task models.CreateTaskRequest
+	Err  string
+}
+
+// Parse - ubah file jadi baris task. mapping hanya untuk CSV: field -> header kolom.
+// Error berarti seluruh file tidak bisa dibaca; kesalahan per baris ada di Row.Err.
+func Parse(format string, data []byte, mapping map[string]string) ([]Row, error) {
+	var rows []Row
+	var err error
+	switch format {
+	case models.ImportCSV:
+		rows, err = parseCSV(data, mapping)
+	case models.ImportJSON:
+		rows, err = parseJSON(data)
+	case models.ImportTodoist:
+		rows, err = parseTodoist(data)
+	case models.ImportTrello:
+		rows, err = parseTrello(data)
+	case models.ImportICS:
+		rows, err = parseICS(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > MaxRows {
+		return nil, fmt.Errorf("file has %d tasks; at most %d can be imported at once", len(rows), MaxRows)
+	}
+	return rows, nil
+}
+
+// fields - nilai mentah satu task sebelum divalidasi
+type fields struct {
+	title       string
+	description string
+	priority    string
+	category    string
+	status      string
+	dueDate     string
+	completed   string
+	labels      []string
+}
+
+// row - validasi dan normalisasi nilai mentah; kesalahan pertama jadi Row.Err
+func (f fields) row(line int) Row {
+	r := Row{Line: line}
+	fail := func(err error) Row {
+		r.Err = err.Error()
+		return r
+	}
+
+	title := strings.TrimSpace(f.title)
+	if title == "" {
+		return fail(errors.New("title is required"))
+	}
+	if utf8.RuneCountInString(title) > maxTitle {
+		return fail(fmt.Errorf("title must be at most %d characters", maxTitle))
+	}
+	r.Task.Title = title
+
+	priority, err := parsePriority(f.priority)
+	if err != nil {
+		return fail(err)
+	}
+	category, err := parseCategory(f.category)
+	if err != nil {
+		return fail(err)
+	}
+	dueDate, err := parseDate(f.dueDate)
+	if err != nil {
+		return fail(err)
+	}
+	completed, err := parseBool(f.completed)
+	if err != nil {
+		return fail(err)
+	}
+
+	// Label bernama prioritas/kategori mengisi field-nya, sisanya ditulis di deskripsi
+	description := strings.TrimSpace(f.description)
+	var rest []string
+	for _, label := range f.labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if p, err := parsePriority(label); err == nil && p != "" && priority == "" {
+			priority = p
+		} else if c, err := parseCategory(label); err == nil && c != "" && category == "" {
+			category = c
+		} else {
+			rest = append(rest, label)
+		}
+	}
+	if len(rest) > 0 {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Labels: " + strings.Join(rest, ", ")
+	}
+	if description != "" {
+		r.Task.Description = &description
+	}
+
+	r.Task.Priority = priority
+	r.Task.Category = category
+	r.Task.Status = strings.TrimSpace(f.status)
+	r.Task.DueDate = dueDate
+	if completed {
+		r.Task.IsCompleted = &completed
+	}
+	return r
+}
+
+// parseCSV - baris pertama header; kolom dipilih lewat mapping atau nama header
+func parseCSV(data []byte, mapping map[string]string) ([]Row, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	columns, err := csvColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+	value := func(record []string, field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, Row{Line: parseErr.StartLine, Err: parseErr.Err.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		var labels []string
+		if raw := value(record, "labels"); raw != "" {
+			labels = strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ';' })
+		}
+		rows = append(rows, fields{
+			title:       value(record, "title"),
+			description: value(record, "description"),
+			priority:    value(record, "priority"),
+			category:    value(record, "category"),
+			status:      value(record, "status"),
+			dueDate:     value(record, "due_date"),
+			completed:   value(record, "completed"),
+			labels:      labels,
+		}.row(line))
+	}
+	return rows, nil
+}
+
+// csvColumns - index kolom per field. Mapping eksplisit menang atas nama header.
+func csvColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	for field, column := range mapping {
+		if !knownField(field) {
+			return nil, fmt.Errorf("mapping: unknown field %q", field)
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, fmt.Errorf("mapping: column %q not found in header", column)
+		}
+		columns[field] = i
+	}
+	for field, aliases := range columnAliases {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, alias := range aliases {
+			if i, ok := index[alias]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New(`no title column; add a "title" header or map one with {"title": "<column>"}`)
+	}
+	return columns, nil
+}
+
+func knownField(field string) bool {
+	for _, f := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTask - satu task di export JSON TaskFlow; field lain (id, version, ...) diabaikan
+type jsonTask struct {
+	Title       string   `json:"title"`
+	Description *string  `json:"description"`
+	Priority    string   `json:"priority"`
+	Category    string   `json:"category"`
+	Status      string   `json:"status"`
+	IsCompleted bool     `json:"is_completed"`
+	DueDate     *string  `json:"due_date"`
+	Labels      []string `json:"labels"`
+}
+
+// parseJSON - array task, {"tasks": [...]}, atau response API {"data": ...}
+func parseJSON(data []byte) ([]Row, error) {
+	items, err := taskList(data, "tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(items))
+	for i, raw := range items {
+		var t jsonTask
+		if err := json.Unmarshal(raw, &t); err != nil {
+			rows = append(rows, Row{Line: i + 1, Err: "invalid task: " + err.Error()})
+			continue
+		}
+		f := fields{
+			title:     t.Title,
+			priority:  t.Priority,
+			category:  t.Category,
+			status:    t.Status,
+			completed: fmt.Sprint(t.IsCompleted),
+			labels:    t.Labels,
+		}
+		if t.Description != nil {
+			f.description = *t.Description
+		}
+		if t.DueDate != nil {
+			f.dueDate = *t.DueDate
+		}
+		rows = append(rows, f.row(i+1))
+	}
+	return rows, nil
+}
+
+// todoistItem - task dari export Todoist (Sync API "items" atau API "tasks")
+type todoistItem struct {
+	Content     string   `json:"content"`
+	Description string   `json:"description"`
+	Priority    int      `json:"priority"`
+	Labels      []string `json:"labels"`
+	Checked     bool     `json:"checked"`
+	IsCompleted bool     `json:"is_completed"`
+	Due         *struct {
+		Date     string `json:"date"`
+		Datetime string `json:"datetime"`
+	} `json:"due"`
+}
+
+// todoistPriorities - Todoist memakai 4 untuk p1 (tertinggi) dan 1 untuk p4 (tanpa prioritas)
+var todoistPriorities = map[int]string{4: "high", 3: "medium", 2: "low"}
+
+func parseTodoist(data []byte) ([]Row, error) {
+	items, err := taskList(data, "items", "tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(items))
+	for i, raw := range items {
+		var item todoistItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			rows = append(rows, Row{Line: i + 1, Err: "invalid item: " + err.Error()})
+			continue
+		}
+		f := fields{
+			title:       item.Content,
+			description: item.Description,
+			priority:    todoistPriorities[item.Priority],
+			completed:   fmt.Sprint(item.Checked || item.IsCompleted),
+			labels:      item.Labels,
+		}
+		if item.Due != nil {
+			f.dueDate = item.Due.Datetime
+			if f.dueDate == "" {
+				f.dueDate = item.Due.Date
+			}
+		}
+		rows = append(rows, f.row(i+1))
+	}
+	return rows, nil
+}
+
+// trelloBoard - bagian export board Trello yang dipakai
+type trelloBoard struct {
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		Name        string  `json:"name"`
+		Desc        string  `json:"desc"`
+		Due         *string `json:"due"`
+		DueComplete bool    `json:"dueComplete"`
+		Closed      bool    `json:"closed"`
+		IDList      string  `json:"idList"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+}
+
+// parseTrello - kartu jadi task, nama list jadi status. Kartu dan list yang
+// diarsipkan dilewati.
+func parseTrello(data []byte) ([]Row, error) {
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, fmt.Errorf("invalid Trello export: %v", err)
+	}
+	if board.Cards == nil {
+		return nil, errors.New(`invalid Trello export: no "cards"`)
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closed := make(map[string]bool)
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		closed[list.ID] = list.Closed
+	}
+
+	var rows []Row
+	for i, card := range board.Cards {
+		if card.Closed || closed[card.IDList] {
+			continue
+		}
+		f := fields{
+			title:       card.Name,
+			description: card.Desc,
+			status:      lists[card.IDList],
+			completed:   fmt.Sprint(card.DueComplete),
+		}
+		if card.Due != nil {
+			f.dueDate = *card.Due
+		}
+		for _, label := range card.Labels {
+			if label.Name != "" {
+				f.labels = append(f.labels, label.Name)
+			}
+		}
+		rows = append(rows, f.row(i+1))
+	}
+	return rows, nil
+}
+
+// parseICS - satu task per VTODO; Row.Line adalah baris BEGIN:VTODO. Komponen
+// lain (VEVENT, VTIMEZONE, ...) dilewati.
+func parseICS(data []byte) ([]Row, error) {
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iCalendar file: %v", err)
+	}
+	todos := ical.Todos(cal)
+	if len(todos) == 0 {
+		return nil, errors.New("iCalendar file has no VTODO entries")
+	}
+
+	rows := make([]Row, 0, len(todos))
+	for _, c := range todos {
+		todo, err := ical.ParseTodo(c)
+		if err != nil {
+			rows = append(rows, Row{Line: c.Line, Err: err.Error()})
+			continue
+		}
+		f := fields{
+			title:       todo.Summary,
+			description: todo.Description,
+			priority:    string(todo.Priority),
+			completed:   fmt.Sprint(todo.Completed),
+			labels:      todo.Categories,
+		}
+		if todo.Due != nil && todo.AllDay {
+			f.dueDate = todo.Due.Format(dateOnly)
+		} else if todo.Due != nil {
+			f.dueDate = todo.Due.Format(time.RFC3339)
+		}
+		// Task belum bisa berulang; aturannya disimpan di deskripsi supaya tidak hilang
+		if todo.RRule != "" {
+			if f.description != "" {
+				f.description += "\n\n"
+			}
+			f.description += "Repeats: " + todo.RRule
+		}
+		rows = append(rows, f.row(c.Line))
+	}
+	return rows, nil
+}
+
+// taskList - array item dari dokumen JSON: array langsung, object dengan salah
+// satu key, atau response API TaskFlow ({"data": ...})
+func taskList(data []byte, keys ...string) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	var list []json.RawMessage
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return list, nil
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	for _, key := range keys {
+		if raw, ok := doc[key]; ok {
+			if err := json.Unmarshal(raw, &list); err != nil {
+				return nil, fmt.Errorf("invalid JSON: %q must be an array", key)
+			}
+			return list, nil
+		}
+	}
+	if raw, ok := doc["data"]; ok {
+		return taskList(raw, keys...)
+	}
+	return nil, fmt.Errorf("invalid JSON: expected an array or a %q key", keys[0])
+}
+
+func parsePriority(s string) (models.Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none", "p4":
+		return "", nil
+	case "high", "h", "p1":
+		return models.PriorityHigh, nil
+	case "medium", "m", "normal", "p2":
+		return models.PriorityMedium, nil
+	case "low", "l", "p3":
+		return models.PriorityLow, nil
+	}
+	return "", fmt.Errorf("unknown priority %q", s)
+}
+
+func parseCategory(s string) (models.Category, error) {
+	switch c := models.Category(strings.ToLower(strings.TrimSpace(s))); c {
+	case "":
+		return "", nil
+	case models.CategoryPersonal, models.CategoryWork, models.CategoryUrgent:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown category %q", s)
+}
+
+// dateLayouts - format due date yang diterima; jam tanpa zona waktu dianggap UTC
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// dateOnly - due date tanpa jam; sama seperti quick-add di CLI, jatuh tempo
+// 23:59 waktu lokal server
+const dateOnly = "2006-01-02"
+
+func parseDate(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if d, err := time.Parse(dateOnly, s); err == nil {
+		t := time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 0, 0, time.Local)
+		return &t, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid due date %q; use YYYY-MM-DD or RFC 3339", s)
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "false", "no", "n", "0", "open":
+		return false, nil
+	case "true", "yes", "y", "1", "x", "done", "completed":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid completed value %q", s)
+}
@@ -0,0 +1,256 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+// endOfDay - jatuh tempo untuk tanggal tanpa jam
+func endOfDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 23, 59, 0, 0, time.Local)
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-05-01", endOfDay(2024, time.May, 1)},
+		{" 2024-05-01 ", endOfDay(2024, time.May, 1)},
+		{"2024-05-01T09:30:00Z", time.Date(2024, time.May, 1, 9, 30, 0, 0, time.UTC)},
+		{"2024-05-01T09:30:00+07:00", time.Date(2024, time.May, 1, 2, 30, 0, 0, time.UTC)},
+		{"2024-05-01T09:30:00", time.Date(2024, time.May, 1, 9, 30, 0, 0, time.UTC)},
+		{"2024-05-01 09:30", time.Date(2024, time.May, 1, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseDate(tt.in)
+		if err != nil {
+			t.Errorf("parseDate(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if got, err := parseDate(""); got != nil || err != nil {
+		t.Errorf("parseDate(\"\") = %v, %v; want nil", got, err)
+	}
+	if _, err := parseDate("01/05/2024"); err == nil {
+		t.Error("parseDate accepted 01/05/2024")
+	}
+}
+
+// dueOf - due date satu baris, atau zero time kalau kosong
+func dueOf(r Row) time.Time {
+	if r.Task.DueDate == nil {
+		return time.Time{}
+	}
+	return *r.Task.DueDate
+}
+
+func TestParseCSV(t *testing.T) {
+	data := "Name,Notes,Priority,Due,Done,Tags\n" +
+		"Write report,Q2 numbers,high,2024-05-01,no,\"work, finance\"\n" +
+		",missing title,,,,\n" +
+		"Bad priority,,urgent!,,,\n" +
+		"Bad date,,,tomorrow,,\n" +
+		"Ship it,,,2024-05-02T10:00:00Z,yes,p2\n"
+
+	rows, err := Parse(models.ImportCSV, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(rows))
+	}
+
+	first := rows[0]
+	if first.Line != 2 || first.Err != "" || first.Task.Title != "Write report" || first.Task.Priority != models.PriorityHigh ||
+		first.Task.Category != models.CategoryWork || !dueOf(first).Equal(endOfDay(2024, time.May, 1)) {
+		t.Errorf("row 1 = %+v", first)
+	}
+	if first.Task.Description == nil || !strings.Contains(*first.Task.Description, "Q2 numbers") || !strings.Contains(*first.Task.Description, "finance") {
+		t.Errorf("row 1 description = %v, want notes and the finance label", first.Task.Description)
+	}
+
+	errs := []string{"title is required", `unknown priority "urgent!"`, `invalid due date "tomorrow"`}
+	for i, want := range errs {
+		if r := rows[i+1]; r.Line != i+3 || !strings.HasPrefix(r.Err, want) {
+			t.Errorf("row %d = line %d %q, want line %d %q", i+2, r.Line, r.Err, i+3, want)
+		}
+	}
+
+	last := rows[4]
+	if last.Err != "" || last.Task.Priority != models.PriorityMedium || !dueOf(last).Equal(time.Date(2024, time.May, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("row 5 = %+v", last)
+	}
+	if last.Task.IsCompleted == nil || !*last.Task.IsCompleted {
+		t.Errorf("row 5 completed = %v, want true", last.Task.IsCompleted)
+	}
+}
+
+func TestParseCSVMapping(t *testing.T) {
+	data := "Task Name,Deadline\nCall bank,2024-06-10\n"
+	rows, err := Parse(models.ImportCSV, []byte(data), map[string]string{"title": "Task Name", "due_date": "Deadline"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Task.Title != "Call bank" || !dueOf(rows[0]).Equal(endOfDay(2024, time.June, 10)) {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	data := `{"data": [
+		{"title": "Exported", "status": "in_progress", "due_date": "2024-05-01T12:00:00Z", "is_completed": false},
+		{"title": "Date only", "due_date": "2024-05-03", "is_completed": true},
+		{"title": 42}
+	]}`
+	rows, err := Parse(models.ImportJSON, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	if r := rows[0]; r.Err != "" || r.Task.Status != "in_progress" || !dueOf(r).Equal(time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("row 1 = %+v", r)
+	}
+	if r := rows[1]; r.Err != "" || !dueOf(r).Equal(endOfDay(2024, time.May, 3)) || r.Task.IsCompleted == nil || !*r.Task.IsCompleted {
+		t.Errorf("row 2 = %+v", r)
+	}
+	if r := rows[2]; r.Line != 3 || r.Err == "" {
+		t.Errorf("row 3 = %+v, want an error", r)
+	}
+}
+
+func TestParseTodoist(t *testing.T) {
+	data := `{"items": [
+		{"content": "Pay rent", "priority": 4, "labels": ["personal", "home"], "due": {"date": "2024-05-01"}},
+		{"content": "Standup", "priority": 1, "checked": true, "due": {"date": "2024-05-02", "datetime": "2024-05-02T09:00:00Z"}},
+		{"content": "Review", "priority": 3}
+	]}`
+	rows, err := Parse(models.ImportTodoist, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	rent := rows[0]
+	if rent.Task.Priority != models.PriorityHigh || rent.Task.Category != models.CategoryPersonal || !dueOf(rent).Equal(endOfDay(2024, time.May, 1)) {
+		t.Errorf("rent = %+v", rent)
+	}
+	if rent.Task.Description == nil || !strings.Contains(*rent.Task.Description, "home") {
+		t.Errorf("rent description = %v, want the home label", rent.Task.Description)
+	}
+	standup := rows[1]
+	if standup.Task.Priority != "" || !dueOf(standup).Equal(time.Date(2024, time.May, 2, 9, 0, 0, 0, time.UTC)) ||
+		standup.Task.IsCompleted == nil || !*standup.Task.IsCompleted {
+		t.Errorf("standup = %+v", standup)
+	}
+	if rows[2].Task.Priority != models.PriorityMedium || rows[2].Task.DueDate != nil {
+		t.Errorf("review = %+v", rows[2])
+	}
+}
+
+func TestParseTrello(t *testing.T) {
+	data := `{
+		"lists": [{"id": "l1", "name": "Doing"}, {"id": "l2", "name": "Old", "closed": true}],
+		"cards": [
+			{"name": "Design", "idList": "l1", "due": "2024-05-01T17:00:00.000Z", "dueComplete": true, "labels": [{"name": "high"}, {"name": "", "color": "red"}]},
+			{"name": "Archived", "idList": "l1", "closed": true},
+			{"name": "In closed list", "idList": "l2"},
+			{"name": "No due", "idList": "l1"}
+		]
+	}`
+	rows, err := Parse(models.ImportTrello, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 open cards: %+v", len(rows), rows)
+	}
+	design := rows[0]
+	if design.Line != 1 || design.Task.Status != "Doing" || design.Task.Priority != models.PriorityHigh ||
+		!dueOf(design).Equal(time.Date(2024, time.May, 1, 17, 0, 0, 0, time.UTC)) || design.Task.IsCompleted == nil || !*design.Task.IsCompleted {
+		t.Errorf("design = %+v", design)
+	}
+	if rows[1].Line != 4 || rows[1].Task.Title != "No due" {
+		t.Errorf("row 2 = %+v", rows[1])
+	}
+
+	if _, err := Parse(models.ImportTrello, []byte(`{"lists": []}`), nil); err == nil {
+		t.Error("Trello export without cards accepted")
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Meeting",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"SUMMARY:All day",
+		"DUE;VALUE=DATE:20240501",
+		"PRIORITY:1",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"SUMMARY:Timed",
+		"DUE;TZID=Asia/Jakarta:20240501T090000",
+		"STATUS:COMPLETED",
+		"RRULE:FREQ=WEEKLY",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"SUMMARY:Broken",
+		"PRIORITY:12",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+	rows, err := Parse(models.ImportICS, []byte(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	allDay := rows[0]
+	if allDay.Line != 6 || allDay.Task.Priority != models.PriorityHigh || !dueOf(allDay).Equal(endOfDay(2024, time.May, 1)) {
+		t.Errorf("all day = %+v", allDay)
+	}
+	timed := rows[1]
+	if !dueOf(timed).Equal(time.Date(2024, time.May, 1, 2, 0, 0, 0, time.UTC)) || timed.Task.IsCompleted == nil || !*timed.Task.IsCompleted {
+		t.Errorf("timed = %+v", timed)
+	}
+	if timed.Task.Description == nil || *timed.Task.Description != "Repeats: FREQ=WEEKLY" {
+		t.Errorf("timed description = %v", timed.Task.Description)
+	}
+	if rows[2].Err == "" {
+		t.Errorf("broken VTODO = %+v, want an error", rows[2])
+	}
+
+	if _, err := Parse(models.ImportICS, []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil); err == nil {
+		t.Error("calendar without VTODO accepted")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse("xml", nil, nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("unknown format = %v", err)
+	}
+	if _, err := Parse(models.ImportJSON, []byte(`{"items": []}`), nil); err == nil {
+		t.Error("JSON without tasks accepted")
+	}
+	if _, err := Parse(models.ImportTodoist, []byte(`not json`), nil); err == nil {
+		t.Error("invalid JSON accepted")
+	}
+}
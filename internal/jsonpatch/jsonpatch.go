@@ -0,0 +1,319 @@
+// Package jsonpatch menerapkan JSON Merge Patch (RFC 7396) dan JSON Patch
+// (RFC 6902) pada dokumen JSON.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalid - patch tidak valid atau tidak bisa diterapkan pada dokumen
+	ErrInvalid = errors.New("invalid patch")
+	// ErrTestFailed - operasi "test" tidak cocok dengan dokumen
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// Merge - terapkan merge patch (RFC 7396): null menghapus member, object
+// digabung secara rekursif, nilai lain mengganti.
+func Merge(doc, patch []byte) ([]byte, error) {
+	var target, p interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = merge(t[key], value)
+		}
+	}
+	return t
+}
+
+// Operation - satu operasi JSON Patch. Value nil berarti member "value" tidak
+// ada; "value": null tetap terisi (literal null).
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// UnmarshalJSON - decode per member supaya "value": null bisa dibedakan dari
+// member yang tidak ada
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	if members == nil {
+		return errors.New("operation must be an object")
+	}
+	for key, dst := range map[string]*string{"op": &o.Op, "path": &o.Path, "from": &o.From} {
+		if raw, ok := members[key]; ok {
+			if err := json.Unmarshal(raw, dst); err != nil {
+				return fmt.Errorf("%q must be a string", key)
+			}
+		}
+	}
+	o.Value = members["value"]
+	return nil
+}
+
+// Apply - terapkan JSON Patch (RFC 6902) secara berurutan; gagal satu, gagal semua
+func Apply(doc, patch []byte) ([]byte, error) {
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: patch must be an array of operations", ErrInvalid)
+	}
+
+	var root interface{}
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		var err error
+		root, err = apply(root, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func apply(root interface{}, op Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: value is required", ErrInvalid)
+		}
+		var value interface{}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(root, path, value)
+		case "replace":
+			if root, _, err = remove(root, path); err != nil {
+				return nil, err
+			}
+			return add(root, path, value)
+		default:
+			current, err := get(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return root, nil
+		}
+
+	case "remove":
+		root, _, err = remove(root, path)
+		return root, err
+
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if op.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalid)
+			}
+			root, value, err = remove(root, from)
+		} else {
+			value, err = get(root, from)
+			value = clone(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalid, op.Op)
+}
+
+// parsePointer - JSON Pointer (RFC 6901) jadi daftar token
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			value, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalid)
+			}
+			node = value
+		case []interface{}:
+			i, err := index(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalid)
+		}
+	}
+	return node, nil
+}
+
+// add - tambah atau ganti nilai di path, kembalikan root yang (mungkin) baru
+func add(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+		return root, nil
+	case []interface{}:
+		i, err := index(last, len(p), true)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, nil)
+		copy(p[i+1:], p[i:])
+		p[i] = value
+		return replaceParent(root, path[:len(path)-1], p)
+	}
+	return nil, fmt.Errorf("%w: parent is not a container", ErrInvalid)
+}
+
+// remove - hapus nilai di path, kembalikan root baru dan nilai yang dihapus
+func remove(root interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, root, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		value, ok := p[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: path not found", ErrInvalid)
+		}
+		delete(p, last)
+		return root, value, nil
+	case []interface{}:
+		i, err := index(last, len(p), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := p[i]
+		p = append(p[:i:i], p[i+1:]...)
+		root, err = replaceParent(root, path[:len(path)-1], p)
+		return root, value, err
+	}
+	return nil, nil, fmt.Errorf("%w: path not found", ErrInvalid)
+}
+
+// replaceParent - slice hasil append/hapus harus ditulis ulang ke induknya
+func replaceParent(root interface{}, path []string, value []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	grandparent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch g := grandparent.(type) {
+	case map[string]interface{}:
+		g[last] = value
+	case []interface{}:
+		i, err := index(last, len(g), false)
+		if err != nil {
+			return nil, err
+		}
+		g[i] = value
+	}
+	return root, nil
+}
+
+// index - token array; "-" (akhir array) hanya boleh untuk add
+func index(token string, length int, forAdd bool) (int, error) {
+	if forAdd && token == "-" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalid, token)
+	}
+	if i > length || (!forAdd && i == length) {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrInvalid, i)
+	}
+	return i, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func clone(value interface{}) interface{} {
+	raw, _ := json.Marshal(value)
+	var out interface{}
+	json.Unmarshal(raw, &out)
+	return out
+}
@@ -0,0 +1,167 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const taskDoc = `{"title":"Ship it","description":"Before Friday","tags":["a","b"],"meta":{"x":1}}`
+
+// equalJSON - bandingkan dua dokumen JSON tanpa peduli urutan member
+func equalJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("result is not JSON: %v (%s)", err, got)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("bad expectation %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "replace with null",
+			patch: `[{"op":"replace","path":"/description","value":null}]`,
+			want:  `{"title":"Ship it","description":null,"tags":["a","b"],"meta":{"x":1}}`,
+		},
+		{
+			name:  "add with null",
+			patch: `[{"op":"add","path":"/due_date","value":null}]`,
+			want:  `{"title":"Ship it","description":"Before Friday","tags":["a","b"],"meta":{"x":1},"due_date":null}`,
+		},
+		{
+			name:  "add null to array",
+			patch: `[{"op":"add","path":"/tags/1","value":null}]`,
+			want:  `{"title":"Ship it","description":"Before Friday","tags":["a",null,"b"],"meta":{"x":1}}`,
+		},
+		{
+			name:  "test null then remove",
+			patch: `[{"op":"add","path":"/meta/y","value":null},{"op":"test","path":"/meta/y","value":null},{"op":"remove","path":"/meta/y"}]`,
+			want:  taskDoc,
+		},
+		{
+			name:  "replace",
+			patch: `[{"op":"replace","path":"/title","value":"Ship it now"}]`,
+			want:  `{"title":"Ship it now","description":"Before Friday","tags":["a","b"],"meta":{"x":1}}`,
+		},
+		{
+			name:  "append to array",
+			patch: `[{"op":"add","path":"/tags/-","value":"c"}]`,
+			want:  `{"title":"Ship it","description":"Before Friday","tags":["a","b","c"],"meta":{"x":1}}`,
+		},
+		{
+			name:  "move and copy",
+			patch: `[{"op":"move","from":"/meta/x","path":"/x"},{"op":"copy","from":"/tags","path":"/labels"}]`,
+			want:  `{"title":"Ship it","description":"Before Friday","tags":["a","b"],"labels":["a","b"],"meta":{},"x":1}`,
+		},
+		{
+			name:  "escaped pointer",
+			patch: `[{"op":"add","path":"/meta/a~1b~0c","value":true}]`,
+			want:  `{"title":"Ship it","description":"Before Friday","tags":["a","b"],"meta":{"x":1,"a/b~c":true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(taskDoc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			equalJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  error
+	}{
+		{"value missing", `[{"op":"replace","path":"/description"}]`, ErrInvalid},
+		{"add value missing", `[{"op":"add","path":"/due_date"}]`, ErrInvalid},
+		{"not an array", `{"op":"remove","path":"/title"}`, ErrInvalid},
+		{"unknown op", `[{"op":"merge","path":"/title","value":1}]`, ErrInvalid},
+		{"path without slash", `[{"op":"remove","path":"title"}]`, ErrInvalid},
+		{"remove missing member", `[{"op":"remove","path":"/nope"}]`, ErrInvalid},
+		{"array index out of range", `[{"op":"replace","path":"/tags/2","value":"c"}]`, ErrInvalid},
+		{"leading zero index", `[{"op":"remove","path":"/tags/01"}]`, ErrInvalid},
+		{"move into itself", `[{"op":"move","from":"/meta","path":"/meta/inner"}]`, ErrInvalid},
+		{"test mismatch", `[{"op":"test","path":"/title","value":"Other"}]`, ErrTestFailed},
+		{"test null against value", `[{"op":"test","path":"/description","value":null}]`, ErrTestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply([]byte(taskDoc), []byte(tt.patch))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Apply error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyIsAtomic(t *testing.T) {
+	doc := []byte(taskDoc)
+	_, err := Apply(doc, []byte(`[{"op":"replace","path":"/title","value":"Changed"},{"op":"remove","path":"/nope"}]`))
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Apply error = %v, want ErrInvalid", err)
+	}
+	equalJSON(t, doc, taskDoc)
+}
+
+func TestOperationUnmarshal(t *testing.T) {
+	var ops []Operation
+	err := json.Unmarshal([]byte(`[{"op":"add","path":"/a","value":null},{"op":"remove","path":"/a"}]`), &ops)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if string(ops[0].Value) != "null" {
+		t.Errorf("explicit null value = %q, want %q", ops[0].Value, "null")
+	}
+	if ops[1].Value != nil {
+		t.Errorf("missing value = %q, want nil", ops[1].Value)
+	}
+
+	if err := json.Unmarshal([]byte(`[{"op":1,"path":"/a"}]`), &ops); err == nil {
+		t.Error("numeric op was accepted")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"null removes member", `{"description":null}`, `{"title":"Ship it","tags":["a","b"],"meta":{"x":1}}`},
+		{"nested object merges", `{"meta":{"y":2,"x":null}}`, `{"title":"Ship it","description":"Before Friday","tags":["a","b"],"meta":{"y":2}}`},
+		{"array replaces", `{"tags":["c"]}`, `{"title":"Ship it","description":"Before Friday","tags":["c"],"meta":{"x":1}}`},
+		{"empty patch", `{}`, taskDoc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge([]byte(taskDoc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			equalJSON(t, got, tt.want)
+		})
+	}
+
+	if _, err := Merge([]byte(taskDoc), []byte(`{`)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("invalid patch error = %v, want ErrInvalid", err)
+	}
+}
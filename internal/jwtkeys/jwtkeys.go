@@ -0,0 +1,222 @@
+// Package jwtkeys mengelola kunci HMAC untuk JWT. Token baru ditandatangani
+// dengan kunci aktif terbaru di tabel jwt_keys dan kid-nya ditulis di header.
+// Selama belum pernah dirotasi dipakai JWT_SECRET tanpa kid, jadi instalasi
+// lama tetap jalan tanpa setup tambahan.
+package jwtkeys
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// RefreshInterval - seberapa sering kunci dibaca ulang, supaya rotasi dari
+	// instance lain atau dari taskflow-admin ikut terpakai
+	RefreshInterval = 30 * time.Second
+	// minReload - kid yang belum dikenal memicu baca ulang paling sering sekali per ini
+	minReload = time.Second
+)
+
+// Key - satu kunci penandatangan. ID kosong berarti JWT_SECRET.
+type Key struct {
+	ID        string
+	Secret    string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	Expired   bool
+}
+
+// Keyring - kunci dari database, di-cache selama RefreshInterval
+type Keyring struct {
+	db       *sql.DB
+	dialect  database.Dialect
+	fallback string
+
+	mu       sync.Mutex
+	keys     map[string]Key
+	signing  Key
+	loadedAt time.Time
+}
+
+func New(db *sql.DB, fallbackSecret string) *Keyring {
+	return &Keyring{db: db, dialect: database.DialectOf(db), fallback: fallbackSecret, signing: Key{Secret: fallbackSecret}}
+}
+
+// Sign - tandatangani claims dengan kunci aktif
+func (k *Keyring) Sign(claims jwt.MapClaims) (string, error) {
+	key := k.current()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString([]byte(key.Secret))
+}
+
+// Parse - parse dan verifikasi token dengan kunci sesuai kid-nya
+func (k *Keyring) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.lookup(kid)
+		if !ok || key.Expired {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return []byte(key.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+}
+
+// Rotate - buat kunci baru untuk token berikutnya. Kunci lama (termasuk
+// JWT_SECRET) masih menerima token selama grace; grace 0 langsung membatalkan
+// semua token yang sudah terbit.
+func (k *Keyring) Rotate(ctx context.Context, grace time.Duration) (Key, error) {
+	kid, err := utils.GenerateToken(8)
+	if err != nil {
+		return Key{}, err
+	}
+	secret, err := utils.GenerateToken(32)
+	if err != nil {
+		return Key{}, err
+	}
+	seconds := int(grace / time.Second)
+	// Jam database, sama dengan yang dipakai load
+	expiresAt := k.dialect.Pick(
+		"CURRENT_TIMESTAMP + $1::integer * INTERVAL '1 second'",
+		"strftime('%Y-%m-%d %H:%M:%f', 'now', $1 || ' seconds')",
+	)
+
+	tx, err := k.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Key{}, err
+	}
+	defer tx.Rollback()
+
+	// Kunci yang sudah dijadwalkan kadaluarsa lebih cepat tidak diperpanjang
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE jwt_keys SET expires_at = "+expiresAt+" WHERE expires_at IS NULL OR expires_at > "+expiresAt,
+		seconds,
+	); err != nil {
+		return Key{}, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO jwt_keys (kid, secret, expires_at)
+		 SELECT '', '', `+expiresAt+`
+		 WHERE NOT EXISTS (SELECT 1 FROM jwt_keys WHERE kid = '')`,
+		seconds,
+	); err != nil {
+		return Key{}, err
+	}
+
+	key := Key{ID: kid, Secret: secret}
+	if err := tx.QueryRowContext(ctx,
+		"INSERT INTO jwt_keys (kid, secret) VALUES ($1, $2) RETURNING created_at",
+		kid, secret,
+	).Scan(&key.CreatedAt); err != nil {
+		return Key{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Key{}, err
+	}
+
+	k.mu.Lock()
+	k.loadedAt = time.Time{}
+	k.mu.Unlock()
+	return key, nil
+}
+
+// Keys - semua kunci di database, yang terbaru dulu; Secret dikosongkan
+func (k *Keyring) Keys(ctx context.Context) ([]Key, error) {
+	keys, err := k.query(ctx)
+	for i := range keys {
+		keys[i].Secret = ""
+	}
+	return keys, err
+}
+
+func (k *Keyring) current() Key {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.refresh(RefreshInterval)
+	return k.signing
+}
+
+func (k *Keyring) lookup(kid string) (Key, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.refresh(RefreshInterval)
+
+	key, ok := k.keys[kid]
+	if !ok && kid != "" {
+		// Mungkin baru dirotasi oleh instance lain
+		k.refresh(minReload)
+		key, ok = k.keys[kid]
+	}
+	if !ok && kid == "" {
+		// Belum pernah dirotasi: JWT_SECRET masih berlaku penuh
+		return Key{Secret: k.fallback}, true
+	}
+	return key, ok
+}
+
+// refresh - baca ulang kunci kalau cache lebih tua dari maxAge. Kalau gagal
+// (mis. migrasi belum dijalankan) cache lama tetap dipakai. Dipanggil dengan mu.
+func (k *Keyring) refresh(maxAge time.Duration) {
+	if time.Since(k.loadedAt) < maxAge {
+		return
+	}
+	k.loadedAt = time.Now()
+
+	keys, err := k.query(context.Background())
+	if err != nil {
+		log.Printf("jwtkeys: failed to load keys: %v", err)
+		return
+	}
+
+	// Rotate memberi expires_at ke semua kunci lama, jadi paling banyak satu
+	// kunci yang masih aktif
+	k.keys = make(map[string]Key, len(keys))
+	k.signing = Key{Secret: k.fallback}
+	for _, key := range keys {
+		if key.ID == "" {
+			key.Secret = k.fallback
+		} else if key.ExpiresAt == nil {
+			k.signing = key
+		}
+		k.keys[key.ID] = key
+	}
+}
+
+func (k *Keyring) query(ctx context.Context) ([]Key, error) {
+	if k.db == nil {
+		// Tanpa database (test): hanya JWT_SECRET
+		return nil, nil
+	}
+	rows, err := k.db.QueryContext(ctx,
+		`SELECT kid, secret, created_at, expires_at,
+		        COALESCE(expires_at <= CURRENT_TIMESTAMP, FALSE)
+		 FROM jwt_keys ORDER BY created_at DESC, kid DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []Key
+	for rows.Next() {
+		var key Key
+		if err := rows.Scan(&key.ID, &key.Secret, &key.CreatedAt, &key.ExpiresAt, &key.Expired); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
@@ -2,16 +2,19 @@ package middleware
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
+	"taskflow-api/internal/jwtkeys"
 	"taskflow-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(jwtSecret string, db *sql.DB) gin.HandlerFunc {
+func AuthMiddleware(keys *jwtkeys.Keyring, db *sql.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Ambil Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -29,42 +32,131 @@ func AuthMiddleware(jwtSecret string, db *sql.DB) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
+		authenticate(c, parts[1], keys, db)
+	}
+}
 
-		// Parse token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
+// StreamAuthMiddleware - seperti AuthMiddleware, tapi juga menerima ?ticket=
+// dari POST /auth/stream-ticket, karena EventSource dan WebSocket di browser
+// tidak bisa mengirim header Authorization. JWT sengaja tidak diterima di query
+// string: URL ikut tercatat di log server dan proxy.
+func StreamAuthMiddleware(keys *jwtkeys.Keyring, db *sql.DB) gin.HandlerFunc {
+	header := AuthMiddleware(keys, db)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if ticket := c.Query("ticket"); ticket != "" {
+				userID, err := redeemStreamTicket(db, ticket)
+				if err != nil {
+					utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+					c.Abort()
+					return
+				}
+				c.Set("user_id", userID)
+				c.Next()
+				return
 			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
-			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid or expired token")
-			c.Abort()
-			return
 		}
+		header(c)
+	}
+}
+
+// redeemStreamTicket - ticket dihapus saat dipakai, jadi hanya berlaku untuk
+// satu koneksi; pesan error aman ditampilkan ke client
+func redeemStreamTicket(db *sql.DB, ticket string) (int, error) {
+	var userID int
+	var expiresAt time.Time
+	err := db.QueryRow(
+		"DELETE FROM stream_tickets WHERE token_hash = $1 RETURNING user_id, expires_at",
+		utils.HashToken(ticket),
+	).Scan(&userID, &expiresAt)
+	if err != nil || expiresAt.Before(time.Now()) {
+		return 0, errors.New("Invalid or expired ticket")
+	}
+	if err := activeUser(db, userID); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// authenticate - validasi JWT lalu set user_id di context
+func authenticate(c *gin.Context, tokenString string, keys *jwtkeys.Keyring, db *sql.DB) {
+	userID, err := VerifyToken(tokenString, keys, db)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		c.Abort()
+		return
+	}
+
+	// Set user ID in context
+	c.Set("user_id", userID)
+	c.Next()
+}
+
+// VerifyToken - user ID dari JWT yang valid dan usernya masih ada dan aktif;
+// pesan error aman ditampilkan ke client
+func VerifyToken(tokenString string, keys *jwtkeys.Keyring, db *sql.DB) (int, error) {
+	// Parse token
+	token, err := keys.Parse(tokenString)
 
-		// Get claims
-		claims, ok := token.Claims.(jwt.MapClaims)
+	if err != nil || !token.Valid {
+		return 0, errors.New("Invalid or expired token")
+	}
+
+	// Get claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, errors.New("Invalid token claims")
+	}
+
+	id, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, errors.New("Invalid token claims")
+	}
+	userID := int(id)
+
+	if err := activeUser(db, userID); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// activeUser - user masih ada dan tidak dinonaktifkan
+func activeUser(db *sql.DB, userID int) error {
+	var disabled bool
+	err := db.QueryRow("SELECT disabled_at IS NOT NULL FROM users WHERE id = $1", userID).Scan(&disabled)
+	if err != nil {
+		return errors.New("User not found")
+	}
+	if disabled {
+		return errors.New("Account is disabled")
+	}
+	return nil
+}
+
+// BasicAuthMiddleware - HTTP Basic dengan email dan app password, untuk client
+// yang tidak bisa memakai Bearer token (CalDAV)
+func BasicAuthMiddleware(db *sql.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email, password, ok := c.Request.BasicAuth()
 		if !ok {
-			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid token claims")
-			c.Abort()
+			c.Header("WWW-Authenticate", `Basic realm="TaskFlow", charset="UTF-8"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		userID := int(claims["user_id"].(float64))
-
-		// Verify user exists
-		var exists bool
-		err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", userID).Scan(&exists)
-		if err != nil || !exists {
-			utils.ErrorResponse(c, http.StatusUnauthorized, "User not found")
-			c.Abort()
+		var userID, passwordID int
+		err := db.QueryRow(
+			`SELECT u.id, p.id FROM app_passwords p JOIN users u ON u.id = p.user_id
+			 WHERE lower(u.email) = lower($1) AND p.password_hash = $2 AND u.disabled_at IS NULL`,
+			email, utils.HashToken(password),
+		).Scan(&userID, &passwordID)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Basic realm="TaskFlow", charset="UTF-8"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
+		db.Exec("UPDATE app_passwords SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1", passwordID)
 
-		// Set user ID in context
 		c.Set("user_id", userID)
 		c.Next()
 	}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"taskflow-api/internal/jwtkeys"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type grpcUserKey struct{}
+
+// GRPCUserID - user yang login, diisi oleh interceptor auth
+func GRPCUserID(ctx context.Context) int {
+	userID, _ := ctx.Value(grpcUserKey{}).(int)
+	return userID
+}
+
+// GRPCAuthInterceptors - padanan AuthMiddleware untuk gRPC: metadata
+// "authorization: Bearer <token>" wajib, kecuali untuk method di public
+func GRPCAuthInterceptors(keys *jwtkeys.Keyring, db *sql.DB, public ...string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	skip := map[string]bool{}
+	for _, method := range public {
+		skip[method] = true
+	}
+
+	authenticate := func(ctx context.Context, method string) (context.Context, error) {
+		if skip[method] {
+			return ctx, nil
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "Authorization header required")
+		}
+
+		// Format: Bearer <token>
+		parts := strings.Split(values[0], " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return nil, status.Error(codes.Unauthenticated, "Invalid authorization format")
+		}
+
+		userID, err := VerifyToken(parts[1], keys, db)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return context.WithValue(ctx, grpcUserKey{}, userID), nil
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+
+	return unary, stream
+}
+
+// authStream - ServerStream dengan context yang sudah berisi user
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// secretParams - query parameter yang tidak boleh masuk log
+var secretParams = []string{"access_token", "ticket"}
+
+// Logger - gin.Logger dengan format yang sama, tapi nilai secretParams di URL
+// diganti REDACTED sebelum ditulis
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Formatter: func(param gin.LogFormatterParams) string {
+			var statusColor, methodColor, resetColor string
+			if param.IsOutputColor() {
+				statusColor = param.StatusCodeColor()
+				methodColor = param.MethodColor()
+				resetColor = param.ResetColor()
+			}
+			if param.Latency > time.Minute {
+				param.Latency = param.Latency.Truncate(time.Second)
+			}
+			return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+				param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+				statusColor, param.StatusCode, resetColor,
+				param.Latency,
+				param.ClientIP,
+				methodColor, param.Method, resetColor,
+				redactPath(param.Path),
+				param.ErrorMessage,
+			)
+		},
+	})
+}
+
+// redactPath - path dengan query string dari LogFormatterParams
+func redactPath(path string) string {
+	base, rawQuery, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Query yang tidak bisa di-parse tidak dicatat sama sekali
+		return base + "?REDACTED"
+	}
+	redacted := false
+	for _, key := range secretParams {
+		if _, found := query[key]; found {
+			query.Set(key, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	return base + "?" + query.Encode()
+}
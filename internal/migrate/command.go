@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+)
+
+const usage = `Usage: %s migrate <command> [flags]
+
+Commands:
+  up [-to VERSION]        apply pending migrations (all, or up to VERSION)
+  down [-steps N]         roll back the last N migrations (default 1)
+  status                  list migrations and whether they are applied
+  create [-dir DIR] NAME  write empty up/down files for Postgres and SQLite
+`
+
+// Command - subcommand "migrate" untuk cmd/api dan cmd/taskflow-admin. connect
+// baru dipanggil kalau command butuh database ("create" tidak). Keluar dari
+// proses kalau gagal.
+func Command(program string, args []string, connect func() *sql.DB) {
+	printUsage := func() { fmt.Fprintf(os.Stderr, usage, program) }
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	flags.Usage = printUsage
+	to := flags.Int("to", 0, "target version")
+	steps := flags.Int("steps", 1, "number of migrations to roll back")
+	dir := flags.String("dir", "migrations", "migrations directory")
+	flags.Parse(args[1:])
+
+	if args[0] == "create" {
+		if flags.NArg() != 1 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		files, err := Create(*dir, flags.Arg(0))
+		if err != nil {
+			log.Fatal("Failed to create migration: ", err)
+		}
+		for _, file := range files {
+			fmt.Println("Created", file)
+		}
+		return
+	}
+
+	db := connect()
+	defer db.Close()
+
+	migrator, err := New(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations: ", err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx, *to)
+		printMigrations("Applied", applied)
+		if err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database is up to date")
+		}
+	case "down":
+		if *steps < 1 {
+			log.Fatal("-steps must be at least 1")
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		printMigrations("Rolled back", reverted)
+		if err != nil {
+			log.Fatal("Rollback failed: ", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("Nothing to roll back")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal("Failed to read migration status: ", err)
+		}
+		printStatus(statuses)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+}
+
+func printMigrations(verb string, list []Migration) {
+	for _, m := range list {
+		fmt.Printf("%s %03d_%s\n", verb, m.Version, m.Name)
+	}
+}
+
+func printStatus(statuses []Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+	for _, s := range statuses {
+		appliedAt, note := "pending", ""
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case s.Missing:
+			note = "no migration file"
+		case s.Modified:
+			note = "checksum mismatch"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, appliedAt, note)
+	}
+	w.Flush()
+}
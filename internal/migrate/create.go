@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create - tulis file up dan down kosong untuk versi berikutnya, di dir untuk
+// Postgres dan di dir/sqlite untuk SQLite. Nomor versi sama di kedua dialek.
+// Mengembalikan path file yang dibuat.
+func Create(dir, name string) ([]string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name must contain letters or digits")
+	}
+
+	dirs := []string{dir, filepath.Join(dir, "sqlite")}
+	version := 0
+	for _, d := range dirs {
+		list, err := Load(os.DirFS(d), ".")
+		if err != nil {
+			return nil, err
+		}
+		if n := len(list); n > 0 && list[n-1].Version > version {
+			version = list[n-1].Version
+		}
+	}
+	version++
+
+	var created []string
+	for _, d := range dirs {
+		for _, suffix := range []string{".sql", ".down.sql"} {
+			file := filepath.Join(d, fmt.Sprintf("%03d_%s%s", version, name, suffix))
+			header := fmt.Sprintf("-- %s\n\n", filepath.ToSlash(file))
+			if err := os.WriteFile(file, []byte(header), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, file)
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,360 @@
+// Package migrate menjalankan migrasi skema dari package migrations. Versi yang
+// sudah diterapkan dicatat di schema_migrations bersama checksum file up-nya,
+// jadi file migrasi yang diubah setelah diterapkan ketahuan sebelum apa pun
+// dijalankan.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/migrations"
+)
+
+// lockKey - key pg_advisory_lock yang dipegang selama migrasi berjalan, supaya
+// beberapa instance yang start bersamaan tidak menjalankan migrasi yang sama
+const lockKey = 727_436_211
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+// fileName - NNN_nama.sql atau NNN_nama.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+?)(\.down)?\.sql$`)
+
+var (
+	// ErrChecksum - file up dari migrasi yang sudah diterapkan berubah
+	ErrChecksum = errors.New("migration was modified after it was applied")
+	// ErrUnknownVersion - database punya versi yang tidak ada di binary ini
+	ErrUnknownVersion = errors.New("database has a migration this binary does not know")
+	// ErrNoDown - migrasi tidak punya file down
+	ErrNoDown = errors.New("migration has no down file")
+)
+
+// Migration - satu versi skema. Checksum adalah SHA-256 dari file up.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status - migrasi beserta kapan diterapkan; AppliedAt nil kalau belum.
+// Modified berarti checksum di database beda dengan file up sekarang.
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	Modified  bool
+	// Missing - versi tercatat di database tapi tidak ada filenya
+	Missing bool
+}
+
+type applied struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator - menjalankan migrasi dialek yang sesuai dengan db
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New - migrasi SQLite untuk database SQLite, selain itu migrasi Postgres
+func New(db *sql.DB) (*Migrator, error) {
+	dir := "."
+	if database.IsSQLite(db) {
+		dir = "sqlite"
+	}
+	list, err := Load(migrations.FS, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// Load - baca migrasi dari dir, urut berdasarkan versi. Setiap versi wajib
+// punya file up; file down boleh tidak ada.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %03d has files with different names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] != "" {
+			m.Down = string(content)
+		} else {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up file", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Migrations - semua migrasi yang dikenal, urut berdasarkan versi
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up - terapkan migrasi yang belum diterapkan sampai versi target (0 = semua).
+// Mengembalikan migrasi yang baru diterapkan.
+func (m *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if target > 0 && migration.Version > target {
+				break
+			}
+			if _, ok := state[migration.Version]; ok {
+				continue
+			}
+			ran, err := m.apply(ctx, conn, migration)
+			if err != nil {
+				return err
+			}
+			if ran {
+				done = append(done, migration)
+			}
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down - rollback steps migrasi terakhir, yang terbaru dulu. Mengembalikan
+// migrasi yang di-rollback.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		state, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := state[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("%03d_%s: %w", migration.Version, migration.Name, ErrNoDown)
+			}
+			ran, err := m.revert(ctx, conn, migration)
+			if err != nil {
+				return err
+			}
+			if ran {
+				done = append(done, migration)
+			}
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status - semua migrasi yang dikenal plus versi di database yang tidak dikenal
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	state, err := m.load(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if a, ok := state[migration.Version]; ok {
+			appliedAt := a.appliedAt
+			status.AppliedAt = &appliedAt
+			status.Modified = a.checksum != migration.Checksum
+			delete(state, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, a := range state {
+		appliedAt := a.appliedAt
+		statuses = append(statuses, Status{Version: version, Name: a.name, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// withLock - jalankan fn di satu koneksi yang memegang advisory lock migrasi.
+// SQLite tidak punya lock sesi: setiap migrasi berjalan dalam transaksi BEGIN
+// IMMEDIATE yang mengecek ulang schema_migrations (inTx), jadi runner lain
+// menunggu lalu melewati migrasi yang sudah diterapkan.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if database.IsSQLite(m.db) {
+		return fn(conn)
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	return fn(conn)
+}
+
+// verify - baca schema_migrations dan tolak kalau ada versi yang checksum-nya
+// berubah atau tidak dikenal
+func (m *Migrator) verify(ctx context.Context, conn *sql.Conn) (map[int]applied, error) {
+	state, err := m.load(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, a := range state {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%03d_%s: %w", version, a.name, ErrUnknownVersion)
+		}
+		if a.checksum != migration.Checksum {
+			return nil, fmt.Errorf("%03d_%s: %w", version, migration.Name, ErrChecksum)
+		}
+	}
+	return state, nil
+}
+
+// load - isi schema_migrations; tabelnya dibuat kalau belum ada
+func (m *Migrator) load(ctx context.Context, conn *sql.Conn) (map[int]applied, error) {
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	state := map[int]applied{}
+	for rows.Next() {
+		var version int
+		var a applied
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		state[version] = a
+	}
+	return state, rows.Err()
+}
+
+// apply - satu migrasi up dalam satu transaksi. false kalau ternyata sudah
+// diterapkan oleh runner lain.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) (bool, error) {
+	return m.inTx(ctx, conn, migration, func(tx *sql.Tx, isApplied bool) (bool, error) {
+		if isApplied {
+			return false, nil
+		}
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return false, err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum,
+		)
+		return err == nil, err
+	})
+}
+
+// revert - satu migrasi down dalam satu transaksi. false kalau ternyata sudah
+// di-rollback oleh runner lain.
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) (bool, error) {
+	return m.inTx(ctx, conn, migration, func(tx *sql.Tx, isApplied bool) (bool, error) {
+		if !isApplied {
+			return false, nil
+		}
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return false, err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		return err == nil, err
+	})
+}
+
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, migration Migration, fn func(tx *sql.Tx, isApplied bool) (bool, error)) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM schema_migrations WHERE version = $1", migration.Version,
+	).Scan(&count); err != nil {
+		return false, err
+	}
+
+	ran, err := fn(tx, count > 0)
+	if err != nil {
+		return false, fmt.Errorf("%03d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return ran, tx.Commit()
+}
@@ -0,0 +1,270 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"taskflow-api/internal/database"
+)
+
+// openDB - database SQLite kosong di direktori sementara test
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "migrate.db"))
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// testMigrations - tiga migrasi kecil (003 tanpa file down) di antara file
+// yang bukan migrasi dan harus dilewati Load
+var testMigrations = fstest.MapFS{
+	"001_notes.sql":           {Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)")},
+	"001_notes.down.sql":      {Data: []byte("DROP TABLE notes")},
+	"002_note_tags.sql":       {Data: []byte("CREATE TABLE note_tags (note_id INTEGER, tag TEXT)")},
+	"002_note_tags.down.sql":  {Data: []byte("DROP TABLE note_tags")},
+	"003_note_index.sql":      {Data: []byte("CREATE INDEX idx_notes_body ON notes (body)")},
+	"README.md":               {Data: []byte("not a migration")},
+	"sqlite/001_other.sql":    {Data: []byte("SELECT 1")},
+	"004_ignored.sql.orig":    {Data: []byte("SELECT 1")},
+	"005_Bad-Name.sql":        {Data: []byte("SELECT 1")},
+	"002_note_tags.down.sqlx": {Data: []byte("SELECT 1")},
+}
+
+func newTestMigrator(t *testing.T, db *sql.DB, fsys fstest.MapFS) *Migrator {
+	t.Helper()
+	list, err := Load(fsys, ".")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return &Migrator{db: db, migrations: list}
+}
+
+func versions(list []Migration) []int {
+	var out []int
+	for _, m := range list {
+		out = append(out, m.Version)
+	}
+	return out
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1", name).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n > 0
+}
+
+func TestLoad(t *testing.T) {
+	list, err := Load(testMigrations, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(list); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("versions = %v, want [1 2 3]", got)
+	}
+	if list[0].Name != "notes" || list[0].Down != "DROP TABLE notes" || list[2].Down != "" || len(list[0].Checksum) != 64 {
+		t.Errorf("migrations = %+v", list)
+	}
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"down without up", fstest.MapFS{"001_a.down.sql": {Data: []byte("x")}}, "001_a has no up file"},
+		{"names differ", fstest.MapFS{"001_a.sql": {Data: []byte("x")}, "001_b.down.sql": {Data: []byte("x")}}, "different names"},
+	}
+	for _, tt := range tests {
+		if _, err := Load(tt.fsys, "."); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+	if _, err := Load(testMigrations, "missing"); err == nil {
+		t.Error("missing directory accepted")
+	}
+}
+
+func TestUpDownRoundTrip(t *testing.T) {
+	db := openDB(t)
+	m := newTestMigrator(t, db, testMigrations)
+	ctx := context.Background()
+
+	done, err := m.Up(ctx, 2)
+	if err != nil || len(done) != 2 {
+		t.Fatalf("Up(2) = %v, %v", versions(done), err)
+	}
+	if !tableExists(t, db, "note_tags") {
+		t.Fatal("note_tags not created")
+	}
+	if done, err = m.Up(ctx, 0); err != nil || len(done) != 1 || done[0].Version != 3 {
+		t.Fatalf("Up(0) = %v, %v; want [3]", versions(done), err)
+	}
+	if done, err = m.Up(ctx, 0); err != nil || len(done) != 0 {
+		t.Fatalf("second Up = %v, %v; want nothing", versions(done), err)
+	}
+
+	// 003 tidak punya file down: rollback berhenti sebelum menyentuh apa pun
+	if done, err = m.Down(ctx, 1); !errors.Is(err, ErrNoDown) || len(done) != 0 {
+		t.Fatalf("Down over 003 = %v, %v; want ErrNoDown", versions(done), err)
+	}
+	if _, err := db.Exec("DELETE FROM schema_migrations WHERE version = 3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if done, err = m.Down(ctx, 5); err != nil || len(done) != 2 || done[0].Version != 2 || done[1].Version != 1 {
+		t.Fatalf("Down(5) = %v, %v; want [2 1]", versions(done), err)
+	}
+	if tableExists(t, db, "notes") || tableExists(t, db, "note_tags") {
+		t.Error("tables left after rolling everything back")
+	}
+	if done, err = m.Down(ctx, 1); err != nil || len(done) != 0 {
+		t.Errorf("Down on empty database = %v, %v", versions(done), err)
+	}
+}
+
+func TestFailedMigrationRollsBack(t *testing.T) {
+	db := openDB(t)
+	m := newTestMigrator(t, db, fstest.MapFS{
+		"001_notes.sql":  {Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY)")},
+		"002_broken.sql": {Data: []byte("CREATE TABLE tags (id INTEGER); INSERT INTO nope VALUES (1)")},
+	})
+
+	done, err := m.Up(context.Background(), 0)
+	if err == nil || !strings.HasPrefix(err.Error(), "002_broken:") || len(done) != 1 {
+		t.Fatalf("Up = %v, %v; want 001 applied and a 002_broken error", versions(done), err)
+	}
+	if tableExists(t, db, "tags") {
+		t.Error("failed migration left its table behind")
+	}
+	statuses, err := m.Status(context.Background())
+	if err != nil || statuses[1].AppliedAt != nil {
+		t.Errorf("002 status = %+v, %v; want pending", statuses, err)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	if _, err := newTestMigrator(t, db, testMigrations).Up(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	edited := fstest.MapFS{}
+	for name, file := range testMigrations {
+		edited[name] = file
+	}
+	edited["001_notes.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT, title TEXT)")}
+	m := newTestMigrator(t, db, edited)
+
+	if done, err := m.Up(ctx, 0); !errors.Is(err, ErrChecksum) || len(done) != 0 {
+		t.Errorf("Up = %v, %v; want ErrChecksum before anything runs", versions(done), err)
+	}
+	if _, err := m.Down(ctx, 1); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Down = %v, want ErrChecksum", err)
+	}
+	if tableExists(t, db, "note_tags") {
+		t.Error("Up ran 002 despite the checksum mismatch")
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil || len(statuses) != 3 || !statuses[0].Modified || statuses[1].Modified {
+		t.Errorf("Status = %+v, %v; want 001 modified", statuses, err)
+	}
+}
+
+func TestUnknownVersion(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	if _, err := newTestMigrator(t, db, testMigrations).Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Binary lama yang hanya mengenal 001
+	m := newTestMigrator(t, db, fstest.MapFS{
+		"001_notes.sql":      testMigrations["001_notes.sql"],
+		"001_notes.down.sql": testMigrations["001_notes.down.sql"],
+	})
+	if _, err := m.Up(ctx, 0); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Up = %v, want ErrUnknownVersion", err)
+	}
+	if _, err := m.Down(ctx, 1); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Down = %v, want ErrUnknownVersion", err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil || len(statuses) != 3 {
+		t.Fatalf("Status = %+v, %v", statuses, err)
+	}
+	for _, s := range statuses[1:] {
+		if !s.Missing || s.AppliedAt == nil {
+			t.Errorf("status %03d = %+v, want missing", s.Version, s)
+		}
+	}
+	if statuses[1].Name != "note_tags" {
+		t.Errorf("missing migration name = %q, want the name recorded in the database", statuses[1].Name)
+	}
+}
+
+func TestEmbeddedMigrationsRoundTrip(t *testing.T) {
+	db := openDB(t)
+	m, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	all := m.Migrations()
+
+	if done, err := m.Up(ctx, 0); err != nil || len(done) != len(all) {
+		t.Fatalf("Up = %d migrations, %v; want %d", len(done), err, len(all))
+	}
+	if done, err := m.Down(ctx, len(all)); err != nil || len(done) != len(all) {
+		t.Fatalf("Down = %d migrations, %v; want %d", len(done), err, len(all))
+	}
+	if tableExists(t, db, "tasks") || tableExists(t, db, "users") {
+		t.Error("tables left after rolling back every migration")
+	}
+	if done, err := m.Up(ctx, 0); err != nil || len(done) != len(all) {
+		t.Fatalf("Up after Down = %d migrations, %v", len(done), err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sqlite"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"001_init.sql", "sqlite/001_init.sql", "sqlite/002_sqlite_only.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("SELECT 1"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := Create(dir, "Add Task Labels!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"003_add_task_labels.sql", "003_add_task_labels.down.sql", "sqlite/003_add_task_labels.sql", "sqlite/003_add_task_labels.down.sql"}
+	if len(files) != len(want) {
+		t.Fatalf("files = %v", files)
+	}
+	for i, file := range files {
+		if file != filepath.Join(dir, want[i]) {
+			t.Errorf("file %d = %s, want %s", i, file, want[i])
+		}
+	}
+	if list, err := Load(os.DirFS(dir), "."); err != nil || len(list) != 2 {
+		t.Errorf("Load after Create = %v, %v", list, err)
+	}
+
+	if _, err := Create(dir, "--"); err == nil {
+		t.Error("name without letters accepted")
+	}
+}
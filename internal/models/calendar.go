@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CalendarFeed - URL langganan iCalendar milik user. Token dan URL hanya dikirim
+// sekali saat dibuat.
+type CalendarFeed struct {
+	Token          string     `json:"token,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Status job export
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// ExportJob - export yang dibuat di background. DownloadURL hanya dikirim saat
+// job dibuat; link berlaku begitu status completed sampai ExpiresAt.
+type ExportJob struct {
+	ID          int        `json:"id"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Size        int64      `json:"size"`
+	Error       *string    `json:"error"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Format file import
+const (
+	ImportCSV     = "csv"
+	ImportJSON    = "json"
+	ImportTodoist = "todoist"
+	ImportTrello  = "trello"
+	ImportICS     = "ics"
+)
+
+// Status job import
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+// ImportJob - satu file yang diimpor di background. Progress = ProcessedRows / TotalRows.
+type ImportJob struct {
+	ID            int              `json:"id"`
+	UserID        int              `json:"user_id"`
+	WorkspaceID   *int             `json:"workspace_id"`
+	Format        string           `json:"format"`
+	Filename      string           `json:"filename"`
+	Status        string           `json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	ImportedCount int              `json:"imported_count"`
+	FailedCount   int              `json:"failed_count"`
+	Error         *string          `json:"error"` // kenapa seluruh job gagal
+	Errors        []ImportRowError `json:"errors,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	StartedAt     *time.Time       `json:"started_at"`
+	FinishedAt    *time.Time       `json:"finished_at"`
+}
+
+// ImportRowError - baris yang tidak bisa diimpor. Row = nomor baris CSV atau
+// baris BEGIN:VTODO, atau urutan item (mulai 1) untuk format JSON.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
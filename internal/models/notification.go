@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Jenis notifikasi
+const (
+	NotificationAssignment = "assignment"
+	NotificationMention    = "mention"
+	NotificationReminder   = "reminder"
+	NotificationDueSoon    = "due_soon"
+	NotificationOverdue    = "overdue"
+)
+
+// NotificationTypes - semua jenis yang punya preferensi
+var NotificationTypes = []string{
+	NotificationAssignment,
+	NotificationMention,
+	NotificationReminder,
+	NotificationDueSoon,
+	NotificationOverdue,
+}
+
+type Notification struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	TaskID    *int       `json:"task_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationList - response list notifikasi beserta jumlah yang belum dibaca
+type NotificationList struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"`
+}
+
+// NotificationPreference - channel mana yang aktif untuk satu jenis notifikasi
+type NotificationPreference struct {
+	Type    string `json:"type"`
+	InApp   bool   `json:"in_app"`
+	Email   bool   `json:"email"`
+	Webhook bool   `json:"webhook"`
+}
+
+// Struct untuk request update preferensi; field kosong tidak diubah
+type UpdatePreferenceRequest struct {
+	Type    string `json:"type" binding:"required,oneof=assignment mention reminder due_soon overdue"`
+	InApp   *bool  `json:"in_app"`
+	Email   *bool  `json:"email"`
+	Webhook *bool  `json:"webhook"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences []UpdatePreferenceRequest `json:"preferences" binding:"required,dive"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type Project struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	WorkspaceID *int      `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	IsArchived  bool      `json:"is_archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Struct untuk request create project
+type CreateProjectRequest struct {
+	WorkspaceID *int   `json:"workspace_id"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	Color       string `json:"color" binding:"omitempty,hexcolor,max=7"`
+}
+
+// Struct untuk request update project
+type UpdateProjectRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description"`
+	Color       *string `json:"color" binding:"omitempty,hexcolor,max=7"`
+	IsArchived  *bool   `json:"is_archived"`
+}
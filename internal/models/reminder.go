@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Nama channel notifikasi yang bisa dipilih untuk reminder
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelInApp   = "in_app"
+)
+
+type TaskReminder struct {
+	ID            int        `json:"id"`
+	TaskID        int        `json:"task_id"`
+	UserID        int        `json:"user_id"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	FireAt        *time.Time `json:"fire_at"` // null kalau offset tapi task belum punya due date
+	Channels      []string   `json:"channels"`
+	SentAt        *time.Time `json:"sent_at"`
+	LastError     *string    `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Struct untuk request create reminder; isi remind_at ATAU offset_minutes.
+// Channels kosong = semua channel yang aktif di server.
+type CreateReminderRequest struct {
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes" binding:"omitempty,min=0,max=525600"`
+	Channels      []string   `json:"channels" binding:"omitempty,dive,oneof=email webhook in_app"`
+}
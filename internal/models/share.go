@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type TaskShare struct {
+	ID             int        `json:"id"`
+	TaskID         int        `json:"task_id"`
+	Token          string     `json:"token,omitempty"` // hanya dikirim sekali saat dibuat
+	URL            string     `json:"url,omitempty"`
+	CreatedBy      int        `json:"created_by"`
+	ReadOnly       bool       `json:"read_only"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Struct untuk request create share link (default read-only, tanpa expiry)
+type CreateShareRequest struct {
+	ReadOnly  *bool      `json:"read_only"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// PublicTask - tampilan task untuk orang tanpa akun, tanpa data internal
+type PublicTask struct {
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Priority    Priority   `json:"priority"`
+	Category    Category   `json:"category"`
+	Status      string     `json:"status"`
+	IsCompleted bool       `json:"is_completed"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ReadOnly    bool       `json:"read_only"`
+}
+
+// Struct untuk request update lewat share link yang tidak read-only
+type PublicTaskUpdateRequest struct {
+	Description *string `json:"description"`
+	IsCompleted *bool   `json:"is_completed"`
+}
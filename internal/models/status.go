@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// Default workflow statuses dibuat untuk setiap user baru
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusReview     = "review"
+	StatusDone       = "done"
+)
+
+type TaskStatus struct {
+	ID          int       `json:"id"`
+	UserID      *int      `json:"user_id"`
+	WorkspaceID *int      `json:"workspace_id"`
+	ProjectID   *int      `json:"project_id"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Position    int       `json:"position"`
+	IsTerminal  bool      `json:"is_terminal"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DefaultStatuses - kolom board default (To Do, In Progress, Review, Done)
+func DefaultStatuses() []TaskStatus {
+	return []TaskStatus{
+		{Key: StatusTodo, Name: "To Do", Position: 0},
+		{Key: StatusInProgress, Name: "In Progress", Position: 1},
+		{Key: StatusReview, Name: "Review", Position: 2},
+		{Key: StatusDone, Name: "Done", Position: 3, IsTerminal: true},
+	}
+}
+
+// Struct untuk request create status
+type CreateStatusRequest struct {
+	Key        string `json:"key" binding:"required,max=50"`
+	Name       string `json:"name" binding:"required,max=100"`
+	Position   *int   `json:"position"`
+	IsTerminal bool   `json:"is_terminal"`
+}
+
+// Struct untuk request update status
+type UpdateStatusRequest struct {
+	Name       *string `json:"name" binding:"omitempty,max=100"`
+	Position   *int    `json:"position"`
+	IsTerminal *bool   `json:"is_terminal"`
+}
+
+// Struct untuk request move task (drag-and-drop di board).
+// AfterID menaruh task tepat setelah task tersebut, BeforeID tepat sebelumnya.
+type MoveTaskRequest struct {
+	Status   string `json:"status" binding:"required"`
+	AfterID  *int   `json:"after_id"`
+	BeforeID *int   `json:"before_id"`
+}
+
+// Satu kolom di board beserta task-tasknya
+type BoardColumn struct {
+	Status TaskStatus `json:"status"`
+	Tasks  []Task     `json:"tasks"`
+}
+
+// FindStatus - status dengan key tertentu di daftar kolom board
+func FindStatus(statuses []TaskStatus, key string) (TaskStatus, bool) {
+	for _, s := range statuses {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return TaskStatus{}, false
+}
+
+// FirstStatus - kolom pertama yang open (terminal=false) atau selesai (terminal=true)
+func FirstStatus(statuses []TaskStatus, terminal bool) (TaskStatus, bool) {
+	for _, s := range statuses {
+		if s.IsTerminal == terminal {
+			return s, true
+		}
+	}
+	return TaskStatus{}, false
+}
+
+// HasOpenAndTerminal - board butuh minimal satu kolom open dan satu kolom selesai
+func HasOpenAndTerminal(statuses []TaskStatus) bool {
+	_, hasOpen := FirstStatus(statuses, false)
+	_, hasTerminal := FirstStatus(statuses, true)
+	return hasOpen && hasTerminal
+}
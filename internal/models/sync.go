@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status hasil satu mutasi POST /sync
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncRejected = "rejected"
+	SyncFailed   = "failed" // kesalahan server, mutasi boleh dikirim ulang
+)
+
+// SyncChanges - perubahan sejak token sebelumnya. Kirim NextToken sebagai ?since=
+// berikutnya; HasMore berarti masih ada halaman yang bisa diambil sekarang juga.
+type SyncChanges struct {
+	Tasks     []Task        `json:"tasks"`
+	Deleted   []DeletedTask `json:"deleted"`
+	NextToken string        `json:"next_token"`
+	HasMore   bool          `json:"has_more"`
+}
+
+// DeletedTask - tombstone task yang sudah dihapus
+type DeletedTask struct {
+	ID        int       `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Struct untuk request POST /sync, mutasi diterapkan berurutan
+type SyncRequest struct {
+	Mutations []SyncMutation `json:"mutations" binding:"required,max=100,dive"`
+}
+
+// SyncMutation - satu perubahan offline. Task berisi body create (op create) atau
+// merge patch (op update). BaseVersion = versi yang dilihat client; kosong berarti
+// tanpa syarat (last write wins).
+type SyncMutation struct {
+	ClientID    string          `json:"client_id" binding:"required,max=100"`
+	Op          string          `json:"op" binding:"required,oneof=create update delete"`
+	TaskID      *int            `json:"task_id"`
+	BaseVersion *int64          `json:"base_version"`
+	Task        json.RawMessage `json:"task"`
+}
+
+// SyncResult - hasil satu mutasi. Untuk conflict, Task berisi versi server
+// (nil kalau task sudah dihapus) supaya client bisa menggabungkan ulang.
+type SyncResult struct {
+	ClientID string `json:"client_id"`
+	Status   string `json:"status"`
+	Task     *Task  `json:"task,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -20,40 +20,71 @@ const (
 type Task struct {
 	ID          int        `json:"id"`
 	UserID      int        `json:"user_id"`
+	WorkspaceID *int       `json:"workspace_id"`
+	ProjectID   *int       `json:"project_id"`
+	AssigneeID  *int       `json:"assignee_id"`
 	Title       string     `json:"title"`
-	Description string     `json:"description"`
+	Description *string    `json:"description"`
 	Priority    Priority   `json:"priority"`
 	Category    Category   `json:"category"`
+	Status      string     `json:"status"`
+	Position    string     `json:"position"`
 	IsCompleted bool       `json:"is_completed"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
+	Version     int        `json:"version"` // naik setiap edit, dipakai sebagai ETag
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // Struct untuk request create task
 type CreateTaskRequest struct {
-	Title       string     `json:"title" binding:"required"`
-	Description string     `json:"description"`
+	Title       string     `json:"title" binding:"required,max=200"`
+	Description *string    `json:"description"`
+	WorkspaceID *int       `json:"workspace_id"`
+	ProjectID   *int       `json:"project_id"`
+	AssigneeID  *int       `json:"assignee_id"`
 	Priority    Priority   `json:"priority" binding:"omitempty,oneof=high medium low"`
 	Category    Category   `json:"category" binding:"omitempty,oneof=personal work urgent"`
+	Status      string     `json:"status"`
+	IsCompleted *bool      `json:"is_completed"` // tanpa status: kolom terminal pertama
 	DueDate     *time.Time `json:"due_date,omitempty"`
 }
 
-// Struct untuk request update task
-type UpdateTaskRequest struct {
-	Title       *string    `json:"title"`
+// TaskDocument - field task yang bisa diedit. Body PUT (penggantian penuh, field
+// yang tidak dikirim kembali ke default/null) dan dokumen yang di-PATCH.
+type TaskDocument struct {
+	Title       string     `json:"title" binding:"required,max=200"`
 	Description *string    `json:"description"`
-	Priority    *Priority  `json:"priority" binding:"omitempty,oneof=high medium low"`
-	Category    *Category  `json:"category" binding:"omitempty,oneof=personal work urgent"`
+	ProjectID   *int       `json:"project_id"`
+	AssigneeID  *int       `json:"assignee_id"`
+	Priority    Priority   `json:"priority" binding:"omitempty,oneof=high medium low"`
+	Category    Category   `json:"category" binding:"omitempty,oneof=personal work urgent"`
+	Status      string     `json:"status"`
 	IsCompleted *bool      `json:"is_completed"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
+	DueDate     *time.Time `json:"due_date"`
+}
+
+// Struct untuk request assign/unassign task (null = unassign)
+type AssignTaskRequest struct {
+	AssigneeID *int `json:"assignee_id"`
 }
 
 // Struct untuk task statistics
 type TaskStats struct {
-	Total        int `json:"total"`
-	Completed    int `json:"completed"`
-	Pending      int `json:"pending"`
-	HighPriority int `json:"high_priority"`
-	Overdue      int `json:"overdue"`
+	Total        int             `json:"total"`
+	Completed    int             `json:"completed"`
+	Pending      int             `json:"pending"`
+	HighPriority int             `json:"high_priority"`
+	Overdue      int             `json:"overdue"`
+	ByAssignee   []AssigneeStats `json:"by_assignee"`
+}
+
+// Statistik per assignee (AssigneeID nil = belum di-assign)
+type AssigneeStats struct {
+	AssigneeID *int   `json:"assignee_id"`
+	Name       string `json:"name"`
+	Total      int    `json:"total"`
+	Completed  int    `json:"completed"`
+	Pending    int    `json:"pending"`
+	Overdue    int    `json:"overdue"`
 }
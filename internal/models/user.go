@@ -9,6 +9,8 @@ type User struct {
 	Password  string    `json:"-"` // "-" artinya field ini tidak akan muncul di response JSON
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DisabledAt - diisi admin; user nonaktif tidak bisa login
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
 }
 
 // Struct untuk request register
@@ -29,3 +31,25 @@ type LoginResponse struct {
 	Token string `json:"token"`
 	User  User   `json:"user"`
 }
+
+// AppPassword - password terpisah untuk client yang hanya bisa HTTP Basic auth
+// (CalDAV). Password hanya dikirim sekali saat dibuat.
+type AppPassword struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Password   string     `json:"password,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// StreamTicket - pengganti JWT di query string untuk /events, /ws dan GET
+// /graphql. Berlaku sebentar dan hanya untuk satu koneksi.
+type StreamTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Struct untuk request create app password
+type CreateAppPasswordRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Status pengiriman webhook
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type Webhook struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	WorkspaceID *int      `json:"workspace_id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"` // hanya dikirim sekali saat dibuat
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Struct untuk request create webhook
+type CreateWebhookRequest struct {
+	WorkspaceID *int     `json:"workspace_id"`
+	URL         string   `json:"url" binding:"required,url,max=2000"`
+	Events      []string `json:"events" binding:"required,min=1,dive,oneof=task.created task.updated task.completed task.deleted"`
+	Description string   `json:"description" binding:"max=255"`
+}
+
+// Struct untuk request update webhook (pointer = optional)
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,url,max=2000"`
+	Events      []string `json:"events" binding:"omitempty,min=1,dive,oneof=task.created task.updated task.completed task.deleted"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// WebhookDelivery - satu percobaan (beserta retry-nya) mengirim satu event
+type WebhookDelivery struct {
+	ID             int        `json:"id"`
+	WebhookID      int        `json:"webhook_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload,omitempty"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	Error          *string    `json:"error"`
+	RedeliveryOf   *int       `json:"redelivery_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
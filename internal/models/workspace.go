@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type Workspace struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   int       `json:"owner_id"`
+	Role      string    `json:"role,omitempty"` // role user yang sedang login
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WorkspaceMember struct {
+	UserID    int       `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WorkspaceInvitation struct {
+	ID          int        `json:"id"`
+	WorkspaceID int        `json:"workspace_id"`
+	Workspace   string     `json:"workspace,omitempty"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Token       string     `json:"token,omitempty"` // hanya dikirim sekali saat dibuat
+	InvitedBy   int        `json:"invited_by"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Struct untuk request create/update workspace
+type WorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// Struct untuk request invite anggota
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=admin member viewer"`
+}
+
+// Struct untuk request ubah role anggota
+type UpdateMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member viewer"`
+}
@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// LogChannel - tulis notifikasi ke log, dipakai kalau SMTP belum dikonfigurasi
+type LogChannel struct{}
+
+func (LogChannel) Name() string { return "log" }
+
+func (LogChannel) Send(ctx context.Context, msg Message) error {
+	log.Printf("📨 Notification for %s <%s>: %s", msg.Name, msg.Email, msg.Subject)
+	return nil
+}
+
+// SMTPConfig - konfigurasi server email
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// EmailChannel - kirim notifikasi lewat SMTP
+type EmailChannel struct {
+	cfg SMTPConfig
+}
+
+func NewEmailChannel(cfg SMTPConfig) *EmailChannel {
+	return &EmailChannel{cfg: cfg}
+}
+
+func (e *EmailChannel) Name() string { return "email" }
+
+func (e *EmailChannel) Send(ctx context.Context, msg Message) error {
+	if msg.Email == "" {
+		return fmt.Errorf("user %d has no email", msg.UserID)
+	}
+
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		auth = smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, e.cfg.Host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+	b.WriteString("\r\n")
+
+	return smtp.SendMail(net.JoinHostPort(e.cfg.Host, e.cfg.Port), auth, e.cfg.From, []string{msg.Email}, []byte(b.String()))
+}
+
+// InAppChannel - simpan notifikasi ke tabel notifications (inbox di aplikasi)
+type InAppChannel struct {
+	db *sql.DB
+}
+
+func NewInAppChannel(db *sql.DB) *InAppChannel {
+	return &InAppChannel{db: db}
+}
+
+func (c *InAppChannel) Name() string { return "in_app" }
+
+func (c *InAppChannel) Send(ctx context.Context, msg Message) error {
+	msgType := msg.Type
+	if msgType == "" {
+		msgType = "general"
+	}
+	_, err := c.db.ExecContext(ctx,
+		"INSERT INTO notifications (user_id, type, title, body, task_id) VALUES ($1, $2, $3, $4, $5)",
+		msg.UserID, msgType, msg.Subject, msg.Body, msg.TaskID,
+	)
+	return err
+}
+
+// WebhookChannel - POST notifikasi sebagai JSON ke satu URL (mis. Slack relay, n8n)
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WebhookChannel) Name() string { return "webhook" }
+
+func (w *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"type":    msg.Type,
+		"user_id": msg.UserID,
+		"email":   msg.Email,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+		"task_id": msg.TaskID,
+		"sent_at": time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TaskFlow-Notifier")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// sanitizeHeader - cegah header injection lewat judul task
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+)
+
+// Message - satu notifikasi untuk satu user. Type adalah salah satu
+// models.Notification*, dipakai untuk preferensi user.
+type Message struct {
+	Type    string
+	UserID  int
+	Name    string
+	Email   string
+	Subject string
+	Body    string
+	TaskID  *int
+}
+
+// Channel - cara pengiriman notifikasi (email, log, ...)
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Notifier - kirim notifikasi ke user lewat semua channel yang terdaftar
+type Notifier struct {
+	db       *sql.DB
+	channels []Channel
+}
+
+func NewNotifier(db *sql.DB, channels ...Channel) *Notifier {
+	return &Notifier{db: db, channels: channels}
+}
+
+// NotifyUser - kirim notifikasi sederhana ke semua channel
+func (n *Notifier) NotifyUser(ctx context.Context, userID int, subject, body string) error {
+	return n.Send(ctx, Message{UserID: userID, Subject: subject, Body: body}, nil)
+}
+
+// Send - lengkapi nama/email penerima lalu kirim lewat channel yang dipilih
+// dan diizinkan oleh preferensi user. channels nil/kosong = semua channel.
+func (n *Notifier) Send(ctx context.Context, msg Message, channels []string) error {
+	err := n.db.QueryRowContext(ctx, "SELECT name, email FROM users WHERE id = $1", msg.UserID).Scan(&msg.Name, &msg.Email)
+	if err != nil {
+		return err
+	}
+
+	pref, err := n.preference(ctx, msg.UserID, msg.Type)
+	if err != nil {
+		return err
+	}
+
+	var failed *DeliveryError
+	for _, ch := range n.channels {
+		if !selected(ch.Name(), channels) || !allows(pref, ch.Name()) {
+			continue
+		}
+		if err := ch.Send(ctx, msg); err != nil {
+			log.Printf("notify: %s delivery to user %d failed: %v", ch.Name(), msg.UserID, err)
+			if failed == nil {
+				failed = &DeliveryError{Err: err}
+			}
+			failed.Channels = append(failed.Channels, ch.Name())
+		}
+	}
+	if failed != nil {
+		return failed
+	}
+	return nil
+}
+
+// DeliveryError - sebagian channel gagal. Channel lain sudah terkirim, jadi
+// percobaan ulang cukup lewat Channels.
+type DeliveryError struct {
+	Channels []string
+	Err      error // error channel pertama yang gagal
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("delivery via %s failed: %v", strings.Join(e.Channels, ", "), e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// HandleEvent - subscriber untuk events.Bus
+func (n *Notifier) HandleEvent(e events.Event) {
+	taskID := e.Task.ID
+	var messages []Message
+
+	switch e.Type {
+	case events.TaskAssigned:
+		// Tidak perlu memberi tahu user yang meng-assign dirinya sendiri
+		if e.Task.AssigneeID == nil || *e.Task.AssigneeID == e.ActorID {
+			return
+		}
+		msg := Message{
+			Type:    models.NotificationAssignment,
+			UserID:  *e.Task.AssigneeID,
+			Subject: fmt.Sprintf("You were assigned: %s", e.Task.Title),
+			Body:    fmt.Sprintf("You have been assigned to the task %q (#%d).", e.Task.Title, e.Task.ID),
+			TaskID:  &taskID,
+		}
+		if e.Task.DueDate != nil {
+			msg.Body += fmt.Sprintf("\nDue: %s", e.Task.DueDate.Format(time.RFC1123))
+		}
+		messages = append(messages, msg)
+
+	case events.TaskMentioned:
+		for _, userID := range e.Mentioned {
+			if userID == e.ActorID {
+				continue
+			}
+			messages = append(messages, Message{
+				Type:    models.NotificationMention,
+				UserID:  userID,
+				Subject: fmt.Sprintf("You were mentioned in: %s", e.Task.Title),
+				Body:    fmt.Sprintf("You were mentioned in the task %q (#%d).", e.Task.Title, e.Task.ID),
+				TaskID:  &taskID,
+			})
+		}
+	}
+
+	if len(messages) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		for _, msg := range messages {
+			n.Send(ctx, msg, nil)
+		}
+	}()
+}
+
+// preference - preferensi user untuk satu jenis notifikasi; default semua aktif
+func (n *Notifier) preference(ctx context.Context, userID int, msgType string) (models.NotificationPreference, error) {
+	pref := models.NotificationPreference{Type: msgType, InApp: true, Email: true, Webhook: true}
+	if msgType == "" {
+		return pref, nil
+	}
+
+	err := n.db.QueryRowContext(ctx,
+		"SELECT in_app, email, webhook FROM notification_preferences WHERE user_id = $1 AND type = $2",
+		userID, msgType,
+	).Scan(&pref.InApp, &pref.Email, &pref.Webhook)
+	if err == sql.ErrNoRows {
+		return pref, nil
+	}
+	return pref, err
+}
+
+// allows - apakah preferensi mengizinkan pengiriman lewat channel
+func allows(p models.NotificationPreference, channel string) bool {
+	switch channelKind(channel) {
+	case models.ChannelInApp:
+		return p.InApp
+	case models.ChannelEmail:
+		return p.Email
+	case models.ChannelWebhook:
+		return p.Webhook
+	}
+	return true
+}
+
+// channelKind - LogChannel menggantikan email selama SMTP belum dikonfigurasi
+func channelKind(name string) string {
+	if name == "log" {
+		return models.ChannelEmail
+	}
+	return name
+}
+
+// selected - apakah channel termasuk pilihan
+func selected(name string, channels []string) bool {
+	if len(channels) == 0 {
+		return true
+	}
+	for _, c := range channels {
+		if c == channelKind(name) {
+			return true
+		}
+	}
+	return false
+}
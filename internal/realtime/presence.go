@@ -0,0 +1,195 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"taskflow-api/internal/events"
+)
+
+const (
+	// presenceChannel - channel LISTEN/NOTIFY untuk perubahan viewer, payload-nya task id
+	presenceChannel = "task_presence"
+	// PresenceTTL - viewer yang tidak mengirim heartbeat selama ini dianggap pergi
+	PresenceTTL = 60 * time.Second
+)
+
+// Presence - tipe Event untuk daftar viewer sebuah task
+const Presence events.Type = "presence"
+
+type Viewer struct {
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// PresencePayload - isi Event.Data untuk Presence
+type PresencePayload struct {
+	TaskID  int      `json:"task_id"`
+	Viewers []Viewer `json:"viewers"`
+}
+
+// Watch - client menerima perubahan viewer task ini
+func (h *Hub) Watch(client *Client, taskID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	if h.watchers[taskID] == nil {
+		h.watchers[taskID] = make(map[*Client]struct{})
+	}
+	h.watchers[taskID][client] = struct{}{}
+	client.watching[taskID] = true
+}
+
+func (h *Hub) Unwatch(client *Client, taskID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.unwatch(client, taskID)
+}
+
+// Join - tandai koneksi sedang melihat task
+func (h *Hub) Join(ctx context.Context, connectionID string, userID, taskID int) error {
+	_, err := h.db.ExecContext(ctx, h.dialect.Pick(
+		`WITH p AS (
+		     INSERT INTO task_presence (connection_id, task_id, user_id) VALUES ($1, $2, $3)
+		     ON CONFLICT (connection_id, task_id) DO UPDATE SET seen_at = CURRENT_TIMESTAMP
+		     RETURNING task_id)
+		 SELECT pg_notify($4, task_id::text) FROM p`,
+		`INSERT INTO task_presence (connection_id, task_id, user_id) VALUES ($1, $2, $3)
+		 ON CONFLICT (connection_id, task_id) DO UPDATE SET seen_at = CURRENT_TIMESTAMP
+		 RETURNING pg_notify($4, task_id)`),
+		connectionID, taskID, userID, presenceChannel,
+	)
+	return err
+}
+
+// Leave - koneksi berhenti melihat task
+func (h *Hub) Leave(ctx context.Context, connectionID string, taskID int) error {
+	_, err := h.db.ExecContext(ctx, h.dialect.Pick(
+		`WITH p AS (DELETE FROM task_presence WHERE connection_id = $1 AND task_id = $2 RETURNING task_id)
+		 SELECT pg_notify($3, task_id::text) FROM p`,
+		"DELETE FROM task_presence WHERE connection_id = $1 AND task_id = $2 RETURNING pg_notify($3, task_id)"),
+		connectionID, taskID, presenceChannel,
+	)
+	return err
+}
+
+// LeaveAll - koneksi ditutup
+func (h *Hub) LeaveAll(ctx context.Context, connectionID string) error {
+	_, err := h.db.ExecContext(ctx, h.dialect.Pick(
+		`WITH p AS (DELETE FROM task_presence WHERE connection_id = $1 RETURNING task_id)
+		 SELECT pg_notify($2, task_id::text) FROM p`,
+		"DELETE FROM task_presence WHERE connection_id = $1 RETURNING pg_notify($2, task_id)"),
+		connectionID, presenceChannel,
+	)
+	return err
+}
+
+// Touch - heartbeat; perpanjang semua presence milik koneksi
+func (h *Hub) Touch(ctx context.Context, connectionID string) error {
+	_, err := h.db.ExecContext(ctx,
+		"UPDATE task_presence SET seen_at = CURRENT_TIMESTAMP WHERE connection_id = $1", connectionID,
+	)
+	return err
+}
+
+// PresenceEvent - daftar viewer task saat ini, satu user sekali walau punya beberapa koneksi
+func (h *Hub) PresenceEvent(ctx context.Context, taskID int) (Event, error) {
+	rows, err := h.db.QueryContext(ctx,
+		`SELECT u.id, u.name FROM users u
+		 WHERE u.id IN (SELECT user_id FROM task_presence WHERE task_id = $1 AND seen_at > $2)
+		 ORDER BY u.name, u.id`,
+		taskID, time.Now().Add(-PresenceTTL),
+	)
+	if err != nil {
+		return Event{}, err
+	}
+	defer rows.Close()
+
+	payload := PresencePayload{TaskID: taskID, Viewers: []Viewer{}}
+	for rows.Next() {
+		var v Viewer
+		if err := rows.Scan(&v.UserID, &v.Name); err != nil {
+			return Event{}, err
+		}
+		payload.Viewers = append(payload.Viewers, v)
+	}
+	if err := rows.Err(); err != nil {
+		return Event{}, err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{Type: Presence, Data: string(data)}, nil
+}
+
+// broadcastPresence - kirim daftar viewer terbaru ke client lokal yang mengawasi task
+func (h *Hub) broadcastPresence(ctx context.Context, taskID int) {
+	h.mu.Lock()
+	watched := len(h.watchers[taskID]) > 0
+	h.mu.Unlock()
+	if !watched {
+		return
+	}
+
+	e, err := h.PresenceEvent(ctx, taskID)
+	if err != nil {
+		log.Printf("realtime: presence of task %d: %v", taskID, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.watchers[taskID] {
+		select {
+		case client.C <- e:
+		default:
+			h.drop(client)
+		}
+	}
+}
+
+// refreshPresence - kirim ulang semua presence yang diikuti, mis. setelah listener putus
+func (h *Hub) refreshPresence(ctx context.Context) {
+	h.mu.Lock()
+	taskIDs := make([]int, 0, len(h.watchers))
+	for taskID := range h.watchers {
+		taskIDs = append(taskIDs, taskID)
+	}
+	h.mu.Unlock()
+
+	for _, taskID := range taskIDs {
+		h.broadcastPresence(ctx, taskID)
+	}
+}
+
+// expirePresence - hapus viewer yang heartbeat-nya berhenti (mis. instance mati)
+func (h *Hub) expirePresence(ctx context.Context) {
+	// Postgres menggabungkan NOTIFY yang sama dalam satu transaksi, jadi satu per task
+	_, err := h.db.ExecContext(ctx, h.dialect.Pick(
+		`WITH p AS (DELETE FROM task_presence WHERE seen_at < $1 RETURNING task_id)
+		 SELECT pg_notify($2, task_id::text) FROM p`,
+		"DELETE FROM task_presence WHERE seen_at < $1 RETURNING pg_notify($2, task_id)"),
+		time.Now().Add(-PresenceTTL), presenceChannel,
+	)
+	if err != nil {
+		log.Printf("realtime: expire presence: %v", err)
+	}
+}
+
+// unwatch - h.mu harus dipegang
+func (h *Hub) unwatch(client *Client, taskID int) {
+	delete(client.watching, taskID)
+	if watchers := h.watchers[taskID]; watchers != nil {
+		delete(watchers, client)
+		if len(watchers) == 0 {
+			delete(h.watchers, taskID)
+		}
+	}
+}
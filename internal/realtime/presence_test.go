@@ -0,0 +1,124 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// viewers - user id di presence task saat ini
+func viewers(t *testing.T, h *Hub, taskID int) []int {
+	t.Helper()
+	e, err := h.PresenceEvent(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("PresenceEvent: %v", err)
+	}
+	var payload PresencePayload
+	if err := json.Unmarshal([]byte(e.Data), &payload); err != nil {
+		t.Fatalf("decode presence: %v", err)
+	}
+	ids := []int{}
+	for _, v := range payload.Viewers {
+		ids = append(ids, v.UserID)
+	}
+	return ids
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPresenceListsEachUserOnce(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	for _, join := range []struct {
+		connection string
+		userID     int
+	}{{"a1", f.alice}, {"a2", f.alice}, {"b1", f.bob}} {
+		if err := f.hub.Join(ctx, join.connection, join.userID, f.taskID); err != nil {
+			t.Fatalf("Join %s: %v", join.connection, err)
+		}
+	}
+	if got := viewers(t, f.hub, f.taskID); !equalIDs(got, []int{f.alice, f.bob}) {
+		t.Fatalf("viewers = %v, want [%d %d]", got, f.alice, f.bob)
+	}
+
+	// Alice masih punya koneksi lain
+	if err := f.hub.LeaveAll(ctx, "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.hub.Leave(ctx, "b1", f.taskID); err != nil {
+		t.Fatal(err)
+	}
+	if got := viewers(t, f.hub, f.taskID); !equalIDs(got, []int{f.alice}) {
+		t.Errorf("viewers = %v, want [%d]", got, f.alice)
+	}
+}
+
+func TestExpirePresenceDropsViewersWithoutHeartbeat(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	if err := f.hub.Join(ctx, "a1", f.alice, f.taskID); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.hub.Join(ctx, "b1", f.bob, f.taskID); err != nil {
+		t.Fatal(err)
+	}
+	// Heartbeat bob berhenti (mis. instance-nya mati)
+	if _, err := f.db.Exec("UPDATE task_presence SET seen_at = $1 WHERE connection_id = 'b1'", time.Now().Add(-2*PresenceTTL)); err != nil {
+		t.Fatal(err)
+	}
+	if got := viewers(t, f.hub, f.taskID); !equalIDs(got, []int{f.alice}) {
+		t.Errorf("viewers before expiry = %v, want [%d]", got, f.alice)
+	}
+
+	f.hub.expirePresence(ctx)
+	var rows int
+	if err := f.db.QueryRow("SELECT COUNT(*) FROM task_presence").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 1 {
+		t.Errorf("presence rows after expiry = %d, want 1", rows)
+	}
+}
+
+func TestBroadcastPresenceOnlyToWatchers(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	watcher, other := f.hub.Subscribe(f.alice), f.hub.Subscribe(f.bob)
+	f.hub.Watch(watcher, f.taskID)
+
+	if err := f.hub.Join(ctx, "b1", f.bob, f.taskID); err != nil {
+		t.Fatal(err)
+	}
+	f.hub.broadcastPresence(ctx, f.taskID)
+
+	select {
+	case e := <-watcher.C:
+		if e.Type != Presence {
+			t.Errorf("event type = %s, want %s", e.Type, Presence)
+		}
+	default:
+		t.Error("watcher got no presence event")
+	}
+	if got := received(other); len(got) != 0 {
+		t.Errorf("client that is not watching got %d events", len(got))
+	}
+
+	f.hub.Unwatch(watcher, f.taskID)
+	f.hub.broadcastPresence(ctx, f.taskID)
+	if got := received(watcher); len(got) != 0 {
+		t.Errorf("unwatched client got %d events", len(got))
+	}
+}
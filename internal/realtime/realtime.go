@@ -0,0 +1,341 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// channel - nama channel LISTEN/NOTIFY, payload-nya id task_events
+	channel = "task_events"
+	// retention - event lebih tua dari ini tidak bisa di-resume lagi
+	retention = 24 * time.Hour
+	// clientBuffer - client yang tertinggal sejauh ini diputus dan harus reconnect
+	clientBuffer = 64
+	// replayPage - jumlah event per query saat replay Last-Event-ID
+	replayPage = 500
+	// pingInterval - seberapa sering koneksi LISTEN dicek, supaya koneksi yang
+	// mati diam-diam ketahuan dan disambung ulang
+	pingInterval = 90 * time.Second
+)
+
+// Events - tipe event yang dikirim ke stream
+var Events = []events.Type{events.TaskCreated, events.TaskUpdated, events.TaskDeleted}
+
+// Event - satu baris task_events
+type Event struct {
+	ID          int64
+	Type        events.Type
+	UserID      int
+	WorkspaceID *int
+	Data        string // JSON, lihat Payload
+}
+
+// Payload - isi field data di stream
+type Payload struct {
+	Type       events.Type `json:"type"`
+	ActorID    int         `json:"actor_id"`
+	Task       models.Task `json:"task"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+// Client - satu koneksi stream. C ditutup kalau hub memutus client yang lambat.
+type Client struct {
+	UserID int
+	C      chan Event
+
+	watching map[int]bool // task yang presence-nya diikuti, dijaga Hub.mu
+}
+
+// Hub - menulis event task ke task_events + NOTIFY, lalu meneruskan setiap
+// notifikasi (dari instance mana pun) ke client lokal yang boleh melihat task-nya.
+type Hub struct {
+	db          *sql.DB
+	dialect     database.Dialect
+	databaseURL string
+
+	mu       sync.Mutex
+	clients  map[*Client]struct{}
+	watchers map[int]map[*Client]struct{} // task id -> client yang mengikuti presence
+	lastID   int64
+}
+
+func NewHub(db *sql.DB, databaseURL string) *Hub {
+	return &Hub{
+		db:          db,
+		dialect:     database.DialectOf(db),
+		databaseURL: databaseURL,
+		clients:     make(map[*Client]struct{}),
+		watchers:    make(map[int]map[*Client]struct{}),
+	}
+}
+
+// HandleEvent - subscriber untuk events.Bus; hanya menulis ke task_events
+func (h *Hub) HandleEvent(e events.Event) {
+	if !streamed(e.Type) {
+		return
+	}
+
+	data, err := json.Marshal(Payload{Type: e.Type, ActorID: e.ActorID, Task: e.Task, OccurredAt: e.OccurredAt})
+	if err != nil {
+		log.Printf("realtime: encode %s: %v", e.Type, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = h.db.ExecContext(ctx, h.dialect.Pick(
+		`WITH e AS (
+		     INSERT INTO task_events (type, task_id, user_id, workspace_id, payload)
+		     VALUES ($1, $2, $3, $4, $5) RETURNING id)
+		 SELECT pg_notify($6, id::text) FROM e`,
+		`INSERT INTO task_events (type, task_id, user_id, workspace_id, payload)
+		 VALUES ($1, $2, $3, $4, $5) RETURNING pg_notify($6, id)`),
+		string(e.Type), e.Task.ID, e.Task.UserID, e.Task.WorkspaceID, string(data), channel,
+	)
+	if err != nil {
+		log.Printf("realtime: publish %s for task %d: %v", e.Type, e.Task.ID, err)
+	}
+}
+
+// Run - LISTEN task_events dan task_presence lalu teruskan ke client sampai ctx dibatalkan
+func (h *Hub) Run(ctx context.Context) {
+	notifications, pingListener, closeListener, ok := h.listen()
+	if !ok {
+		return
+	}
+	defer closeListener()
+
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+	expire := time.NewTicker(PresenceTTL / 2)
+	defer expire.Stop()
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-notifications:
+			// nil = koneksi baru tersambung lagi; notifikasi selama putus hilang
+			if n == nil {
+				h.catchUp(ctx)
+				h.refreshPresence(ctx)
+				continue
+			}
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				continue
+			}
+			if n.Channel == presenceChannel {
+				h.broadcastPresence(ctx, int(id))
+			} else {
+				h.load(ctx, "id = $1", id)
+			}
+		case <-ping.C:
+			go pingListener()
+		case <-prune.C:
+			if _, err := h.db.ExecContext(ctx, "DELETE FROM task_events WHERE created_at < $1", time.Now().Add(-retention)); err != nil {
+				log.Printf("realtime: prune: %v", err)
+			}
+		case <-expire.C:
+			h.expirePresence(ctx)
+		}
+	}
+}
+
+// listen - sumber notifikasi: pq.Listener untuk Postgres, atau notifikasi dalam
+// proses untuk SQLite (hanya ada satu instance API per file database)
+func (h *Hub) listen() (<-chan *pq.Notification, func(), func(), bool) {
+	if local := database.Notifications(h.db); local != nil {
+		return local, func() {}, func() {}, true
+	}
+
+	listener := pq.NewListener(h.databaseURL, 10*time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("realtime: listener: %v", err)
+		}
+	})
+	for _, name := range []string{channel, presenceChannel} {
+		if err := listener.Listen(name); err != nil {
+			log.Printf("realtime: listen %s: %v", name, err)
+			listener.Close()
+			return nil, nil, nil, false
+		}
+	}
+	return listener.Notify, func() { listener.Ping() }, func() { listener.Close() }, true
+}
+
+// Subscribe - daftarkan client baru untuk user
+func (h *Hub) Subscribe(userID int) *Client {
+	client := &Client{UserID: userID, C: make(chan Event, clientBuffer), watching: make(map[int]bool)}
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+	return client
+}
+
+func (h *Hub) Unsubscribe(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(client)
+}
+
+// Since - event setelah lastID yang boleh dilihat user, urut naik. ok false kalau
+// sebagian event sudah di-prune sehingga client harus memuat ulang datanya.
+func (h *Hub) Since(ctx context.Context, userID int, lastID int64) ([]Event, bool, error) {
+	var oldest sql.NullInt64
+	if err := h.db.QueryRowContext(ctx, "SELECT MIN(id) FROM task_events").Scan(&oldest); err != nil {
+		return nil, false, err
+	}
+	if oldest.Valid && lastID < oldest.Int64-1 {
+		return nil, false, nil
+	}
+
+	var result []Event
+	for {
+		rows, err := h.db.QueryContext(ctx,
+			`SELECT id, type, user_id, workspace_id, payload FROM task_events
+			 WHERE id > $2 AND `+authz.Visible("task_events", 1)+`
+			 ORDER BY id LIMIT $3`,
+			userID, lastID, replayPage,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		page, err := scanEvents(rows)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, page...)
+		if len(page) < replayPage {
+			return result, true, nil
+		}
+		lastID = page[len(page)-1].ID
+	}
+}
+
+// catchUp - ambil event yang terlewat selama listener putus
+func (h *Hub) catchUp(ctx context.Context) {
+	h.mu.Lock()
+	lastID := h.lastID
+	h.mu.Unlock()
+	h.load(ctx, "id > $1 ORDER BY id", lastID)
+}
+
+// load - ambil event dengan kondisi where lalu kirim ke client
+func (h *Hub) load(ctx context.Context, where string, arg interface{}) {
+	rows, err := h.db.QueryContext(ctx, "SELECT id, type, user_id, workspace_id, payload FROM task_events WHERE "+where, arg)
+	if err != nil {
+		log.Printf("realtime: load events: %v", err)
+		return
+	}
+	list, err := scanEvents(rows)
+	if err != nil {
+		log.Printf("realtime: load events: %v", err)
+		return
+	}
+	for _, e := range list {
+		h.broadcast(ctx, e)
+	}
+}
+
+// broadcast - kirim ke pemilik task personal, atau ke semua anggota workspace
+func (h *Hub) broadcast(ctx context.Context, e Event) {
+	var members map[int]bool
+	if e.WorkspaceID != nil {
+		var err error
+		members, err = h.members(ctx, *e.WorkspaceID)
+		if err != nil {
+			log.Printf("realtime: members of workspace %d: %v", *e.WorkspaceID, err)
+			return
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if e.ID > h.lastID {
+		h.lastID = e.ID
+	}
+	for client := range h.clients {
+		if e.WorkspaceID == nil && client.UserID != e.UserID {
+			continue
+		}
+		if e.WorkspaceID != nil && !members[client.UserID] {
+			continue
+		}
+		select {
+		case client.C <- e:
+		default:
+			// Jangan sampai satu client lambat menahan yang lain
+			h.drop(client)
+		}
+	}
+}
+
+func (h *Hub) members(ctx context.Context, workspaceID int) (map[int]bool, error) {
+	rows, err := h.db.QueryContext(ctx, "SELECT user_id FROM workspace_members WHERE workspace_id = $1", workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make(map[int]bool)
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		members[userID] = true
+	}
+	return members, rows.Err()
+}
+
+// drop - hapus client dan tutup channel-nya; h.mu harus dipegang
+func (h *Hub) drop(client *Client) {
+	if _, ok := h.clients[client]; ok {
+		for taskID := range client.watching {
+			h.unwatch(client, taskID)
+		}
+		delete(h.clients, client)
+		close(client.C)
+	}
+}
+
+func scanEvents(rows *sql.Rows) ([]Event, error) {
+	defer rows.Close()
+
+	var list []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.Type, &e.UserID, &e.WorkspaceID, &e.Data); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+func streamed(t events.Type) bool {
+	for _, e := range Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
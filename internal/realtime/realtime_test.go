@@ -0,0 +1,175 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+)
+
+// fixture - alice dan bob anggota satu workspace, carol tidak
+type fixture struct {
+	db                *sql.DB
+	hub               *Hub
+	alice, bob, carol int
+	workspaceID       int
+	taskID            int // task workspace untuk presence
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db := dbtest.Open(t)
+	f := &fixture{
+		db:    db,
+		hub:   NewHub(db, ""),
+		alice: dbtest.User(t, db, "alice@example.com"),
+		bob:   dbtest.User(t, db, "bob@example.com"),
+		carol: dbtest.User(t, db, "carol@example.com"),
+	}
+	if err := db.QueryRow("INSERT INTO workspaces (name, owner_id) VALUES ('Team', $1) RETURNING id", f.alice).Scan(&f.workspaceID); err != nil {
+		t.Fatalf("insert workspace: %v", err)
+	}
+	for user, role := range map[int]string{f.alice: "owner", f.bob: "member"} {
+		if _, err := db.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)", f.workspaceID, user, role); err != nil {
+			t.Fatalf("insert member: %v", err)
+		}
+	}
+	if err := db.QueryRow("INSERT INTO tasks (user_id, workspace_id, title) VALUES ($1, $2, 'Plan') RETURNING id", f.alice, f.workspaceID).Scan(&f.taskID); err != nil {
+		t.Fatalf("insert task: %v", err)
+	}
+	return f
+}
+
+// publish - simpan event untuk task lewat HandleEvent
+func (f *fixture) publish(task models.Task) {
+	f.hub.HandleEvent(events.Event{Type: events.TaskUpdated, ActorID: task.UserID, Task: task, OccurredAt: time.Now()})
+}
+
+// received - id event yang sudah ada di channel client
+func received(client *Client) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e := <-client.C:
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestBroadcastOnlyToUsersWhoCanSeeTheTask(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	alice, bob, carol := f.hub.Subscribe(f.alice), f.hub.Subscribe(f.bob), f.hub.Subscribe(f.carol)
+
+	f.publish(models.Task{ID: 1, UserID: f.alice})
+	f.publish(models.Task{ID: 2, UserID: f.alice, WorkspaceID: &f.workspaceID})
+	// Event yang tidak di-stream tidak disimpan
+	f.hub.HandleEvent(events.Event{Type: events.TaskMentioned, Task: models.Task{ID: 3, UserID: f.alice}})
+	f.hub.catchUp(ctx)
+
+	tests := []struct {
+		name   string
+		client *Client
+		want   []int64
+	}{
+		{name: "owner sees personal and workspace task", client: alice, want: []int64{1, 2}},
+		{name: "member sees workspace task only", client: bob, want: []int64{2}},
+		{name: "outsider sees nothing", client: carol, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := received(tt.client)
+			if len(got) != len(tt.want) {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("events = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSinceReturnsVisibleEvents(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.publish(models.Task{ID: 1, UserID: f.alice})
+	f.publish(models.Task{ID: 2, UserID: f.alice, WorkspaceID: &f.workspaceID})
+	f.publish(models.Task{ID: 3, UserID: f.carol})
+
+	tests := []struct {
+		name   string
+		userID int
+		lastID int64
+		want   []int64
+	}{
+		{name: "owner", userID: f.alice, want: []int64{1, 2}},
+		{name: "member", userID: f.bob, want: []int64{2}},
+		{name: "other user", userID: f.carol, want: []int64{3}},
+		{name: "after last id", userID: f.alice, lastID: 1, want: []int64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, ok, err := f.hub.Since(ctx, tt.userID, tt.lastID)
+			if err != nil || !ok {
+				t.Fatalf("Since = %v, %v", ok, err)
+			}
+			if len(list) != len(tt.want) {
+				t.Fatalf("events = %d, want %v", len(list), tt.want)
+			}
+			for i, e := range list {
+				if e.ID != tt.want[i] {
+					t.Errorf("event %d = %d, want %d", i, e.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSinceReportsPrunedEvents(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.publish(models.Task{ID: 1, UserID: f.alice})
+	f.publish(models.Task{ID: 2, UserID: f.alice})
+	f.publish(models.Task{ID: 3, UserID: f.alice})
+	if _, err := f.db.Exec("DELETE FROM task_events WHERE id < 3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, err := f.hub.Since(ctx, f.alice, 1); err != nil || ok {
+		t.Errorf("Since after pruned event = %v, %v, want not ok", ok, err)
+	}
+	if list, ok, err := f.hub.Since(ctx, f.alice, 2); err != nil || !ok || len(list) != 1 {
+		t.Errorf("Since(2) = %d events, %v, %v, want 1 event", len(list), ok, err)
+	}
+}
+
+func TestBroadcastDropsSlowClient(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	slow := f.hub.Subscribe(f.alice)
+
+	for i := 1; i <= clientBuffer+1; i++ {
+		f.hub.broadcast(ctx, Event{ID: int64(i), Type: events.TaskUpdated, UserID: f.alice})
+	}
+
+	n := 0
+	for range slow.C {
+		n++
+	}
+	if n != clientBuffer {
+		t.Errorf("slow client got %d events before being dropped, want %d", n, clientBuffer)
+	}
+	f.hub.Unsubscribe(slow) // sudah diputus; tidak boleh panic
+}
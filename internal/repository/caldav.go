@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/ical"
+	"taskflow-api/internal/models"
+)
+
+// calDAVTaskName - nama resource task yang tidak dibuat lewat CalDAV
+var calDAVTaskName = regexp.MustCompile(`^task-([0-9]+)\.ics$`)
+
+// ReservedCalDAVName - nama berbentuk task-<id>.ics milik task yang tidak dibuat
+// lewat CalDAV; resource baru harus memilih nama lain
+func ReservedCalDAVName(name string) bool {
+	return calDAVTaskName.MatchString(name)
+}
+
+// CalDAVObject - task beserta nama resource dan UID-nya
+type CalDAVObject struct {
+	models.Task
+	Name string
+	UID  string
+}
+
+// CalDAVCalendar - isi satu kalender: task di ProjectID kalau diisi, selain itu
+// task berkategori Category
+type CalDAVCalendar struct {
+	Category  models.Category
+	ProjectID *int
+}
+
+// CalDAVChanges - hasil sync-collection
+type CalDAVChanges struct {
+	Watermark int64
+	// Changed - sync awal: isi kalender; selain itu semua task yang terlihat dan
+	// berubah sejak token, termasuk yang sudah pindah ke kalender lain
+	Changed []CalDAVObject
+	// Deleted - nama resource task yang dihapus sejak token
+	Deleted []string
+}
+
+// CalDAVRepository - task sebagai resource CalDAV
+type CalDAVRepository interface {
+	// Objects - task di kalender yang terlihat oleh user, urut ID
+	Objects(userID int, cal CalDAVCalendar) ([]CalDAVObject, error)
+	// Find - task di kalender dengan nama resource yang dipilih client saat
+	// membuat, atau task-<id>.ics. ErrNotFound kalau tidak ada.
+	Find(userID int, cal CalDAVCalendar, name string) (CalDAVObject, error)
+	// Changes - perubahan sejak token since (0 = sync awal), dengan watermark
+	// yang sama seperti SyncRepository.Changes
+	Changes(ctx context.Context, userID int, cal CalDAVCalendar, since int64) (CalDAVChanges, error)
+	// Watermark - token sync saat ini
+	Watermark() (int64, error)
+	// SetName - simpan nama resource dan UID pilihan client untuk task
+	SetName(taskID int, name, uid string) error
+}
+
+type sqlCalDAV struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewCalDAVRepository - CalDAVRepository di atas database SQL aplikasi
+func NewCalDAVRepository(db *sql.DB) CalDAVRepository {
+	return &sqlCalDAV{db: db, dialect: database.DialectOf(db)}
+}
+
+const calDAVSelect = "SELECT " + TaskColumns + ", o.name, o.uid FROM tasks LEFT JOIN caldav_objects o ON o.task_id = tasks.id"
+
+// calDAVScanner - ScanTask plus kolom nama dan UID dari caldav_objects
+type calDAVScanner struct {
+	row  RowScanner
+	name *sql.NullString
+	uid  *sql.NullString
+}
+
+func (s calDAVScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.name, s.uid)...)
+}
+
+func scanCalDAVObject(row RowScanner, o *CalDAVObject) error {
+	var name, uid sql.NullString
+	if err := ScanTask(calDAVScanner{row: row, name: &name, uid: &uid}, &o.Task); err != nil {
+		return err
+	}
+	o.Name, o.UID = name.String, uid.String
+	if !name.Valid {
+		o.Name = "task-" + strconv.Itoa(o.ID) + ".ics"
+		o.UID = ical.UID(o.ID)
+	}
+	return nil
+}
+
+func queryCalDAVObjects(q Queryer, query string, args ...interface{}) ([]CalDAVObject, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objects []CalDAVObject
+	for rows.Next() {
+		var o CalDAVObject
+		if err := scanCalDAVObject(rows, &o); err != nil {
+			return nil, err
+		}
+		objects = append(objects, o)
+	}
+	return objects, rows.Err()
+}
+
+// where - syarat SQL task di kalender ini; nilainya jadi $argPos
+func (cal CalDAVCalendar) where(argPos int) (string, interface{}) {
+	if cal.ProjectID != nil {
+		return " AND tasks.project_id = $" + strconv.Itoa(argPos), *cal.ProjectID
+	}
+	return " AND tasks.category = $" + strconv.Itoa(argPos), cal.Category
+}
+
+func (r *sqlCalDAV) Objects(userID int, cal CalDAVCalendar) ([]CalDAVObject, error) {
+	return calendarObjects(r.db, userID, cal)
+}
+
+func calendarObjects(q Queryer, userID int, cal CalDAVCalendar) ([]CalDAVObject, error) {
+	cond, arg := cal.where(2)
+	return queryCalDAVObjects(q, calDAVSelect+" WHERE "+authz.Visible("tasks", 1)+cond+" ORDER BY tasks.id", userID, arg)
+}
+
+func (r *sqlCalDAV) Find(userID int, cal CalDAVCalendar, name string) (CalDAVObject, error) {
+	taskID := 0
+	if m := calDAVTaskName.FindStringSubmatch(name); m != nil {
+		taskID, _ = strconv.Atoi(m[1])
+	}
+
+	cond, arg := cal.where(4)
+	var o CalDAVObject
+	err := scanCalDAVObject(r.db.QueryRow(
+		calDAVSelect+" WHERE "+authz.Visible("tasks", 1)+cond+
+			" AND (o.name = $2 OR (o.name IS NULL AND tasks.id = $3)) ORDER BY tasks.id LIMIT 1",
+		userID, name, taskID, arg,
+	), &o)
+	return o, notFound(err)
+}
+
+func (r *sqlCalDAV) Changes(ctx context.Context, userID int, cal CalDAVCalendar, since int64) (CalDAVChanges, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return CalDAVChanges{}, err
+	}
+	defer tx.Rollback()
+
+	var changes CalDAVChanges
+	if changes.Watermark, err = syncWatermark(tx, r.dialect); err != nil {
+		return CalDAVChanges{}, err
+	}
+
+	if since == 0 {
+		changes.Changed, err = calendarObjects(tx, userID, cal)
+		return changes, err
+	}
+
+	changes.Changed, err = queryCalDAVObjects(tx,
+		calDAVSelect+" WHERE "+authz.Visible("tasks", 1)+" AND tasks.change_seq >= $2 AND tasks.change_seq < $3"+
+			" ORDER BY tasks.change_seq, tasks.id",
+		userID, since, changes.Watermark,
+	)
+	if err != nil {
+		return CalDAVChanges{}, err
+	}
+
+	rows, err := tx.Query(
+		`SELECT COALESCE(o.name, 'task-' || t.task_id || '.ics') FROM task_tombstones t
+		 LEFT JOIN caldav_objects o ON o.task_id = t.task_id
+		 WHERE `+authz.Visible("t", 1)+` AND t.change_seq >= $2 AND t.change_seq < $3
+		 ORDER BY t.change_seq, t.task_id`,
+		userID, since, changes.Watermark,
+	)
+	if err != nil {
+		return CalDAVChanges{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return CalDAVChanges{}, err
+		}
+		changes.Deleted = append(changes.Deleted, name)
+	}
+	return changes, rows.Err()
+}
+
+func (r *sqlCalDAV) Watermark() (int64, error) {
+	return syncWatermark(r.db, r.dialect)
+}
+
+func (r *sqlCalDAV) SetName(taskID int, name, uid string) error {
+	_, err := r.db.Exec(
+		`INSERT INTO caldav_objects (task_id, name, uid) VALUES ($1, $2, $3)
+		 ON CONFLICT (task_id) DO UPDATE SET name = EXCLUDED.name, uid = EXCLUDED.uid`,
+		taskID, name, uid,
+	)
+	return err
+}
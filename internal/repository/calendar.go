@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+)
+
+// CalendarRepository - URL langganan iCalendar, satu per user
+type CalendarRepository interface {
+	// Get - ErrNotFound kalau user belum punya feed
+	Get(userID int) (models.CalendarFeed, error)
+	// Rotate - simpan token baru; token lama langsung tidak berlaku
+	Rotate(userID int, tokenHash string) (models.CalendarFeed, error)
+	// Delete - ErrNotFound kalau user belum punya feed
+	Delete(userID int) error
+	// Owner - pemilik feed dengan token hash; ErrNotFound kalau tidak dikenal
+	Owner(tokenHash string) (int, error)
+	// Touch - catat waktu akses terakhir
+	Touch(userID int) error
+	// Tasks - task dengan due date yang terlihat oleh user, urut due date
+	Tasks(userID int, f TaskFilter) ([]models.Task, error)
+}
+
+type sqlCalendars struct {
+	db *sql.DB
+}
+
+// NewCalendarRepository - CalendarRepository di atas database SQL aplikasi
+func NewCalendarRepository(db *sql.DB) CalendarRepository {
+	return &sqlCalendars{db: db}
+}
+
+func (r *sqlCalendars) Get(userID int) (models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.QueryRow(
+		"SELECT created_at, last_accessed_at FROM calendar_feeds WHERE user_id = $1", userID,
+	).Scan(&feed.CreatedAt, &feed.LastAccessedAt)
+	return feed, notFound(err)
+}
+
+func (r *sqlCalendars) Rotate(userID int, tokenHash string) (models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.QueryRow(
+		`INSERT INTO calendar_feeds (user_id, token_hash) VALUES ($1, $2)
+		 ON CONFLICT (user_id) DO UPDATE
+		 SET token_hash = EXCLUDED.token_hash, created_at = CURRENT_TIMESTAMP, last_accessed_at = NULL
+		 RETURNING created_at, last_accessed_at`,
+		userID, tokenHash,
+	).Scan(&feed.CreatedAt, &feed.LastAccessedAt)
+	return feed, err
+}
+
+func (r *sqlCalendars) Delete(userID int) error {
+	return execOne(r.db, "DELETE FROM calendar_feeds WHERE user_id = $1", userID)
+}
+
+func (r *sqlCalendars) Owner(tokenHash string) (int, error) {
+	var userID int
+	err := r.db.QueryRow("SELECT user_id FROM calendar_feeds WHERE token_hash = $1", tokenHash).Scan(&userID)
+	return userID, notFound(err)
+}
+
+func (r *sqlCalendars) Touch(userID int) error {
+	_, err := r.db.Exec("UPDATE calendar_feeds SET last_accessed_at = CURRENT_TIMESTAMP WHERE user_id = $1", userID)
+	return err
+}
+
+func (r *sqlCalendars) Tasks(userID int, f TaskFilter) ([]models.Task, error) {
+	query, args := FilterTasks(f, userID,
+		"SELECT "+TaskColumns+" FROM tasks WHERE "+authz.Visible("tasks", 1)+" AND due_date IS NOT NULL",
+		[]interface{}{userID},
+	)
+	query = HideArchived(f, query)
+	return QueryTasks(r.db, query+" ORDER BY due_date, id", args...)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+const appPasswordColumns = "id, name, last_used_at, created_at"
+
+func scanAppPassword(row RowScanner, p *models.AppPassword) error {
+	return row.Scan(&p.ID, &p.Name, &p.LastUsedAt, &p.CreatedAt)
+}
+
+// CredentialRepository - app password untuk client CalDAV dan ticket stream.
+// Keduanya hanya disimpan sebagai hash.
+type CredentialRepository interface {
+	// CreateAppPassword - password baru; Password di hasilnya kosong
+	CreateAppPassword(userID int, name, passwordHash string) (models.AppPassword, error)
+	// AppPasswords - app password milik user, terbaru dulu
+	AppPasswords(userID int) ([]models.AppPassword, error)
+	// DeleteAppPassword - ErrNotFound kalau bukan milik user
+	DeleteAppPassword(userID, id int) error
+	// CreateStreamTicket - simpan ticket dan bersihkan ticket yang sudah kadaluarsa
+	CreateStreamTicket(userID int, tokenHash string, expiresAt time.Time) error
+}
+
+type sqlCredentials struct {
+	db *sql.DB
+}
+
+// NewCredentialRepository - CredentialRepository di atas database SQL aplikasi
+func NewCredentialRepository(db *sql.DB) CredentialRepository {
+	return &sqlCredentials{db: db}
+}
+
+func (r *sqlCredentials) CreateAppPassword(userID int, name, passwordHash string) (models.AppPassword, error) {
+	var p models.AppPassword
+	err := scanAppPassword(r.db.QueryRow(
+		`INSERT INTO app_passwords (user_id, name, password_hash) VALUES ($1, $2, $3)
+		 RETURNING `+appPasswordColumns,
+		userID, name, passwordHash,
+	), &p)
+	return p, err
+}
+
+func (r *sqlCredentials) AppPasswords(userID int) ([]models.AppPassword, error) {
+	rows, err := r.db.Query(
+		"SELECT "+appPasswordColumns+" FROM app_passwords WHERE user_id = $1 ORDER BY created_at DESC, id DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	passwords := []models.AppPassword{}
+	for rows.Next() {
+		var p models.AppPassword
+		if err := scanAppPassword(rows, &p); err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, p)
+	}
+	return passwords, rows.Err()
+}
+
+func (r *sqlCredentials) DeleteAppPassword(userID, id int) error {
+	return execOne(r.db, "DELETE FROM app_passwords WHERE id = $1 AND user_id = $2", id, userID)
+}
+
+func (r *sqlCredentials) CreateStreamTicket(userID int, tokenHash string, expiresAt time.Time) error {
+	if _, err := r.db.Exec(
+		"INSERT INTO stream_tickets (token_hash, user_id, expires_at) VALUES ($1, $2, $3)",
+		tokenHash, userID, expiresAt,
+	); err != nil {
+		return err
+	}
+	// Ticket yang tidak pernah dipakai dibersihkan di sini; gagal membersihkan
+	// tidak membatalkan ticket baru
+	r.db.Exec("DELETE FROM stream_tickets WHERE expires_at <= $1", time.Now())
+	return nil
+}
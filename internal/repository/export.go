@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/export"
+	"taskflow-api/internal/models"
+)
+
+const exportColumns = "id, format, status, size, error, created_at, finished_at, expires_at"
+
+func scanExport(row RowScanner, e *models.ExportJob) error {
+	return row.Scan(&e.ID, &e.Format, &e.Status, &e.Size, &e.Error, &e.CreatedAt, &e.FinishedAt, &e.ExpiresAt)
+}
+
+// ExportRepository - export background dan jumlah data yang akan diekspor.
+// Pembuatan file-nya ada di export.Runner.
+type ExportRepository interface {
+	// TaskCount - jumlah task yang terlihat oleh user
+	TaskCount(userID int) (int, error)
+	// Running - job format ini yang masih pending/running; ErrNotFound kalau tidak ada
+	Running(userID int, format string) (models.ExportJob, error)
+	// Create - antrekan job baru; token download hanya disimpan sebagai hash
+	Create(userID int, format, tokenHash string, expiresAt time.Time) (models.ExportJob, error)
+	// List - job milik user yang belum kadaluarsa, terbaru dulu
+	List(userID int) ([]models.ExportJob, error)
+	// Get - ErrNotFound kalau job bukan milik user
+	Get(userID, id int) (models.ExportJob, error)
+	// ByToken - job dengan token download; ErrNotFound kalau tidak dikenal
+	ByToken(tokenHash string) (models.ExportJob, error)
+	// Copy - tulis isi file job yang sudah selesai ke w
+	Copy(ctx context.Context, w io.Writer, id int) error
+	// Source - data user untuk export yang di-stream langsung
+	Source(ctx context.Context, userID int) export.Source
+}
+
+type sqlExports struct {
+	db *sql.DB
+}
+
+// NewExportRepository - ExportRepository di atas database SQL aplikasi
+func NewExportRepository(db *sql.DB) ExportRepository {
+	return &sqlExports{db: db}
+}
+
+func (r *sqlExports) TaskCount(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM tasks WHERE "+authz.Visible("tasks", 1), userID).Scan(&count)
+	return count, err
+}
+
+func (r *sqlExports) Running(userID int, format string) (models.ExportJob, error) {
+	var job models.ExportJob
+	err := scanExport(r.db.QueryRow(
+		"SELECT "+exportColumns+" FROM exports WHERE user_id = $1 AND format = $2 AND status IN ($3, $4)",
+		userID, format, models.ExportPending, models.ExportRunning,
+	), &job)
+	return job, notFound(err)
+}
+
+func (r *sqlExports) Create(userID int, format, tokenHash string, expiresAt time.Time) (models.ExportJob, error) {
+	var job models.ExportJob
+	err := scanExport(r.db.QueryRow(
+		`INSERT INTO exports (user_id, format, token_hash, expires_at) VALUES ($1, $2, $3, $4)
+		 RETURNING `+exportColumns,
+		userID, format, tokenHash, expiresAt,
+	), &job)
+	return job, err
+}
+
+func (r *sqlExports) List(userID int) ([]models.ExportJob, error) {
+	rows, err := r.db.Query(
+		"SELECT "+exportColumns+" FROM exports WHERE user_id = $1 AND expires_at > $2 ORDER BY created_at DESC, id DESC",
+		userID, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.ExportJob{}
+	for rows.Next() {
+		var job models.ExportJob
+		if err := scanExport(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *sqlExports) Get(userID, id int) (models.ExportJob, error) {
+	var job models.ExportJob
+	err := scanExport(r.db.QueryRow(
+		"SELECT "+exportColumns+" FROM exports WHERE id = $1 AND user_id = $2", id, userID,
+	), &job)
+	return job, notFound(err)
+}
+
+func (r *sqlExports) ByToken(tokenHash string) (models.ExportJob, error) {
+	var job models.ExportJob
+	err := scanExport(r.db.QueryRow("SELECT "+exportColumns+" FROM exports WHERE token_hash = $1", tokenHash), &job)
+	return job, notFound(err)
+}
+
+func (r *sqlExports) Copy(ctx context.Context, w io.Writer, id int) error {
+	return export.Copy(ctx, r.db, w, id)
+}
+
+func (r *sqlExports) Source(ctx context.Context, userID int) export.Source {
+	return exportSource{ctx: ctx, db: r.db, userID: userID}
+}
+
+// ExportSource - export.SourceFunc untuk export background
+func ExportSource(db *sql.DB) export.SourceFunc {
+	return func(ctx context.Context, userID int) export.Source {
+		return exportSource{ctx: ctx, db: db, userID: userID}
+	}
+}
+
+// exportSource - export.Source dari database: semua yang terlihat oleh user,
+// plus data pribadinya (reminder, notifikasi, webhook tanpa secret)
+type exportSource struct {
+	ctx    context.Context
+	db     *sql.DB
+	userID int
+}
+
+func (s exportSource) Profile() (models.User, error) {
+	var user models.User
+	err := s.db.QueryRowContext(s.ctx,
+		"SELECT id, name, email, created_at, updated_at FROM users WHERE id = $1", s.userID,
+	).Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+	return user, err
+}
+
+func (s exportSource) Workspaces(fn func(models.Workspace) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var w models.Workspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.OwnerID, &w.Role, &w.CreatedAt, &w.UpdatedAt); err != nil {
+			return err
+		}
+		return fn(w)
+	}, `SELECT w.id, w.name, w.owner_id, m.role, w.created_at, w.updated_at
+	    FROM workspaces w JOIN workspace_members m ON m.workspace_id = w.id
+	    WHERE m.user_id = $1 ORDER BY w.id`)
+}
+
+func (s exportSource) Projects(fn func(models.Project) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var p models.Project
+		if err := ScanProject(rows, &p); err != nil {
+			return err
+		}
+		return fn(p)
+	}, "SELECT "+ProjectColumns+" FROM projects WHERE "+authz.Visible("projects", 1)+" ORDER BY workspace_id NULLS FIRST, id")
+}
+
+func (s exportSource) Statuses(fn func(models.TaskStatus) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var status models.TaskStatus
+		if err := ScanStatus(rows, &status); err != nil {
+			return err
+		}
+		return fn(status)
+	}, "SELECT "+StatusColumns+" FROM task_statuses WHERE "+authz.Visible("task_statuses", 1)+" ORDER BY workspace_id NULLS FIRST, project_id NULLS FIRST, position, id")
+}
+
+func (s exportSource) Tasks(fn func(models.Task) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var task models.Task
+		if err := ScanTask(rows, &task); err != nil {
+			return err
+		}
+		return fn(task)
+	}, "SELECT "+TaskColumns+" FROM tasks WHERE "+authz.Visible("tasks", 1)+
+		" ORDER BY workspace_id NULLS FIRST, project_id NULLS FIRST, status, position, id")
+}
+
+func (s exportSource) Reminders(fn func(models.TaskReminder) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var reminder models.TaskReminder
+		if err := ScanReminder(rows, &reminder); err != nil {
+			return err
+		}
+		return fn(reminder)
+	}, "SELECT "+ReminderColumns+" FROM task_reminders WHERE user_id = $1 ORDER BY id")
+}
+
+func (s exportSource) Notifications(fn func(models.Notification) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var n models.Notification
+		if err := ScanNotification(rows, &n); err != nil {
+			return err
+		}
+		return fn(n)
+	}, "SELECT "+NotificationColumns+" FROM notifications WHERE user_id = $1 ORDER BY id")
+}
+
+func (s exportSource) Webhooks(fn func(models.Webhook) error) error {
+	return s.each(func(rows *sql.Rows) error {
+		var w models.Webhook
+		if err := ScanWebhook(rows, &w); err != nil {
+			return err
+		}
+		return fn(w)
+	}, "SELECT "+WebhookColumns+" FROM webhooks WHERE user_id = $1 ORDER BY id")
+}
+
+// each - jalankan query dengan user ID sebagai $1 dan panggil scan per baris
+func (s exportSource) each(scan func(*sql.Rows) error, query string) error {
+	rows, err := s.db.QueryContext(s.ctx, query, s.userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
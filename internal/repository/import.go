@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+
+	"taskflow-api/internal/models"
+)
+
+const importJobColumns = `id, user_id, workspace_id, format, filename, status, total_rows, processed_rows,
+	imported_count, failed_count, error, created_at, started_at, finished_at`
+
+func scanImportJob(row RowScanner, j *models.ImportJob) error {
+	return row.Scan(&j.ID, &j.UserID, &j.WorkspaceID, &j.Format, &j.Filename, &j.Status, &j.TotalRows,
+		&j.ProcessedRows, &j.ImportedCount, &j.FailedCount, &j.Error, &j.CreatedAt, &j.StartedAt, &j.FinishedAt)
+}
+
+// ImportRepository - job import yang dibuat lewat API; pemrosesannya ada di
+// package importer
+type ImportRepository interface {
+	// Create - antrekan job untuk file data; job.UserID, WorkspaceID, Format,
+	// Filename dan TotalRows diambil dari job
+	Create(job models.ImportJob, data []byte, mapping *string) (models.ImportJob, error)
+	// List - 50 job terbaru milik user
+	List(userID int) ([]models.ImportJob, error)
+	// Get - job beserta error per baris; ErrNotFound kalau bukan milik user
+	Get(userID, id int) (models.ImportJob, error)
+}
+
+type sqlImports struct {
+	db *sql.DB
+}
+
+// NewImportRepository - ImportRepository di atas database SQL aplikasi
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &sqlImports{db: db}
+}
+
+func (r *sqlImports) Create(job models.ImportJob, data []byte, mapping *string) (models.ImportJob, error) {
+	var created models.ImportJob
+	err := scanImportJob(r.db.QueryRow(
+		`INSERT INTO import_jobs (user_id, workspace_id, format, filename, data, mapping, total_rows)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING `+importJobColumns,
+		job.UserID, job.WorkspaceID, job.Format, job.Filename, data, mapping, job.TotalRows,
+	), &created)
+	return created, err
+}
+
+func (r *sqlImports) List(userID int) ([]models.ImportJob, error) {
+	rows, err := r.db.Query(
+		"SELECT "+importJobColumns+" FROM import_jobs WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT 50",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.ImportJob{}
+	for rows.Next() {
+		var job models.ImportJob
+		if err := scanImportJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *sqlImports) Get(userID, id int) (models.ImportJob, error) {
+	var job models.ImportJob
+	err := scanImportJob(r.db.QueryRow(
+		"SELECT "+importJobColumns+" FROM import_jobs WHERE id = $1 AND user_id = $2",
+		id, userID,
+	), &job)
+	if err != nil {
+		return job, notFound(err)
+	}
+
+	rows, err := r.db.Query(
+		"SELECT row_number, message FROM import_errors WHERE job_id = $1 ORDER BY row_number, id",
+		job.ID,
+	)
+	if err != nil {
+		return job, err
+	}
+	defer rows.Close()
+
+	job.Errors = []models.ImportRowError{}
+	for rows.Next() {
+		var e models.ImportRowError
+		if err := rows.Scan(&e.Row, &e.Message); err != nil {
+			return job, err
+		}
+		job.Errors = append(job.Errors, e)
+	}
+	return job, rows.Err()
+}
@@ -0,0 +1,97 @@
+package memory
+
+import "taskflow-api/internal/authz"
+
+// Authorizer - aturan izin authz.Authorizer di atas data Store
+type Authorizer struct {
+	s *Store
+}
+
+func NewAuthorizer(s *Store) *Authorizer {
+	return &Authorizer{s: s}
+}
+
+// WorkspaceRole - role user di workspace, authz.ErrNotFound kalau bukan anggota
+func (a *Authorizer) WorkspaceRole(userID, workspaceID int) (authz.Role, error) {
+	a.s.mu.Lock()
+	defer a.s.mu.Unlock()
+	role := a.s.role(userID, workspaceID)
+	if role == "" {
+		return "", authz.ErrNotFound
+	}
+	return role, nil
+}
+
+func (a *Authorizer) Workspace(userID, workspaceID int, action authz.Action) (authz.Role, error) {
+	role, err := a.WorkspaceRole(userID, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	if !role.Can(action) {
+		return role, authz.ErrForbidden
+	}
+	return role, nil
+}
+
+func (a *Authorizer) Scope(userID int, workspaceID *int, action authz.Action) error {
+	if workspaceID == nil {
+		return nil
+	}
+	_, err := a.Workspace(userID, *workspaceID, action)
+	return err
+}
+
+func (a *Authorizer) Task(userID, taskID int, action authz.Action) (authz.Resource, error) {
+	a.s.mu.Lock()
+	t, ok := a.s.tasks[taskID]
+	a.s.mu.Unlock()
+	if !ok {
+		return authz.Resource{ID: taskID}, authz.ErrNotFound
+	}
+	res := authz.Resource{ID: t.ID, UserID: t.UserID, WorkspaceID: t.WorkspaceID}
+	return res, a.Check(userID, res, action)
+}
+
+func (a *Authorizer) Project(userID, projectID int, action authz.Action) (authz.Resource, error) {
+	a.s.mu.Lock()
+	p, ok := a.s.projects[projectID]
+	a.s.mu.Unlock()
+	if !ok {
+		return authz.Resource{ID: projectID}, authz.ErrNotFound
+	}
+	res := authz.Resource{ID: projectID, UserID: p.board.UserID, WorkspaceID: p.board.WorkspaceID}
+	return res, a.Check(userID, res, action)
+}
+
+func (a *Authorizer) Webhook(userID, webhookID int, action authz.Action) (authz.Resource, error) {
+	a.s.mu.Lock()
+	b, ok := a.s.webhooks[webhookID]
+	a.s.mu.Unlock()
+	if !ok {
+		return authz.Resource{ID: webhookID}, authz.ErrNotFound
+	}
+	res := authz.Resource{ID: webhookID, UserID: b.UserID, WorkspaceID: b.WorkspaceID}
+	return res, a.Check(userID, res, action)
+}
+
+// Check - sama dengan authz.Authorizer.Check
+func (a *Authorizer) Check(userID int, res authz.Resource, action authz.Action) error {
+	if res.WorkspaceID == nil {
+		if res.UserID == userID {
+			return nil
+		}
+		return authz.ErrNotFound
+	}
+
+	role, err := a.WorkspaceRole(userID, *res.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if !role.Can(action) {
+		return authz.ErrForbidden
+	}
+	if action == authz.ActionDelete && role == authz.RoleMember && res.UserID != userID {
+		return authz.ErrForbidden
+	}
+	return nil
+}
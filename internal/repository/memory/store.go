@@ -0,0 +1,198 @@
+// Package memory - implementasi repository dan authorizer di memori, untuk test
+// service dan handler tanpa database. Aturannya mengikuti implementasi SQL:
+// board, izin workspace, urutan list dan ErrNotFound.
+package memory
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+)
+
+// Store - data bersama TaskRepository, UserRepository dan Authorizer di memori
+type Store struct {
+	mu   sync.Mutex
+	txMu sync.Mutex // Transact dijalankan satu per satu, seperti lock baris di SQL
+
+	nextID      int
+	now         func() time.Time
+	users       map[int]storedUser
+	tasks       map[int]models.Task
+	statuses    map[string][]models.TaskStatus
+	projects    map[int]project
+	webhooks    map[int]repository.Board
+	members     map[int]map[int]authz.Role
+	rescheduled []int
+}
+
+type storedUser struct {
+	user     models.User
+	password string
+}
+
+type project struct {
+	board    repository.Board
+	archived bool
+}
+
+func NewStore() *Store {
+	return &Store{
+		now:      time.Now,
+		users:    map[int]storedUser{},
+		tasks:    map[int]models.Task{},
+		statuses: map[string][]models.TaskStatus{},
+		projects: map[int]project{},
+		webhooks: map[int]repository.Board{},
+		members:  map[int]map[int]authz.Role{},
+	}
+}
+
+// AddMember - jadikan user anggota workspace dengan role tertentu
+func (s *Store) AddMember(workspaceID, userID int, role authz.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members[workspaceID] == nil {
+		s.members[workspaceID] = map[int]authz.Role{}
+	}
+	s.members[workspaceID][userID] = role
+}
+
+// AddWorkspace - workspace baru beserta pemilik dan kolom board default-nya
+func (s *Store) AddWorkspace(ownerID int) int {
+	s.mu.Lock()
+	id := s.id()
+	s.mu.Unlock()
+
+	s.AddMember(id, ownerID, authz.RoleOwner)
+	s.SeedStatuses(repository.Board{UserID: ownerID, WorkspaceID: &id})
+	return id
+}
+
+// AddProject - project di board b
+func (s *Store) AddProject(b repository.Board, archived bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.id()
+	s.projects[id] = project{board: b, archived: archived}
+	return id
+}
+
+// AddWebhook - webhook di board b
+func (s *Store) AddWebhook(b repository.Board) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.id()
+	s.webhooks[id] = b
+	return id
+}
+
+// ArchiveProject - arsipkan project id
+func (s *Store) ArchiveProject(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.projects[id]
+	p.archived = true
+	s.projects[id] = p
+}
+
+// SeedStatuses - kolom board default untuk b; dengan b.ProjectID, project itu
+// mendapat status sendiri
+func (s *Store) SeedStatuses(b repository.Board) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seedStatuses(b)
+}
+
+// Rescheduled - ID task yang reminder-nya dihitung ulang, urut sesuai panggilan
+func (s *Store) Rescheduled() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.rescheduled...)
+}
+
+func (s *Store) seedStatuses(b repository.Board) {
+	var userID *int
+	if b.WorkspaceID == nil {
+		userID = &b.UserID
+	}
+	now := s.now()
+	for _, st := range models.DefaultStatuses() {
+		st.ID = s.id()
+		st.UserID = userID
+		st.WorkspaceID = b.WorkspaceID
+		st.ProjectID = b.ProjectID
+		st.CreatedAt = now
+		st.UpdatedAt = now
+		s.statuses[statusKey(b)] = append(s.statuses[statusKey(b)], st)
+	}
+}
+
+// id - ID berikutnya; dipakai bersama semua tabel supaya ID tidak pernah tertukar
+func (s *Store) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+// role - role user di workspace ("" kalau bukan anggota)
+func (s *Store) role(userID, workspaceID int) authz.Role {
+	return s.members[workspaceID][userID]
+}
+
+// visible - aturan yang sama dengan authz.Visible
+func (s *Store) visible(userID int, b repository.Board) bool {
+	if b.WorkspaceID == nil {
+		return b.UserID == userID
+	}
+	return s.role(userID, *b.WorkspaceID) != ""
+}
+
+// snapshot - salinan data yang bisa diubah di dalam Transact
+func (s *Store) snapshot() (map[int]models.Task, map[string][]models.TaskStatus) {
+	tasks := make(map[int]models.Task, len(s.tasks))
+	for id, t := range s.tasks {
+		tasks[id] = t
+	}
+	statuses := make(map[string][]models.TaskStatus, len(s.statuses))
+	for k, v := range s.statuses {
+		statuses[k] = append([]models.TaskStatus(nil), v...)
+	}
+	return tasks, statuses
+}
+
+func boardKey(b repository.Board) string {
+	if b.WorkspaceID != nil {
+		return "w" + strconv.Itoa(*b.WorkspaceID)
+	}
+	return "u" + strconv.Itoa(b.UserID)
+}
+
+// statusKey - key s.statuses untuk kolom milik b, tanpa fallback ke board
+func statusKey(b repository.Board) string {
+	if b.ProjectID != nil {
+		return "p" + strconv.Itoa(*b.ProjectID)
+	}
+	return boardKey(b)
+}
+
+// usesBoard - t memakai kolom board b, aturan yang sama dengan repository.BoardTasks
+func (s *Store) usesBoard(t models.Task, b repository.Board) bool {
+	if !sameBoard(boardOf(t), b) {
+		return false
+	}
+	if b.ProjectID != nil {
+		return t.ProjectID != nil && *t.ProjectID == *b.ProjectID
+	}
+	return t.ProjectID == nil || len(s.statuses[statusKey(repository.Board{ProjectID: t.ProjectID})]) == 0
+}
+
+func boardOf(t models.Task) repository.Board {
+	return repository.Board{UserID: t.UserID, WorkspaceID: t.WorkspaceID}
+}
+
+func sameBoard(a, b repository.Board) bool {
+	return boardKey(a) == boardKey(b)
+}
@@ -0,0 +1,432 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+)
+
+type tasks struct {
+	s    *Store
+	inTx bool
+}
+
+// NewTaskRepository - TaskRepository di atas s
+func NewTaskRepository(s *Store) repository.TaskRepository {
+	return &tasks{s: s}
+}
+
+// Transact - perubahan di fn dibatalkan kalau fn mengembalikan error
+func (r *tasks) Transact(fn func(tx repository.TaskRepository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	r.s.txMu.Lock()
+	defer r.s.txMu.Unlock()
+
+	r.s.mu.Lock()
+	savedTasks, savedStatuses := r.s.snapshot()
+	r.s.mu.Unlock()
+
+	if err := fn(&tasks{s: r.s, inTx: true}); err != nil {
+		r.s.mu.Lock()
+		r.s.tasks, r.s.statuses = savedTasks, savedStatuses
+		r.s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *tasks) Get(id int) (models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task, ok := r.s.tasks[id]
+	if !ok {
+		return models.Task{}, repository.ErrNotFound
+	}
+	return task, nil
+}
+
+// Lock - di memori cukup Get; Transact sudah berjalan satu per satu
+func (r *tasks) Lock(id int) (models.Task, error) {
+	return r.Get(id)
+}
+
+func (r *tasks) List(viewerID int, f repository.TaskFilter) ([]models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	list := r.filter(viewerID, f)
+	if f.Board != nil {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Position != list[j].Position {
+				return list[i].Position < list[j].Position
+			}
+			return newer(list[i], list[j])
+		})
+	} else {
+		sort.SliceStable(list, func(i, j int) bool { return newer(list[i], list[j]) })
+	}
+
+	if f.Limit > 0 {
+		if f.Offset >= len(list) {
+			return []models.Task{}, nil
+		}
+		list = list[f.Offset:]
+		if len(list) > f.Limit {
+			list = list[:f.Limit]
+		}
+	}
+	return list, nil
+}
+
+func (r *tasks) Stats(viewerID int, f repository.TaskFilter) (models.TaskStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stats := models.TaskStats{ByAssignee: []models.AssigneeStats{}}
+	byAssignee := map[int]*models.AssigneeStats{}
+	now := r.s.now()
+	for _, t := range r.filter(viewerID, f) {
+		overdue := !t.IsCompleted && t.DueDate != nil && t.DueDate.Before(now)
+
+		stats.Total++
+		if t.IsCompleted {
+			stats.Completed++
+		} else {
+			stats.Pending++
+		}
+		if t.Priority == models.PriorityHigh {
+			stats.HighPriority++
+		}
+		if overdue {
+			stats.Overdue++
+		}
+
+		key := 0
+		if t.AssigneeID != nil {
+			key = *t.AssigneeID
+		}
+		a := byAssignee[key]
+		if a == nil {
+			a = &models.AssigneeStats{AssigneeID: t.AssigneeID}
+			if t.AssigneeID != nil {
+				a.Name = r.s.users[*t.AssigneeID].user.Name
+			}
+			byAssignee[key] = a
+		}
+		a.Total++
+		if t.IsCompleted {
+			a.Completed++
+		} else {
+			a.Pending++
+		}
+		if overdue {
+			a.Overdue++
+		}
+	}
+
+	for _, a := range byAssignee {
+		stats.ByAssignee = append(stats.ByAssignee, *a)
+	}
+	sort.Slice(stats.ByAssignee, func(i, j int) bool {
+		if stats.ByAssignee[i].Total != stats.ByAssignee[j].Total {
+			return stats.ByAssignee[i].Total > stats.ByAssignee[j].Total
+		}
+		return stats.ByAssignee[i].Name < stats.ByAssignee[j].Name
+	})
+	return stats, nil
+}
+
+func (r *tasks) Create(task models.Task) (models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := r.s.now()
+	task.ID = r.s.id()
+	task.Version = 1
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	r.s.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *tasks) Update(task models.Task) (models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	current, ok := r.s.tasks[task.ID]
+	if !ok {
+		return models.Task{}, repository.ErrNotFound
+	}
+	// Kolom yang tidak bisa diubah lewat Update
+	task.UserID = current.UserID
+	task.WorkspaceID = current.WorkspaceID
+	task.CreatedAt = current.CreatedAt
+	task.Version = current.Version + 1
+	task.UpdatedAt = r.s.now()
+	r.s.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *tasks) Delete(id int) (models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	task, ok := r.s.tasks[id]
+	if !ok {
+		return models.Task{}, repository.ErrNotFound
+	}
+	delete(r.s.tasks, id)
+	return task, nil
+}
+
+func (r *tasks) Statuses(b repository.Board) ([]models.TaskStatus, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Project tanpa status sendiri memakai kolom board-nya
+	key := statusKey(b)
+	if len(r.s.statuses[key]) == 0 {
+		key = boardKey(b)
+	}
+	statuses := append([]models.TaskStatus{}, r.s.statuses[key]...)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Position != statuses[j].Position {
+			return statuses[i].Position < statuses[j].Position
+		}
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses, nil
+}
+
+func (r *tasks) LastPosition(b repository.Board, status string) (string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	last := ""
+	for _, t := range r.column(b, status, 0) {
+		if t.Position > last {
+			last = t.Position
+		}
+	}
+	return last, nil
+}
+
+// LockColumn - Transact sudah berjalan satu per satu; di luar Transact error
+// seperti repository SQL, supaya pemanggil yang lupa transaksi ketahuan
+func (r *tasks) LockColumn(b repository.Board, status string) error {
+	if !r.inTx {
+		return errors.New("memory: LockColumn outside a transaction")
+	}
+	return nil
+}
+
+func (r *tasks) Neighbours(b repository.Board, status string, taskID int, afterID, beforeID *int) (string, string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	column := r.column(b, status, taskID)
+	position := func(id int) (string, bool) {
+		for _, t := range column {
+			if t.ID == id {
+				return t.Position, true
+			}
+		}
+		return "", false
+	}
+
+	var lo, hi string
+	var ok bool
+	if afterID != nil {
+		if lo, ok = position(*afterID); !ok {
+			return "", "", repository.ErrNotFound
+		}
+	}
+	if beforeID != nil {
+		if hi, ok = position(*beforeID); !ok {
+			return "", "", repository.ErrNotFound
+		}
+	}
+
+	switch {
+	case afterID != nil && beforeID == nil:
+		// Tetangga bawah = task berikutnya setelah afterID
+		for _, t := range column {
+			if t.Position > lo && (hi == "" || t.Position < hi) {
+				hi = t.Position
+			}
+		}
+	case afterID == nil && beforeID != nil:
+		for _, t := range column {
+			if t.Position < hi && t.Position > lo {
+				lo = t.Position
+			}
+		}
+	case afterID == nil && beforeID == nil:
+		// Tanpa tetangga: taruh paling bawah
+		for _, t := range column {
+			if t.Position > lo {
+				lo = t.Position
+			}
+		}
+	}
+	return lo, hi, nil
+}
+
+func (r *tasks) Rebalance(b repository.Board, status string, taskID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	column := r.column(b, status, taskID)
+	sort.SliceStable(column, func(i, j int) bool {
+		if column[i].Position != column[j].Position {
+			return column[i].Position < column[j].Position
+		}
+		return newer(column[i], column[j])
+	})
+
+	positions, err := utils.NKeysBetween("", "", len(column))
+	if err != nil {
+		return err
+	}
+	for i, t := range column {
+		t.Position = positions[i]
+		r.s.tasks[t.ID] = t
+	}
+	return nil
+}
+
+func (r *tasks) CheckProject(b repository.Board, projectID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.projects[projectID]
+	if !ok || !sameBoard(p.board, b) {
+		return errors.New("Project not found")
+	}
+	if p.archived {
+		return errors.New("Project is archived")
+	}
+	return nil
+}
+
+func (r *tasks) RescheduleReminders(taskID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.rescheduled = append(r.s.rescheduled, taskID)
+	return nil
+}
+
+// column - task di satu kolom board selain exceptID
+func (r *tasks) column(b repository.Board, status string, exceptID int) []models.Task {
+	var column []models.Task
+	for _, t := range r.s.tasks {
+		if t.Status == status && t.ID != exceptID && sameBoard(boardOf(t), b) {
+			column = append(column, t)
+		}
+	}
+	return column
+}
+
+// filter - task yang cocok dengan f, dengan aturan yang sama seperti FilterTasks
+func (r *tasks) filter(viewerID int, f repository.TaskFilter) []models.Task {
+	list := []models.Task{}
+	for _, t := range r.s.tasks {
+		if f.Board != nil {
+			if !r.s.usesBoard(t, *f.Board) {
+				continue
+			}
+		} else if !r.s.visible(viewerID, boardOf(t)) {
+			continue
+		}
+		if r.matches(viewerID, f, t) {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+func (r *tasks) matches(viewerID int, f repository.TaskFilter, t models.Task) bool {
+	switch f.WorkspaceID {
+	case "":
+	case "personal":
+		if t.WorkspaceID != nil {
+			return false
+		}
+	default:
+		if !equalID(t.WorkspaceID, f.WorkspaceID, viewerID) {
+			return false
+		}
+	}
+
+	switch f.Assignee {
+	case "":
+	case "none":
+		if t.AssigneeID != nil {
+			return false
+		}
+	default:
+		if !equalID(t.AssigneeID, f.Assignee, viewerID) {
+			return false
+		}
+	}
+
+	if f.CreatedBy != "" && !equalID(&t.UserID, f.CreatedBy, viewerID) {
+		return false
+	}
+	if f.ProjectID != "" && !equalID(t.ProjectID, f.ProjectID, viewerID) {
+		return false
+	}
+	if f.Priority != "" && t.Priority != f.Priority {
+		return false
+	}
+	if f.Category != "" && t.Category != f.Category {
+		return false
+	}
+	if f.Status != "" && t.Status != f.Status {
+		return false
+	}
+	if f.IsCompleted != nil && t.IsCompleted != *f.IsCompleted {
+		return false
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		description := ""
+		if t.Description != nil {
+			description = *t.Description
+		}
+		if !strings.Contains(strings.ToLower(t.Title), search) && !strings.Contains(strings.ToLower(description), search) {
+			return false
+		}
+	}
+	if !f.IncludeArchived && t.ProjectID != nil && r.s.projects[*t.ProjectID].archived {
+		return false
+	}
+	return true
+}
+
+// equalID - id cocok dengan nilai filter: angka, atau "me" untuk viewer
+func equalID(id *int, value string, viewerID int) bool {
+	if id == nil {
+		return false
+	}
+	if value == "me" {
+		return *id == viewerID
+	}
+	return strconv.Itoa(*id) == value
+}
+
+// newer - urutan "terbaru dulu" seperti ORDER BY created_at DESC, id DESC
+func newer(a, b models.Task) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+)
+
+type users struct {
+	s *Store
+}
+
+// NewUserRepository - UserRepository di atas s
+func NewUserRepository(s *Store) repository.UserRepository {
+	return &users{s: s}
+}
+
+func (r *users) Get(id int) (models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		return models.User{}, repository.ErrNotFound
+	}
+	return u.user, nil
+}
+
+func (r *users) GetMany(ids []int) (map[int]models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	found := map[int]models.User{}
+	for _, id := range ids {
+		if u, ok := r.s.users[id]; ok {
+			found[id] = u.user
+		}
+	}
+	return found, nil
+}
+
+func (r *users) GetByEmail(email string) (models.User, string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, u := range r.s.users {
+		if u.user.Email == email {
+			return u.user, u.password, nil
+		}
+	}
+	return models.User{}, "", repository.ErrNotFound
+}
+
+func (r *users) EmailExists(email string) (bool, error) {
+	_, _, err := r.GetByEmail(email)
+	if err == repository.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *users) Create(name, email, passwordHash string) (models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := r.s.now()
+	user := models.User{ID: r.s.id(), Name: name, Email: email, CreatedAt: now, UpdatedAt: now}
+	r.s.users[user.ID] = storedUser{user: user, password: passwordHash}
+
+	// Board default untuk user baru
+	r.s.seedStatuses(repository.Board{UserID: user.ID})
+	return user, nil
+}
+
+func (r *users) BoardMembers(b repository.Board, emails []string) ([]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var ids []int
+	for _, email := range emails {
+		for id, u := range r.s.users {
+			if strings.ToLower(u.user.Email) == email && r.s.visible(id, b) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func (r *users) List() ([]models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	list := []models.User{}
+	for _, u := range r.s.users {
+		list = append(list, u.user)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (r *users) SetDisabled(id int, disabled bool) error {
+	return r.update(id, func(u *storedUser) {
+		if !disabled {
+			u.user.DisabledAt = nil
+		} else if u.user.DisabledAt == nil {
+			// Tanggal disable pertama dipertahankan
+			now := r.s.now()
+			u.user.DisabledAt = &now
+		}
+	})
+}
+
+func (r *users) SetPassword(id int, passwordHash string) error {
+	return r.update(id, func(u *storedUser) {
+		u.password = passwordHash
+	})
+}
+
+func (r *users) Delete(id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.users, id)
+
+	// Cascade seperti foreign key di database
+	for taskID, t := range r.s.tasks {
+		if t.UserID == id {
+			delete(r.s.tasks, taskID)
+		}
+	}
+	for _, members := range r.s.members {
+		delete(members, id)
+	}
+	return nil
+}
+
+// update - ubah user id di tempat; ErrNotFound kalau tidak ada
+func (r *users) update(id int, fn func(u *storedUser)) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	fn(&u)
+	u.user.UpdatedAt = r.s.now()
+	r.s.users[id] = u
+	return nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+
+	"taskflow-api/internal/models"
+)
+
+// NotificationColumns - kolom notifikasi, urutannya harus sama dengan ScanNotification
+const NotificationColumns = "id, user_id, type, title, COALESCE(body, ''), task_id, read_at, created_at"
+
+func ScanNotification(row RowScanner, n *models.Notification) error {
+	return row.Scan(&n.ID, &n.UserID, &n.Type, &n.Title, &n.Body, &n.TaskID, &n.ReadAt, &n.CreatedAt)
+}
+
+// NotificationFilter - filter inbox notifikasi
+type NotificationFilter struct {
+	Unread bool
+	Type   string
+	Limit  int
+	Offset int
+}
+
+// NotificationRepository - inbox notifikasi in-app dan preferensi channel user
+type NotificationRepository interface {
+	// List - notifikasi user yang cocok dengan filter, terbaru dulu
+	List(userID int, f NotificationFilter) ([]models.Notification, error)
+	UnreadCount(userID int) (int, error)
+	// MarkRead - ErrNotFound kalau notifikasi bukan milik user
+	MarkRead(userID, id int) (models.Notification, error)
+	// MarkAllRead - jumlah notifikasi yang baru ditandai
+	MarkAllRead(userID int) (int64, error)
+	// Delete - ErrNotFound kalau notifikasi bukan milik user
+	Delete(userID, id int) error
+
+	// Preferences - satu entry per jenis notifikasi, default semua channel aktif
+	Preferences(userID int) ([]models.NotificationPreference, error)
+	// UpdatePreferences - field nil tetap seperti sebelumnya; semua atau tidak sama sekali
+	UpdatePreferences(userID int, prefs []models.UpdatePreferenceRequest) error
+}
+
+type sqlNotifications struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository - NotificationRepository di atas database SQL aplikasi
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &sqlNotifications{db: db}
+}
+
+func (r *sqlNotifications) List(userID int, f NotificationFilter) ([]models.Notification, error) {
+	query := "SELECT " + NotificationColumns + " FROM notifications WHERE user_id = $1"
+	args := []interface{}{userID}
+
+	if f.Unread {
+		query += " AND read_at IS NULL"
+	}
+	if f.Type != "" {
+		args = append(args, f.Type)
+		query += " AND type = $" + strconv.Itoa(len(args))
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += " ORDER BY created_at DESC, id DESC LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		if err := ScanNotification(rows, &n); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *sqlNotifications) UnreadCount(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userID).Scan(&count)
+	return count, err
+}
+
+func (r *sqlNotifications) MarkRead(userID, id int) (models.Notification, error) {
+	var n models.Notification
+	err := ScanNotification(r.db.QueryRow(
+		`UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING `+NotificationColumns,
+		id, userID,
+	), &n)
+	return n, notFound(err)
+}
+
+func (r *sqlNotifications) MarkAllRead(userID int) (int64, error) {
+	result, err := r.db.Exec("UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND read_at IS NULL", userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *sqlNotifications) Delete(userID, id int) error {
+	return execOne(r.db, "DELETE FROM notifications WHERE id = $1 AND user_id = $2", id, userID)
+}
+
+func (r *sqlNotifications) Preferences(userID int) ([]models.NotificationPreference, error) {
+	stored := map[string]models.NotificationPreference{}
+
+	rows, err := r.db.Query("SELECT type, in_app, email, webhook FROM notification_preferences WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.NotificationPreference
+		if err := rows.Scan(&p.Type, &p.InApp, &p.Email, &p.Webhook); err != nil {
+			return nil, err
+		}
+		stored[p.Type] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prefs := make([]models.NotificationPreference, 0, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		p, ok := stored[t]
+		if !ok {
+			p = models.NotificationPreference{Type: t, InApp: true, Email: true, Webhook: true}
+		}
+		prefs = append(prefs, p)
+	}
+	return prefs, nil
+}
+
+func (r *sqlNotifications) UpdatePreferences(userID int, prefs []models.UpdatePreferenceRequest) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range prefs {
+		_, err := tx.Exec(
+			`INSERT INTO notification_preferences (user_id, type, in_app, email, webhook)
+			 VALUES ($1, $2, COALESCE($3, TRUE), COALESCE($4, TRUE), COALESCE($5, TRUE))
+			 ON CONFLICT (user_id, type) DO UPDATE SET
+			   in_app = COALESCE($3, notification_preferences.in_app),
+			   email = COALESCE($4, notification_preferences.email),
+			   webhook = COALESCE($5, notification_preferences.webhook)`,
+			userID, p.Type, p.InApp, p.Email, p.Webhook,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ProjectColumns - kolom project, urutannya harus sama dengan ScanProject
+const ProjectColumns = "id, user_id, workspace_id, name, description, color, is_archived, created_at, updated_at"
+
+func ScanProject(row RowScanner, project *models.Project) error {
+	return row.Scan(&project.ID, &project.UserID, &project.WorkspaceID, &project.Name, &project.Description,
+		&project.Color, &project.IsArchived, &project.CreatedAt, &project.UpdatedAt)
+}
+
+// ProjectFilter - filter list project, dengan nilai seperti query string GET /projects
+type ProjectFilter struct {
+	WorkspaceID     string // ID workspace, atau "personal" untuk project di luar workspace
+	IncludeArchived bool
+}
+
+// ProjectRepository - penyimpanan project
+type ProjectRepository interface {
+	// Create - project baru di board b
+	Create(b Board, name, description, color string) (models.Project, error)
+	// Get - ErrNotFound kalau project tidak ada
+	Get(id int) (models.Project, error)
+	// GetMany - project dengan ID di ids; ID yang tidak ada dilewati
+	GetMany(ids []int) (map[int]models.Project, error)
+	// List - project yang terlihat oleh viewer, urut nama
+	List(viewerID int, f ProjectFilter) ([]models.Project, error)
+	// Update - ubah field yang diisi di req; ErrNotFound kalau project tidak ada
+	Update(id int, req models.UpdateProjectRequest) (models.Project, error)
+	// Delete - task di dalamnya tidak dihapus, project_id-nya jadi NULL. Task
+	// dengan status yang hanya ada di kolom project pindah ke kolom board
+	// pertama yang sama open/selesai-nya.
+	Delete(id int) error
+
+	// Tasks - task di project yang cocok dengan filter, terbaru dulu
+	Tasks(projectID, viewerID int, f TaskFilter) ([]models.Task, error)
+	Stats(projectID int) (models.TaskStats, error)
+}
+
+type sqlProjects struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewProjectRepository - ProjectRepository di atas database SQL aplikasi
+func NewProjectRepository(db *sql.DB) ProjectRepository {
+	return &sqlProjects{db: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlProjects) Create(b Board, name, description, color string) (models.Project, error) {
+	var project models.Project
+	err := ScanProject(r.db.QueryRow(
+		`INSERT INTO projects (user_id, workspace_id, name, description, color)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING `+ProjectColumns,
+		b.UserID, b.WorkspaceID, name, description, color,
+	), &project)
+	return project, err
+}
+
+func (r *sqlProjects) Get(id int) (models.Project, error) {
+	var project models.Project
+	err := ScanProject(r.db.QueryRow("SELECT "+ProjectColumns+" FROM projects WHERE id = $1", id), &project)
+	return project, notFound(err)
+}
+
+func (r *sqlProjects) GetMany(ids []int) (map[int]models.Project, error) {
+	projects, err := queryProjects(r.db, "SELECT "+ProjectColumns+" FROM projects WHERE "+r.dialect.InArray("id", "$1"), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]models.Project, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+	return byID, nil
+}
+
+func (r *sqlProjects) List(viewerID int, f ProjectFilter) ([]models.Project, error) {
+	query := "SELECT " + ProjectColumns + " FROM projects WHERE " + authz.Visible("projects", 1)
+	args := []interface{}{viewerID}
+	if f.WorkspaceID == "personal" {
+		query += " AND workspace_id IS NULL"
+	} else if f.WorkspaceID != "" {
+		args = append(args, f.WorkspaceID)
+		query += " AND workspace_id = $" + strconv.Itoa(len(args))
+	}
+	if !f.IncludeArchived {
+		query += " AND is_archived = false"
+	}
+	return queryProjects(r.db, query+" ORDER BY name, id", args...)
+}
+
+func (r *sqlProjects) Update(id int, req models.UpdateProjectRequest) (models.Project, error) {
+	// Build dynamic update query
+	query := "UPDATE projects SET updated_at = CURRENT_TIMESTAMP"
+	args := []interface{}{}
+
+	if req.Name != nil {
+		args = append(args, *req.Name)
+		query += ", name = $" + strconv.Itoa(len(args))
+	}
+	if req.Description != nil {
+		args = append(args, *req.Description)
+		query += ", description = $" + strconv.Itoa(len(args))
+	}
+	if req.Color != nil {
+		args = append(args, *req.Color)
+		query += ", color = $" + strconv.Itoa(len(args))
+	}
+	if req.IsArchived != nil {
+		args = append(args, *req.IsArchived)
+		query += ", is_archived = $" + strconv.Itoa(len(args))
+	}
+
+	args = append(args, id)
+	query += " WHERE id = $" + strconv.Itoa(len(args)) + " RETURNING " + ProjectColumns
+
+	var project models.Project
+	err := ScanProject(r.db.QueryRow(query, args...), &project)
+	return project, notFound(err)
+}
+
+func (r *sqlProjects) Delete(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var project models.Project
+	err = ScanProject(tx.QueryRow("SELECT "+ProjectColumns+" FROM projects WHERE id = $1"+r.dialect.LockRows("FOR UPDATE"), id), &project)
+	if err != nil {
+		return notFound(err)
+	}
+
+	statuses, err := LoadStatuses(tx, Board{UserID: project.UserID, WorkspaceID: project.WorkspaceID})
+	if err != nil {
+		return err
+	}
+	keys := make([]string, len(statuses))
+	for i, st := range statuses {
+		keys[i] = st.Key
+	}
+	for _, terminal := range []bool{false, true} {
+		target, ok := models.FirstStatus(statuses, terminal)
+		if !ok {
+			continue
+		}
+		_, err := tx.Exec(
+			"UPDATE tasks SET status = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE project_id = $2 AND is_completed = $3 AND NOT "+r.dialect.InArray("status", "$4"),
+			target.Key, id, terminal, pq.Array(keys),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := execOne(tx, "DELETE FROM projects WHERE id = $1", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlProjects) Tasks(projectID, viewerID int, f TaskFilter) ([]models.Task, error) {
+	query, args := FilterTasks(f, viewerID,
+		"SELECT "+TaskColumns+" FROM tasks WHERE project_id = $1",
+		[]interface{}{projectID},
+	)
+	return QueryTasks(r.db, query+" ORDER BY created_at DESC, id DESC", args...)
+}
+
+func (r *sqlProjects) Stats(projectID int) (models.TaskStats, error) {
+	return TaskStats(r.db, "project_id = $1", projectID)
+}
+
+// queryProjects - jalankan query yang memilih ProjectColumns
+func queryProjects(q Queryer, query string, args ...interface{}) ([]models.Project, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		if err := ScanProject(rows, &project); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ReminderColumns - kolom reminder, urutannya harus sama dengan ScanReminder
+const ReminderColumns = "id, task_id, user_id, remind_at, offset_minutes, fire_at, channels, sent_at, last_error, created_at"
+
+func ScanReminder(row RowScanner, reminder *models.TaskReminder) error {
+	return row.Scan(&reminder.ID, &reminder.TaskID, &reminder.UserID, &reminder.RemindAt, &reminder.OffsetMinutes,
+		&reminder.FireAt, pq.Array(&reminder.Channels), &reminder.SentAt, &reminder.LastError, &reminder.CreatedAt)
+}
+
+// ReminderRepository - reminder task milik masing-masing user
+type ReminderRepository interface {
+	// DueDate - due date task (nil kalau tidak ada); ErrNotFound kalau task tidak ada
+	DueDate(taskID int) (*time.Time, error)
+	// Create - reminder di remindAt, atau offsetMinutes menit sebelum due date task
+	Create(taskID, userID int, remindAt *time.Time, offsetMinutes *int, channels []string) (models.TaskReminder, error)
+	// ForTask - reminder user untuk satu task termasuk yang sudah terkirim, yang paling dekat dulu
+	ForTask(taskID, userID int) ([]models.TaskReminder, error)
+	// Pending - reminder user yang belum terkirim, yang paling dekat dulu
+	Pending(userID int) ([]models.TaskReminder, error)
+	// Delete - ErrNotFound kalau reminder bukan milik user di task tersebut
+	Delete(taskID, userID, id int) error
+}
+
+type sqlReminders struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewReminderRepository - ReminderRepository di atas database SQL aplikasi
+func NewReminderRepository(db *sql.DB) ReminderRepository {
+	return &sqlReminders{db: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlReminders) DueDate(taskID int) (*time.Time, error) {
+	var dueDate *time.Time
+	err := r.db.QueryRow("SELECT due_date FROM tasks WHERE id = $1", taskID).Scan(&dueDate)
+	return dueDate, notFound(err)
+}
+
+func (r *sqlReminders) Create(taskID, userID int, remindAt *time.Time, offsetMinutes *int, channels []string) (models.TaskReminder, error) {
+	if channels == nil {
+		channels = []string{}
+	}
+
+	// fire_at dihitung dari due date untuk reminder berbasis offset
+	insert := r.dialect.Pick(
+		`INSERT INTO task_reminders (task_id, user_id, remind_at, offset_minutes, channels, fire_at)
+		 SELECT $1, $2, $3::timestamp, $4::integer, $5, COALESCE($3::timestamp, due_date - $4::integer * INTERVAL '1 minute')
+		 FROM tasks WHERE id = $1`,
+		`INSERT INTO task_reminders (task_id, user_id, remind_at, offset_minutes, channels, fire_at)
+		 SELECT $1, $2, $3, $4, $5, COALESCE($3, strftime('%Y-%m-%d %H:%M:%f', due_date, -$4 || ' minutes'))
+		 FROM tasks WHERE id = $1`,
+	)
+	var reminder models.TaskReminder
+	err := ScanReminder(r.db.QueryRow(insert+" RETURNING "+ReminderColumns,
+		taskID, userID, remindAt, offsetMinutes, pq.Array(channels),
+	), &reminder)
+	return reminder, notFound(err)
+}
+
+func (r *sqlReminders) ForTask(taskID, userID int) ([]models.TaskReminder, error) {
+	return r.query(
+		"SELECT "+ReminderColumns+" FROM task_reminders WHERE task_id = $1 AND user_id = $2 ORDER BY fire_at NULLS LAST, id",
+		taskID, userID,
+	)
+}
+
+func (r *sqlReminders) Pending(userID int) ([]models.TaskReminder, error) {
+	return r.query(
+		"SELECT "+ReminderColumns+" FROM task_reminders WHERE user_id = $1 AND sent_at IS NULL ORDER BY fire_at NULLS LAST, id",
+		userID,
+	)
+}
+
+func (r *sqlReminders) Delete(taskID, userID, id int) error {
+	return execOne(r.db,
+		"DELETE FROM task_reminders WHERE id = $1 AND task_id = $2 AND user_id = $3",
+		id, taskID, userID,
+	)
+}
+
+func (r *sqlReminders) query(query string, args ...interface{}) ([]models.TaskReminder, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []models.TaskReminder{}
+	for rows.Next() {
+		var reminder models.TaskReminder
+		if err := ScanReminder(rows, &reminder); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
@@ -0,0 +1,80 @@
+// Package repository - akses data task dan user di balik interface, supaya
+// logika di service bisa dipakai REST, GraphQL, gRPC dan job background, dan bisa
+// dites dengan implementasi di memori.
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+)
+
+// ErrNotFound - baris yang diminta tidak ada
+var ErrNotFound = errors.New("repository: not found")
+
+// Queryer - dipenuhi oleh *sql.DB dan *sql.Tx
+type Queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// RowScanner - dipenuhi oleh *sql.Row dan *sql.Rows
+type RowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Board - papan kanban: personal milik user, atau milik workspace. Dengan
+// ProjectID, board satu project di dalamnya: kolomnya status milik project itu
+// (kalau ada), sedangkan urutan task tetap per kolom di board user/workspace.
+type Board struct {
+	UserID      int
+	WorkspaceID *int
+	ProjectID   *int
+}
+
+// BoardOf - board tempat task/project res berada
+func BoardOf(res authz.Resource) Board {
+	return Board{UserID: res.UserID, WorkspaceID: res.WorkspaceID}
+}
+
+// Where - predicate untuk baris tasks/task_statuses/projects di board user/workspace
+// ini; ProjectID tidak ikut
+func (b Board) Where(args []interface{}) (string, []interface{}) {
+	if b.WorkspaceID != nil {
+		args = append(args, *b.WorkspaceID)
+		return "workspace_id = $" + strconv.Itoa(len(args)), args
+	}
+	args = append(args, b.UserID)
+	return "workspace_id IS NULL AND user_id = $" + strconv.Itoa(len(args)), args
+}
+
+// LockKey - kunci advisory lock untuk satu kolom di board
+func (b Board) LockKey(status string) string {
+	if b.WorkspaceID != nil {
+		return "board:w" + strconv.Itoa(*b.WorkspaceID) + ":" + status
+	}
+	return "board:u" + strconv.Itoa(b.UserID) + ":" + status
+}
+
+// notFound - sql.ErrNoRows sebagai ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// execOne - Exec yang mengembalikan ErrNotFound kalau tidak ada baris yang kena
+func execOne(q Queryer, query string, args ...interface{}) error {
+	result, err := q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+const shareColumns = "id, task_id, created_by, read_only, expires_at, revoked_at, last_accessed_at, created_at"
+
+func scanShare(row RowScanner, share *models.TaskShare) error {
+	return row.Scan(&share.ID, &share.TaskID, &share.CreatedBy, &share.ReadOnly,
+		&share.ExpiresAt, &share.RevokedAt, &share.LastAccessedAt, &share.CreatedAt)
+}
+
+// ShareRepository - share link publik untuk task
+type ShareRepository interface {
+	// Create - share link baru; token-nya hanya disimpan sebagai hash
+	Create(taskID, createdBy int, tokenHash string, readOnly bool, expiresAt *time.Time) (models.TaskShare, error)
+	// Active - share link task yang belum dicabut dan belum expired, terbaru dulu
+	Active(taskID int) ([]models.TaskShare, error)
+	// Revoke - ErrNotFound kalau tidak ada atau sudah dicabut
+	Revoke(taskID, id int) error
+	// ByToken - ErrNotFound kalau token tidak dikenal; status dicabut/expired dicek pemanggil
+	ByToken(tokenHash string) (models.TaskShare, error)
+	// Touch - catat waktu akses terakhir
+	Touch(id int) error
+	// PublicTask - field task yang boleh dilihat lewat share link
+	PublicTask(share models.TaskShare) (models.PublicTask, error)
+}
+
+type sqlShares struct {
+	db *sql.DB
+}
+
+// NewShareRepository - ShareRepository di atas database SQL aplikasi
+func NewShareRepository(db *sql.DB) ShareRepository {
+	return &sqlShares{db: db}
+}
+
+func (r *sqlShares) Create(taskID, createdBy int, tokenHash string, readOnly bool, expiresAt *time.Time) (models.TaskShare, error) {
+	var share models.TaskShare
+	err := scanShare(r.db.QueryRow(
+		`INSERT INTO task_shares (task_id, token_hash, created_by, read_only, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING `+shareColumns,
+		taskID, tokenHash, createdBy, readOnly, expiresAt,
+	), &share)
+	return share, err
+}
+
+func (r *sqlShares) Active(taskID int) ([]models.TaskShare, error) {
+	rows, err := r.db.Query(
+		`SELECT `+shareColumns+` FROM task_shares
+		 WHERE task_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $2)
+		 ORDER BY created_at DESC, id DESC`,
+		taskID, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []models.TaskShare{}
+	for rows.Next() {
+		var share models.TaskShare
+		if err := scanShare(rows, &share); err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+func (r *sqlShares) Revoke(taskID, id int) error {
+	return execOne(r.db,
+		"UPDATE task_shares SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND task_id = $2 AND revoked_at IS NULL",
+		id, taskID,
+	)
+}
+
+func (r *sqlShares) ByToken(tokenHash string) (models.TaskShare, error) {
+	var share models.TaskShare
+	err := scanShare(r.db.QueryRow("SELECT "+shareColumns+" FROM task_shares WHERE token_hash = $1", tokenHash), &share)
+	return share, notFound(err)
+}
+
+func (r *sqlShares) Touch(id int) error {
+	_, err := r.db.Exec("UPDATE task_shares SET last_accessed_at = CURRENT_TIMESTAMP WHERE id = $1", id)
+	return err
+}
+
+func (r *sqlShares) PublicTask(share models.TaskShare) (models.PublicTask, error) {
+	task := models.PublicTask{ReadOnly: share.ReadOnly}
+	err := r.db.QueryRow(
+		`SELECT title, description, priority, category, status, is_completed, due_date, updated_at
+		 FROM tasks WHERE id = $1`,
+		share.TaskID,
+	).Scan(&task.Title, &task.Description, &task.Priority, &task.Category, &task.Status,
+		&task.IsCompleted, &task.DueDate, &task.UpdatedAt)
+	return task, notFound(err)
+}
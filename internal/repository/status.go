@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/utils"
+)
+
+const StatusColumns = "id, user_id, workspace_id, project_id, key, name, position, is_terminal, created_at, updated_at"
+
+func ScanStatus(row RowScanner, s *models.TaskStatus) error {
+	return row.Scan(&s.ID, &s.UserID, &s.WorkspaceID, &s.ProjectID, &s.Key, &s.Name, &s.Position,
+		&s.IsTerminal, &s.CreatedAt, &s.UpdatedAt)
+}
+
+// LoadStatuses - ambil semua status di board, urut sesuai kolom. Board project
+// yang belum punya status sendiri memakai kolom board user/workspace-nya.
+func LoadStatuses(q Queryer, b Board) ([]models.TaskStatus, error) {
+	if b.ProjectID != nil {
+		statuses, err := queryStatuses(q, "project_id = $1", *b.ProjectID)
+		if err != nil || len(statuses) > 0 {
+			return statuses, err
+		}
+	}
+	scope, args := b.Where(nil)
+	return queryStatuses(q, scope+" AND project_id IS NULL", args...)
+}
+
+func queryStatuses(q Queryer, scope string, args ...interface{}) ([]models.TaskStatus, error) {
+	rows, err := q.Query(
+		"SELECT "+StatusColumns+" FROM task_statuses WHERE "+scope+" ORDER BY position, id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := []models.TaskStatus{}
+	for rows.Next() {
+		var s models.TaskStatus
+		if err := ScanStatus(rows, &s); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, rows.Err()
+}
+
+func InsertStatus(q Queryer, b Board, s models.TaskStatus) (models.TaskStatus, error) {
+	var userID *int
+	if b.WorkspaceID == nil {
+		userID = &b.UserID
+	}
+
+	var status models.TaskStatus
+	err := ScanStatus(q.QueryRow(
+		`INSERT INTO task_statuses (user_id, workspace_id, project_id, key, name, position, is_terminal)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING `+StatusColumns,
+		userID, b.WorkspaceID, b.ProjectID, s.Key, s.Name, s.Position, s.IsTerminal,
+	), &status)
+	return status, err
+}
+
+// SeedDefaultStatuses - buat kolom board default untuk user atau workspace baru
+func SeedDefaultStatuses(q Queryer, b Board) error {
+	for _, s := range models.DefaultStatuses() {
+		if _, err := InsertStatus(q, b, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusScope - predicate task_statuses milik board b: milik project b.ProjectID,
+// atau milik board user/workspace (project_id NULL)
+func statusScope(b Board, args []interface{}) (string, []interface{}) {
+	if b.ProjectID != nil {
+		args = append(args, *b.ProjectID)
+		return "project_id = $" + strconv.Itoa(len(args)), args
+	}
+	scope, args := b.Where(args)
+	return scope + " AND project_id IS NULL", args
+}
+
+// BoardTasks - predicate tasks yang memakai kolom board b: task di project
+// b.ProjectID, atau task board yang project-nya tidak punya status sendiri
+func BoardTasks(b Board, args []interface{}) (string, []interface{}) {
+	scope, args := b.Where(args)
+	if b.ProjectID != nil {
+		args = append(args, *b.ProjectID)
+		return scope + " AND project_id = $" + strconv.Itoa(len(args)), args
+	}
+	return scope + " AND (project_id IS NULL OR project_id NOT IN (SELECT project_id FROM task_statuses WHERE project_id IS NOT NULL))", args
+}
+
+// LastPosition - position terbesar di satu kolom ("" kalau kolom kosong)
+func LastPosition(q Queryer, b Board, status string) (string, error) {
+	var last string
+	scope, args := b.Where([]interface{}{status})
+	err := q.QueryRow(
+		"SELECT COALESCE(MAX(position), '') FROM tasks WHERE status = $1 AND "+scope,
+		args...,
+	).Scan(&last)
+	return last, err
+}
+
+// LockColumn - serialisasi perubahan urutan di satu kolom sampai transaksi q
+// selesai. SQLite tidak punya advisory lock; transaksinya sudah memegang write
+// lock seluruh database sejak BEGIN IMMEDIATE, jadi penulis lain menunggu.
+func LockColumn(q Queryer, d database.Dialect, b Board, status string) error {
+	if d == database.SQLite {
+		return nil
+	}
+	_, err := q.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", b.LockKey(status))
+	return err
+}
+
+// StatusRepository - kolom board. Perubahan yang membaca lalu menulis kolom
+// dijalankan di dalam Transact.
+type StatusRepository interface {
+	// Transact - jalankan fn dengan repository yang terikat ke satu transaksi;
+	// di-commit kalau fn tidak mengembalikan error
+	Transact(fn func(tx StatusRepository) error) error
+
+	// List - kolom board, urut; lihat LoadStatuses
+	List(b Board) ([]models.TaskStatus, error)
+	// Customize - project b.ProjectID mendapat salinan kolom board-nya kalau
+	// belum punya status sendiri; mengembalikan kolom project
+	Customize(b Board) ([]models.TaskStatus, error)
+	Create(b Board, s models.TaskStatus) (models.TaskStatus, error)
+	// Update - simpan name, position dan is_terminal; is_completed task di kolom
+	// ini ikut is_terminal-nya
+	Update(b Board, s models.TaskStatus) (models.TaskStatus, error)
+	// Delete - hapus kolom; task-nya harus sudah dipindah dengan MoveTasks
+	Delete(id int) error
+
+	// ColumnTasks - ID task di kolom key urut tampilan, terkunci sampai transaksi selesai
+	ColumnTasks(b Board, key string) ([]int, error)
+	// MoveTasks - pindahkan task ids (urut) ke paling bawah kolom target; kolom
+	// target dikunci seperti TaskRepository.LockColumn
+	MoveTasks(b Board, ids []int, target models.TaskStatus) error
+}
+
+type sqlStatuses struct {
+	db      *sql.DB // nil kalau sudah di dalam transaksi
+	q       Queryer
+	dialect database.Dialect
+}
+
+// NewStatusRepository - StatusRepository di atas database SQL aplikasi
+func NewStatusRepository(db *sql.DB) StatusRepository {
+	return &sqlStatuses{db: db, q: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlStatuses) Transact(fn func(tx StatusRepository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&sqlStatuses{q: tx, dialect: r.dialect}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlStatuses) List(b Board) ([]models.TaskStatus, error) {
+	return LoadStatuses(r.q, b)
+}
+
+func (r *sqlStatuses) Customize(b Board) ([]models.TaskStatus, error) {
+	statuses, err := LoadStatuses(r.q, b)
+	if err != nil || b.ProjectID == nil || (len(statuses) > 0 && statuses[0].ProjectID != nil) {
+		return statuses, err
+	}
+
+	copied := make([]models.TaskStatus, 0, len(statuses))
+	for _, st := range statuses {
+		created, err := InsertStatus(r.q, b, st)
+		if err != nil {
+			return nil, err
+		}
+		copied = append(copied, created)
+	}
+	return copied, nil
+}
+
+func (r *sqlStatuses) Create(b Board, s models.TaskStatus) (models.TaskStatus, error) {
+	return InsertStatus(r.q, b, s)
+}
+
+func (r *sqlStatuses) Update(b Board, s models.TaskStatus) (models.TaskStatus, error) {
+	var status models.TaskStatus
+	scope, args := statusScope(b, []interface{}{s.Name, s.Position, s.IsTerminal, s.ID})
+	err := ScanStatus(r.q.QueryRow(
+		`UPDATE task_statuses SET name = $1, position = $2, is_terminal = $3, updated_at = CURRENT_TIMESTAMP
+		 WHERE id = $4 AND `+scope+`
+		 RETURNING `+StatusColumns,
+		args...,
+	), &status)
+	if err != nil {
+		return status, notFound(err)
+	}
+
+	// is_completed selalu diturunkan dari status
+	scope, args = BoardTasks(b, []interface{}{status.IsTerminal, status.Key})
+	_, err = r.q.Exec(
+		"UPDATE tasks SET is_completed = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE status = $2 AND is_completed <> $1 AND "+scope,
+		args...,
+	)
+	return status, err
+}
+
+func (r *sqlStatuses) Delete(id int) error {
+	return execOne(r.q, "DELETE FROM task_statuses WHERE id = $1", id)
+}
+
+func (r *sqlStatuses) ColumnTasks(b Board, key string) ([]int, error) {
+	scope, args := BoardTasks(b, []interface{}{key})
+	rows, err := r.q.Query(
+		"SELECT id FROM tasks WHERE status = $1 AND "+scope+" ORDER BY position, created_at DESC"+r.dialect.LockRows("FOR UPDATE"),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *sqlStatuses) MoveTasks(b Board, ids []int, target models.TaskStatus) error {
+	if err := LockColumn(r.q, r.dialect, b, target.Key); err != nil {
+		return err
+	}
+	last, err := LastPosition(r.q, b, target.Key)
+	if err != nil {
+		return err
+	}
+	positions, err := utils.NKeysBetween(last, "", len(ids))
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		_, err := r.q.Exec(
+			"UPDATE tasks SET status = $1, position = $2, is_completed = $3, updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $4",
+			target.Key, positions[i], target.IsTerminal, id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
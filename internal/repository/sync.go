@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+)
+
+// SyncRepository - perubahan task untuk client offline
+type SyncRepository interface {
+	// Changes - task yang dibuat/diubah dan tombstone task yang dihapus sejak
+	// token since (0 = sync awal, tanpa tombstone), paling banyak sekitar limit
+	// perubahan per halaman. Task yang jadi terlihat atau tidak terlihat karena
+	// user masuk/keluar workspace ikut sebagai task atau tombstone.
+	Changes(ctx context.Context, userID int, since int64, limit int) (models.SyncChanges, error)
+	// Current - task yang terlihat oleh user; nil kalau tidak ada
+	Current(userID, taskID int) (*models.Task, error)
+	// WasDeleted - task pernah ada dan terlihat oleh user ini sebelum dihapus
+	WasDeleted(userID, taskID int) (bool, error)
+}
+
+type sqlSync struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewSyncRepository - SyncRepository di atas database SQL aplikasi
+func NewSyncRepository(db *sql.DB) SyncRepository {
+	return &sqlSync{db: db, dialect: database.DialectOf(db)}
+}
+
+// Token adalah watermark dari syncWatermark: perubahan dengan change_seq di
+// bawahnya tidak mungkin muncul belakangan. Perubahan di atasnya menunggu sync
+// berikutnya.
+func (r *sqlSync) Changes(ctx context.Context, userID int, since int64, limit int) (models.SyncChanges, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return models.SyncChanges{}, err
+	}
+	defer tx.Rollback()
+
+	watermark, err := syncWatermark(tx, r.dialect)
+	if err != nil {
+		return models.SyncChanges{}, err
+	}
+
+	upper, hasMore, err := syncPageEnd(tx, userID, since, watermark, limit)
+	if err != nil {
+		return models.SyncChanges{}, err
+	}
+
+	tasks, err := QueryTasks(tx,
+		"SELECT "+TaskColumns+" FROM tasks WHERE "+authz.Visible("tasks", 1)+
+			" AND change_seq >= $2 AND change_seq < $3 ORDER BY change_seq, id",
+		userID, since, upper,
+	)
+	if err != nil {
+		return models.SyncChanges{}, err
+	}
+
+	// Sync awal tidak punya data lokal yang perlu dihapus
+	deleted := []models.DeletedTask{}
+	if since > 0 {
+		if deleted, err = queryTombstones(tx, userID, since, upper); err != nil {
+			return models.SyncChanges{}, err
+		}
+		joined, left, err := membershipChanges(tx, userID, since, upper, watermark)
+		if err != nil {
+			return models.SyncChanges{}, err
+		}
+		tasks = append(tasks, joined...)
+		deleted = append(deleted, left...)
+	}
+
+	return models.SyncChanges{
+		Tasks:     tasks,
+		Deleted:   deleted,
+		NextToken: strconv.FormatInt(upper, 10),
+		HasMore:   hasMore,
+	}, nil
+}
+
+func (r *sqlSync) Current(userID, taskID int) (*models.Task, error) {
+	var task models.Task
+	err := ScanTask(r.db.QueryRow(
+		"SELECT "+TaskColumns+" FROM tasks WHERE id = $2 AND "+authz.Visible("tasks", 1),
+		userID, taskID,
+	), &task)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *sqlSync) WasDeleted(userID, taskID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM task_tombstones WHERE task_id = $2 AND "+authz.Visible("task_tombstones", 1)+")",
+		userID, taskID,
+	).Scan(&exists)
+	return exists, err
+}
+
+// syncWatermark - change_seq terkecil yang mungkin masih ditulis oleh transaksi
+// yang belum selesai. Di Postgres change_seq adalah id transaksi, dan semua
+// transaksi di bawah xmin snapshot sudah selesai. Di SQLite change_seq diambil
+// dari sync_counter oleh trigger (migrations/sqlite/014_task_sync.sql) dan hanya
+// ada satu penulis, jadi perubahan berikutnya pasti di atas nilai sekarang.
+func syncWatermark(q Queryer, d database.Dialect) (int64, error) {
+	var watermark int64
+	err := q.QueryRow(d.Pick(
+		"SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint",
+		"SELECT value + 1 FROM sync_counter",
+	)).Scan(&watermark)
+	return watermark, err
+}
+
+// syncPageEnd - batas atas (eksklusif) halaman ini. Perubahan satu transaksi
+// punya change_seq yang sama dan tidak pernah dipecah antar halaman, jadi
+// halaman bisa lebih dari limit kalau satu transaksi mengubah banyak task.
+func syncPageEnd(q Queryer, userID int, since, watermark int64, limit int) (int64, bool, error) {
+	if since >= watermark {
+		return since, false, nil
+	}
+
+	rows, err := q.Query(
+		`SELECT change_seq FROM (
+		     SELECT change_seq FROM tasks
+		     WHERE `+authz.Visible("tasks", 1)+` AND change_seq >= $2 AND change_seq < $3
+		     UNION ALL
+		     SELECT change_seq FROM task_tombstones
+		     WHERE `+authz.Visible("task_tombstones", 1)+` AND change_seq >= $2 AND change_seq < $3 AND $2 > 0
+		     UNION ALL
+		     SELECT change_seq FROM membership_changes
+		     WHERE user_id = $1 AND change_seq >= $2 AND change_seq < $3 AND $2 > 0
+		 ) changes ORDER BY change_seq LIMIT $4`,
+		userID, since, watermark, limit+1,
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	var seqs []int64
+	for rows.Next() {
+		var seq int64
+		if err := rows.Scan(&seq); err != nil {
+			return 0, false, err
+		}
+		seqs = append(seqs, seq)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, false, err
+	}
+
+	if len(seqs) <= limit {
+		return watermark, false, nil
+	}
+	end := seqs[limit]
+	if end == seqs[0] {
+		end++
+	}
+	return end, true, nil
+}
+
+// membershipChanges - akibat user masuk/keluar workspace di halaman [since, upper).
+// Workspace yang sekarang diikuti: task lama di sana (change_seq < since, yang
+// lebih baru sudah ada di halaman ini atau berikutnya). Workspace yang sekarang
+// tidak diikuti: semua task-nya, dan task yang dihapus di sana sejak since
+// (sampai watermark, karena tombstone-nya tidak lagi terlihat di halaman
+// berikutnya), dilaporkan sebagai dihapus.
+func membershipChanges(q Queryer, userID int, since, upper, watermark int64) ([]models.Task, []models.DeletedTask, error) {
+	rows, err := q.Query(
+		`SELECT c.workspace_id, c.changed_at,
+		        EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = c.workspace_id AND m.user_id = $1)
+		 FROM membership_changes c
+		 WHERE c.user_id = $1 AND c.change_seq >= $2 AND c.change_seq < $3
+		 ORDER BY c.change_seq, c.id`,
+		userID, since, upper,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Satu entri per workspace, dengan waktu perubahan terakhir
+	type change struct {
+		workspaceID int
+		changedAt   sql.NullTime
+		member      bool
+	}
+	var changes []change
+	index := map[int]int{}
+	for rows.Next() {
+		var c change
+		if err := rows.Scan(&c.workspaceID, &c.changedAt, &c.member); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		if i, ok := index[c.workspaceID]; ok {
+			changes[i] = c
+			continue
+		}
+		index[c.workspaceID] = len(changes)
+		changes = append(changes, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var joined []models.Task
+	var left []models.DeletedTask
+	for _, c := range changes {
+		if c.member {
+			tasks, err := QueryTasks(q,
+				"SELECT "+TaskColumns+" FROM tasks WHERE workspace_id = $1 AND change_seq < $2 ORDER BY change_seq, id",
+				c.workspaceID, since,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			joined = append(joined, tasks...)
+			continue
+		}
+
+		gone, err := queryDeleted(q, c.changedAt.Time,
+			`SELECT id FROM tasks WHERE workspace_id = $1
+			 UNION ALL
+			 SELECT task_id FROM task_tombstones WHERE workspace_id = $1 AND change_seq >= $2 AND change_seq < $3
+			 ORDER BY 1`,
+			c.workspaceID, since, watermark,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		left = append(left, gone...)
+	}
+	return joined, left, nil
+}
+
+// queryDeleted - ID task dari query sebagai tombstone dengan waktu at
+func queryDeleted(q Queryer, at time.Time, query string, args ...interface{}) ([]models.DeletedTask, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deleted []models.DeletedTask
+	for rows.Next() {
+		d := models.DeletedTask{DeletedAt: at}
+		if err := rows.Scan(&d.ID); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, d)
+	}
+	return deleted, rows.Err()
+}
+
+func queryTombstones(q Queryer, userID int, since, upper int64) ([]models.DeletedTask, error) {
+	rows, err := q.Query(
+		`SELECT task_id, deleted_at FROM task_tombstones
+		 WHERE `+authz.Visible("task_tombstones", 1)+` AND change_seq >= $2 AND change_seq < $3
+		 ORDER BY change_seq, task_id`,
+		userID, since, upper,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deleted := []models.DeletedTask{}
+	for rows.Next() {
+		var d models.DeletedTask
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&d.ID, &deletedAt); err != nil {
+			return nil, err
+		}
+		d.DeletedAt = deletedAt.Time
+		deleted = append(deleted, d)
+	}
+	return deleted, rows.Err()
+}
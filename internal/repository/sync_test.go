@@ -0,0 +1,151 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+)
+
+// syncFixture - workspace milik alice, bob belum anggota
+type syncFixture struct {
+	db          *sql.DB
+	sync        repository.SyncRepository
+	tasks       repository.TaskRepository
+	workspaces  repository.WorkspaceRepository
+	alice, bob  int
+	workspaceID int
+}
+
+func newSyncFixture(t *testing.T) *syncFixture {
+	t.Helper()
+	db := dbtest.Open(t)
+	f := &syncFixture{
+		db:         db,
+		sync:       repository.NewSyncRepository(db),
+		tasks:      repository.NewTaskRepository(db),
+		workspaces: repository.NewWorkspaceRepository(db),
+		alice:      dbtest.User(t, db, "alice@example.com"),
+		bob:        dbtest.User(t, db, "bob@example.com"),
+	}
+	ws, err := f.workspaces.Create("Team", f.alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.workspaceID = ws.ID
+	return f
+}
+
+func (f *syncFixture) task(t *testing.T, title string) int {
+	t.Helper()
+	task, err := f.tasks.Create(models.Task{
+		UserID: f.alice, WorkspaceID: &f.workspaceID, Title: title,
+		Priority: models.PriorityMedium, Category: models.CategoryWork, Status: models.StatusTodo, Position: "a0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return task.ID
+}
+
+func (f *syncFixture) join(t *testing.T, userID int) {
+	t.Helper()
+	if _, err := f.db.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, 'member')", f.workspaceID, userID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pull - perubahan untuk userID sejak token; kembalikan ID task, ID tombstone
+// (urut) dan token berikutnya
+func (f *syncFixture) pull(t *testing.T, userID int, since int64) ([]int, []int, int64) {
+	t.Helper()
+	changes, err := f.sync.Changes(context.Background(), userID, since, 100)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	tasks, deleted := []int{}, []int{}
+	for _, task := range changes.Tasks {
+		tasks = append(tasks, task.ID)
+	}
+	for _, d := range changes.Deleted {
+		deleted = append(deleted, d.ID)
+	}
+	sort.Ints(tasks)
+	sort.Ints(deleted)
+	next, err := strconv.ParseInt(changes.NextToken, 10, 64)
+	if err != nil {
+		t.Fatalf("next token %q: %v", changes.NextToken, err)
+	}
+	return tasks, deleted, next
+}
+
+func TestSyncMembershipChanges(t *testing.T) {
+	f := newSyncFixture(t)
+	old := f.task(t, "Before bob joined")
+
+	tasks, _, token := f.pull(t, f.bob, 0)
+	if len(tasks) != 0 {
+		t.Fatalf("initial sync for outsider = %v", tasks)
+	}
+
+	// Bergabung: task lama di workspace ikut terkirim walau tidak berubah
+	f.join(t, f.bob)
+	tasks, deleted, token := f.pull(t, f.bob, token)
+	if !reflect.DeepEqual(tasks, []int{old}) || len(deleted) != 0 {
+		t.Errorf("after join = %v deleted %v, want [%d]", tasks, deleted, old)
+	}
+	if tasks, deleted, _ := f.pull(t, f.bob, token); len(tasks) != 0 || len(deleted) != 0 {
+		t.Errorf("second pull after join = %v deleted %v, want nothing", tasks, deleted)
+	}
+
+	// Keluar: semua task workspace dilaporkan sebagai dihapus, termasuk yang
+	// dibuat dan dihapus sejak token terakhir
+	added := f.task(t, "While bob was a member")
+	removed := f.task(t, "Deleted after bob left")
+	if err := f.workspaces.RemoveMember(f.workspaceID, f.bob); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.tasks.Delete(removed); err != nil {
+		t.Fatal(err)
+	}
+	tasks, deleted, token = f.pull(t, f.bob, token)
+	want := []int{old, added, removed}
+	sort.Ints(want)
+	if len(tasks) != 0 || !reflect.DeepEqual(deleted, want) {
+		t.Errorf("after leaving = %v deleted %v, want deleted %v", tasks, deleted, want)
+	}
+	if tasks, deleted, _ := f.pull(t, f.bob, token); len(tasks) != 0 || len(deleted) != 0 {
+		t.Errorf("second pull after leaving = %v deleted %v, want nothing", tasks, deleted)
+	}
+
+	// Alice tidak terpengaruh
+	if _, deleted, _ := f.pull(t, f.alice, 1); !reflect.DeepEqual(deleted, []int{removed}) {
+		t.Errorf("owner tombstones = %v, want [%d]", deleted, removed)
+	}
+}
+
+func TestSyncWorkspaceDeleted(t *testing.T) {
+	f := newSyncFixture(t)
+	f.join(t, f.bob)
+	task := f.task(t, "Shared")
+
+	tasks, _, token := f.pull(t, f.bob, 0)
+	if !reflect.DeepEqual(tasks, []int{task}) {
+		t.Fatalf("initial sync = %v, want [%d]", tasks, task)
+	}
+
+	// Keanggotaan dan task terhapus lewat cascade; tombstone-nya tidak lagi
+	// terlihat lewat keanggotaan
+	if err := f.workspaces.Delete(f.workspaceID); err != nil {
+		t.Fatal(err)
+	}
+	if _, deleted, _ := f.pull(t, f.bob, token); !reflect.DeepEqual(deleted, []int{task}) {
+		t.Errorf("after workspace deleted = %v, want [%d]", deleted, task)
+	}
+}
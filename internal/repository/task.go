@@ -0,0 +1,489 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/utils"
+)
+
+// TaskColumns - kolom task yang dikembalikan oleh semua query, urutannya harus sama dengan ScanTask
+const TaskColumns = "id, user_id, workspace_id, project_id, assignee_id, title, description, priority, category, status, position, is_completed, due_date, version, created_at, updated_at"
+
+func ScanTask(row RowScanner, task *models.Task) error {
+	return row.Scan(&task.ID, &task.UserID, &task.WorkspaceID, &task.ProjectID, &task.AssigneeID, &task.Title,
+		&task.Description, &task.Priority, &task.Category, &task.Status, &task.Position,
+		&task.IsCompleted, &task.DueDate, &task.Version, &task.CreatedAt, &task.UpdatedAt)
+}
+
+// QueryTasks - jalankan query yang memilih TaskColumns
+func QueryTasks(q Queryer, query string, args ...interface{}) ([]models.Task, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		if err := ScanTask(rows, &task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// TaskFilter - filter list dan statistik task, dengan nilai seperti query string GET /tasks
+type TaskFilter struct {
+	WorkspaceID     string // ID workspace, atau "personal" untuk task di luar workspace
+	Assignee        string // ID user, "me" atau "none"
+	CreatedBy       string // ID user atau "me"
+	ProjectID       string
+	Priority        models.Priority
+	Category        models.Category
+	Status          string
+	IsCompleted     *bool
+	Search          string // dicari di judul dan deskripsi
+	IncludeArchived bool   // task di project yang diarsipkan ikut muncul
+
+	// Board - hanya task yang memakai kolom board ini (lihat BoardTasks), urut
+	// sesuai position. nil = semua task yang terlihat oleh viewer, terbaru dulu.
+	Board *Board
+
+	Limit  int // 0 = tanpa batas
+	Offset int
+}
+
+// TaskRepository - penyimpanan task. Perubahan yang membaca lalu menulis task
+// dijalankan di dalam Transact supaya Lock dan LockColumn berlaku sampai commit.
+type TaskRepository interface {
+	// Transact - jalankan fn dengan repository yang terikat ke satu transaksi;
+	// di-commit kalau fn tidak mengembalikan error
+	Transact(fn func(tx TaskRepository) error) error
+
+	// Get - ErrNotFound kalau task tidak ada
+	Get(id int) (models.Task, error)
+	// Lock - Get yang mengunci task sampai transaksi selesai
+	Lock(id int) (models.Task, error)
+	// List - task yang cocok dengan filter; viewerID dipakai untuk izin dan "me"
+	List(viewerID int, f TaskFilter) ([]models.Task, error)
+	Stats(viewerID int, f TaskFilter) (models.TaskStats, error)
+
+	// Create - simpan task baru; ID, version dan waktu diisi oleh repository
+	Create(task models.Task) (models.Task, error)
+	// Update - simpan field task yang bisa berubah dan naikkan version. Due date
+	// yang berubah membuat notifikasi due soon/overdue bisa dikirim lagi.
+	Update(task models.Task) (models.Task, error)
+	// Delete - hapus task dan kembalikan snapshot terakhirnya
+	Delete(id int) (models.Task, error)
+
+	// Statuses - kolom board, urut; lihat LoadStatuses
+	Statuses(b Board) ([]models.TaskStatus, error)
+	// LastPosition - position terbesar di satu kolom ("" kalau kolom kosong)
+	LastPosition(b Board, status string) (string, error)
+	// LockColumn - serialisasi perubahan urutan di satu kolom sampai transaksi selesai
+	LockColumn(b Board, status string) error
+	// Neighbours - position tetangga atas dan bawah untuk task yang ditaruh di antara
+	// afterID dan beforeID (keduanya nil = paling bawah). ErrNotFound kalau
+	// afterID/beforeID tidak ada di kolom itu.
+	Neighbours(b Board, status string, taskID int, afterID, beforeID *int) (string, string, error)
+	// Rebalance - beri position baru yang berurutan ke semua task di kolom
+	// (kecuali taskID), mempertahankan urutan tampilan sekarang
+	Rebalance(b Board, status string, taskID int) error
+
+	// CheckProject - task hanya bisa dimasukkan ke project aktif di board yang sama
+	CheckProject(b Board, projectID int) error
+	// RescheduleReminders - hitung ulang reminder berbasis offset setelah due date berubah
+	RescheduleReminders(taskID int) error
+}
+
+type sqlTasks struct {
+	db      *sql.DB // nil kalau sudah di dalam transaksi
+	q       Queryer
+	dialect database.Dialect
+}
+
+// NewTaskRepository - TaskRepository di atas database SQL aplikasi
+func NewTaskRepository(db *sql.DB) TaskRepository {
+	return &sqlTasks{db: db, q: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlTasks) Transact(fn func(tx TaskRepository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&sqlTasks{q: tx, dialect: r.dialect}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlTasks) Get(id int) (models.Task, error) {
+	var task models.Task
+	err := ScanTask(r.q.QueryRow("SELECT "+TaskColumns+" FROM tasks WHERE id = $1", id), &task)
+	return task, notFound(err)
+}
+
+func (r *sqlTasks) Lock(id int) (models.Task, error) {
+	var task models.Task
+	err := ScanTask(r.q.QueryRow("SELECT "+TaskColumns+" FROM tasks WHERE id = $1"+r.dialect.LockRows("FOR UPDATE"), id), &task)
+	return task, notFound(err)
+}
+
+func (r *sqlTasks) List(viewerID int, f TaskFilter) ([]models.Task, error) {
+	scope, args := r.scope(viewerID, f)
+	query, args := FilterTasks(f, viewerID, "SELECT "+TaskColumns+" FROM tasks WHERE "+scope, args)
+	query = HideArchived(f, query)
+
+	if f.Board != nil {
+		query += " ORDER BY position, created_at DESC"
+	} else {
+		query += " ORDER BY created_at DESC, id DESC"
+	}
+	if f.Limit > 0 {
+		args = append(args, f.Limit, f.Offset)
+		query += " LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+	}
+
+	return QueryTasks(r.q, query, args...)
+}
+
+func (r *sqlTasks) Stats(viewerID int, f TaskFilter) (models.TaskStats, error) {
+	scope, args := r.scope(viewerID, f)
+	scope, args = FilterTasks(f, viewerID, scope, args)
+	return TaskStats(r.q, HideArchived(f, scope), args...)
+}
+
+// scope - predicate dasar List/Stats: board di filter, atau task yang terlihat oleh viewer
+func (r *sqlTasks) scope(viewerID int, f TaskFilter) (string, []interface{}) {
+	if f.Board != nil {
+		return BoardTasks(*f.Board, nil)
+	}
+	return authz.Visible("tasks", 1), []interface{}{viewerID}
+}
+
+func (r *sqlTasks) Create(task models.Task) (models.Task, error) {
+	var created models.Task
+	err := ScanTask(r.q.QueryRow(
+		`INSERT INTO tasks (user_id, workspace_id, project_id, assignee_id, title, description, priority, category, status, position, is_completed, due_date)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 RETURNING `+TaskColumns,
+		task.UserID, task.WorkspaceID, task.ProjectID, task.AssigneeID, task.Title, task.Description, task.Priority, task.Category,
+		task.Status, task.Position, task.IsCompleted, task.DueDate,
+	), &created)
+	return created, err
+}
+
+func (r *sqlTasks) Update(task models.Task) (models.Task, error) {
+	var updated models.Task
+	err := ScanTask(r.q.QueryRow(
+		`UPDATE tasks SET title = $1, description = $2, project_id = $3, assignee_id = $4,
+		     priority = $5, category = $6, status = $7, position = $8, is_completed = $9, due_date = $10,
+		     due_notified_at = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_notified_at END,
+		     due_soon_notified_at = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_soon_notified_at END,
+		     due_attempts = CASE WHEN due_date IS DISTINCT FROM $10 THEN 0 ELSE due_attempts END,
+		     due_next_attempt_at = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_next_attempt_at END,
+		     due_pending_channels = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_pending_channels END,
+		     due_soon_attempts = CASE WHEN due_date IS DISTINCT FROM $10 THEN 0 ELSE due_soon_attempts END,
+		     due_soon_next_attempt_at = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_soon_next_attempt_at END,
+		     due_soon_pending_channels = CASE WHEN due_date IS DISTINCT FROM $10 THEN NULL ELSE due_soon_pending_channels END,
+		     updated_at = CURRENT_TIMESTAMP, version = version + 1
+		 WHERE id = $11
+		 RETURNING `+TaskColumns,
+		task.Title, task.Description, task.ProjectID, task.AssigneeID, task.Priority, task.Category,
+		task.Status, task.Position, task.IsCompleted, task.DueDate, task.ID,
+	), &updated)
+	return updated, notFound(err)
+}
+
+func (r *sqlTasks) Delete(id int) (models.Task, error) {
+	var task models.Task
+	err := ScanTask(r.q.QueryRow("DELETE FROM tasks WHERE id = $1 RETURNING "+TaskColumns, id), &task)
+	return task, notFound(err)
+}
+
+func (r *sqlTasks) Statuses(b Board) ([]models.TaskStatus, error) {
+	return LoadStatuses(r.q, b)
+}
+
+func (r *sqlTasks) LastPosition(b Board, status string) (string, error) {
+	return LastPosition(r.q, b, status)
+}
+
+func (r *sqlTasks) LockColumn(b Board, status string) error {
+	// Lock dilepas saat transaksi selesai; di luar transaksi tidak melindungi apa pun
+	if r.db != nil {
+		return errors.New("repository: LockColumn outside a transaction")
+	}
+	return LockColumn(r.q, r.dialect, b, status)
+}
+
+func (r *sqlTasks) Neighbours(b Board, status string, taskID int, afterID, beforeID *int) (string, string, error) {
+	var lo, hi string
+	scope, args := b.Where([]interface{}{status, taskID})
+	column := "status = $1 AND id <> $2 AND " + scope
+	anchor := "$" + strconv.Itoa(len(args)+1)
+
+	if afterID != nil {
+		err := r.q.QueryRow("SELECT position FROM tasks WHERE "+column+" AND id = "+anchor, append(args, *afterID)...).Scan(&lo)
+		if err != nil {
+			return "", "", notFound(err)
+		}
+	}
+	if beforeID != nil {
+		err := r.q.QueryRow("SELECT position FROM tasks WHERE "+column+" AND id = "+anchor, append(args, *beforeID)...).Scan(&hi)
+		if err != nil {
+			return "", "", notFound(err)
+		}
+	}
+
+	switch {
+	case afterID != nil && beforeID == nil:
+		// Tetangga bawah = task berikutnya setelah afterID
+		err := r.q.QueryRow(
+			"SELECT COALESCE(MIN(position), '') FROM tasks WHERE "+column+" AND position > "+anchor,
+			append(args, lo)...,
+		).Scan(&hi)
+		if err != nil {
+			return "", "", err
+		}
+	case afterID == nil && beforeID != nil:
+		err := r.q.QueryRow(
+			"SELECT COALESCE(MAX(position), '') FROM tasks WHERE "+column+" AND position < "+anchor,
+			append(args, hi)...,
+		).Scan(&lo)
+		if err != nil {
+			return "", "", err
+		}
+	case afterID == nil && beforeID == nil:
+		// Tanpa tetangga: taruh paling bawah
+		err := r.q.QueryRow("SELECT COALESCE(MAX(position), '') FROM tasks WHERE "+column, args...).Scan(&lo)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return lo, hi, nil
+}
+
+func (r *sqlTasks) Rebalance(b Board, status string, taskID int) error {
+	scope, args := b.Where([]interface{}{status, taskID})
+	rows, err := r.q.Query(
+		"SELECT id FROM tasks WHERE status = $1 AND id <> $2 AND "+scope+" ORDER BY position, created_at DESC"+r.dialect.LockRows("FOR UPDATE"),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	positions, err := utils.NKeysBetween("", "", len(ids))
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if _, err := r.q.Exec("UPDATE tasks SET position = $1 WHERE id = $2", positions[i], id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *sqlTasks) CheckProject(b Board, projectID int) error {
+	return CheckProjectWritable(r.q, b, projectID)
+}
+
+func (r *sqlTasks) RescheduleReminders(taskID int) error {
+	fireAt := r.dialect.Pick(
+		"t.due_date - r.offset_minutes * INTERVAL '1 minute'",
+		"strftime('%Y-%m-%d %H:%M:%f', t.due_date, -r.offset_minutes || ' minutes')",
+	)
+	// Reminder yang waktunya bergeser boleh dikirim lagi
+	_, err := r.q.Exec(
+		`UPDATE task_reminders AS r
+		 SET fire_at = `+fireAt+`, sent_at = NULL, last_error = NULL,
+		     attempts = 0, next_attempt_at = NULL, pending_channels = NULL
+		 FROM tasks AS t
+		 WHERE t.id = r.task_id AND r.task_id = $1 AND r.offset_minutes IS NOT NULL
+		   AND r.fire_at IS DISTINCT FROM `+fireAt,
+		taskID,
+	)
+	return err
+}
+
+// CheckProjectWritable - project harus ada di board b dan tidak diarsipkan.
+// Pesan error aman ditampilkan ke client.
+func CheckProjectWritable(q Queryer, b Board, projectID int) error {
+	var archived bool
+	scope, args := b.Where([]interface{}{projectID})
+	err := q.QueryRow("SELECT is_archived FROM projects WHERE id = $1 AND "+scope, args...).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return errors.New("Project not found")
+	}
+	if err != nil {
+		return errors.New("Failed to fetch project")
+	}
+	if archived {
+		return errors.New("Project is archived")
+	}
+	return nil
+}
+
+// FilterTasks - tambahkan kondisi filter f ke query task
+func FilterTasks(f TaskFilter, viewerID int, query string, args []interface{}) (string, []interface{}) {
+	// Filter by workspace ("personal" untuk task di luar workspace)
+	if f.WorkspaceID == "personal" {
+		query += " AND workspace_id IS NULL"
+	} else if f.WorkspaceID != "" {
+		args = append(args, f.WorkspaceID)
+		query += " AND workspace_id = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by assignee ("me", user ID, atau "none")
+	if f.Assignee == "me" {
+		args = append(args, viewerID)
+		query += " AND assignee_id = $" + strconv.Itoa(len(args))
+	} else if f.Assignee == "none" {
+		query += " AND assignee_id IS NULL"
+	} else if f.Assignee != "" {
+		args = append(args, f.Assignee)
+		query += " AND assignee_id = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by creator ("me" atau user ID)
+	if f.CreatedBy == "me" {
+		args = append(args, viewerID)
+		query += " AND user_id = $" + strconv.Itoa(len(args))
+	} else if f.CreatedBy != "" {
+		args = append(args, f.CreatedBy)
+		query += " AND user_id = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by project
+	if f.ProjectID != "" {
+		args = append(args, f.ProjectID)
+		query += " AND project_id = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by priority
+	if f.Priority != "" {
+		args = append(args, f.Priority)
+		query += " AND priority = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by category
+	if f.Category != "" {
+		args = append(args, f.Category)
+		query += " AND category = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by workflow status
+	if f.Status != "" {
+		args = append(args, f.Status)
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+
+	// Filter by completion status
+	if f.IsCompleted != nil {
+		args = append(args, *f.IsCompleted)
+		query += " AND is_completed = $" + strconv.Itoa(len(args))
+	}
+
+	// Search in title and description
+	if f.Search != "" {
+		args = append(args, "%"+f.Search+"%")
+		param := "LOWER($" + strconv.Itoa(len(args)) + ")"
+		query += " AND (LOWER(title) LIKE " + param + " OR LOWER(description) LIKE " + param + ")"
+	}
+
+	return query, args
+}
+
+// HideArchived - task di project yang diarsipkan tidak muncul kecuali
+// f.IncludeArchived
+func HideArchived(f TaskFilter, query string) string {
+	if f.IncludeArchived {
+		return query
+	}
+	return query + " AND (project_id IS NULL OR project_id NOT IN (SELECT id FROM projects WHERE is_archived = true))"
+}
+
+// TaskStats - hitung statistik untuk task yang cocok dengan kondisi scope
+func TaskStats(q Queryer, scope string, args ...interface{}) (models.TaskStats, error) {
+	var stats models.TaskStats
+	now := time.Now()
+	overdueArg := "$" + strconv.Itoa(len(args)+1)
+
+	counts := []struct {
+		dest *int
+		cond string
+		args []interface{}
+	}{
+		{&stats.Total, "", args},
+		{&stats.Completed, " AND is_completed = true", args},
+		{&stats.Pending, " AND is_completed = false", args},
+		{&stats.HighPriority, " AND priority = 'high'", args},
+		{&stats.Overdue, " AND is_completed = false AND due_date < " + overdueArg, append(args, now)},
+	}
+	for _, c := range counts {
+		if err := q.QueryRow("SELECT COUNT(*) FROM tasks WHERE "+scope+c.cond, c.args...).Scan(c.dest); err != nil {
+			return models.TaskStats{}, err
+		}
+	}
+
+	// Breakdown per assignee
+	stats.ByAssignee = []models.AssigneeStats{}
+	rows, err := q.Query(
+		`SELECT tasks.assignee_id, COALESCE(u.name, ''), COUNT(*),
+		     COUNT(*) FILTER (WHERE tasks.is_completed = true),
+		     COUNT(*) FILTER (WHERE tasks.is_completed = false),
+		     COUNT(*) FILTER (WHERE tasks.is_completed = false AND tasks.due_date < `+overdueArg+`)
+		 FROM tasks LEFT JOIN users u ON u.id = tasks.assignee_id
+		 WHERE `+scope+`
+		 GROUP BY tasks.assignee_id, u.name
+		 ORDER BY COUNT(*) DESC`,
+		append(args, now)...,
+	)
+	if err != nil {
+		return models.TaskStats{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a models.AssigneeStats
+		if err := rows.Scan(&a.AssigneeID, &a.Name, &a.Total, &a.Completed, &a.Pending, &a.Overdue); err != nil {
+			return models.TaskStats{}, err
+		}
+		stats.ByAssignee = append(stats.ByAssignee, a)
+	}
+	if err := rows.Err(); err != nil {
+		return models.TaskStats{}, err
+	}
+
+	return stats, nil
+}
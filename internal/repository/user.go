@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// UserColumns - kolom user tanpa password, urutannya harus sama dengan ScanUser
+const UserColumns = "id, name, email, created_at, updated_at, disabled_at"
+
+func ScanUser(row RowScanner, user *models.User) error {
+	return row.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.DisabledAt)
+}
+
+// UserRepository - penyimpanan user
+type UserRepository interface {
+	// Get - ErrNotFound kalau user tidak ada
+	Get(id int) (models.User, error)
+	// GetMany - user dengan ID di ids; ID yang tidak ada dilewati
+	GetMany(ids []int) (map[int]models.User, error)
+	// GetByEmail - user beserta hash password-nya, untuk login
+	GetByEmail(email string) (models.User, string, error)
+	EmailExists(email string) (bool, error)
+	// Create - simpan user baru beserta kolom board default-nya
+	Create(name, email, passwordHash string) (models.User, error)
+	// BoardMembers - ID user dengan email di emails yang bisa melihat board b
+	// (anggota workspace, atau pemilik board personal)
+	BoardMembers(b Board, emails []string) ([]int, error)
+	// List - semua user urut ID, untuk admin
+	List() ([]models.User, error)
+	// SetDisabled - nonaktifkan atau aktifkan lagi user; ErrNotFound kalau tidak ada
+	SetDisabled(id int, disabled bool) error
+	// SetPassword - ganti hash password; ErrNotFound kalau user tidak ada
+	SetPassword(id int, passwordHash string) error
+	// Delete - hapus user beserta semua datanya (cascade); ErrNotFound kalau tidak ada
+	Delete(id int) error
+}
+
+type sqlUsers struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewUserRepository - UserRepository di atas database SQL aplikasi
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &sqlUsers{db: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlUsers) Get(id int) (models.User, error) {
+	var user models.User
+	err := ScanUser(r.db.QueryRow("SELECT "+UserColumns+" FROM users WHERE id = $1", id), &user)
+	return user, notFound(err)
+}
+
+func (r *sqlUsers) GetMany(ids []int) (map[int]models.User, error) {
+	rows, err := r.db.Query("SELECT "+UserColumns+" FROM users WHERE "+r.dialect.InArray("id", "$1"), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := map[int]models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := ScanUser(rows, &user); err != nil {
+			return nil, err
+		}
+		users[user.ID] = user
+	}
+	return users, rows.Err()
+}
+
+func (r *sqlUsers) GetByEmail(email string) (models.User, string, error) {
+	var user models.User
+	var hashedPassword string
+	err := r.db.QueryRow(
+		"SELECT id, name, email, password, created_at, updated_at, disabled_at FROM users WHERE email = $1",
+		email,
+	).Scan(&user.ID, &user.Name, &user.Email, &hashedPassword, &user.CreatedAt, &user.UpdatedAt, &user.DisabledAt)
+	return user, hashedPassword, notFound(err)
+}
+
+func (r *sqlUsers) EmailExists(email string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", email).Scan(&exists)
+	return exists, err
+}
+
+func (r *sqlUsers) Create(name, email, passwordHash string) (models.User, error) {
+	var user models.User
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return user, err
+	}
+	defer tx.Rollback()
+
+	err = ScanUser(tx.QueryRow(
+		"INSERT INTO users (name, email, password) VALUES ($1, $2, $3) RETURNING "+UserColumns,
+		name, email, passwordHash,
+	), &user)
+	if err != nil {
+		return user, err
+	}
+
+	// Board default untuk user baru
+	if err := SeedDefaultStatuses(tx, Board{UserID: user.ID}); err != nil {
+		return user, err
+	}
+
+	return user, tx.Commit()
+}
+
+func (r *sqlUsers) BoardMembers(b Board, emails []string) ([]int, error) {
+	query := "SELECT id FROM users WHERE " + r.dialect.InArray("LOWER(email)", "$1") + " AND "
+	args := []interface{}{pq.Array(emails)}
+	if b.WorkspaceID != nil {
+		args = append(args, *b.WorkspaceID)
+		query += "id IN (SELECT user_id FROM workspace_members WHERE workspace_id = $2)"
+	} else {
+		args = append(args, b.UserID)
+		query += "id = $2"
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+func (r *sqlUsers) List() ([]models.User, error) {
+	rows, err := r.db.Query("SELECT " + UserColumns + " FROM users ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := ScanUser(rows, &user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *sqlUsers) SetDisabled(id int, disabled bool) error {
+	query := "UPDATE users SET disabled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+	if disabled {
+		// Tanggal disable pertama dipertahankan
+		query = "UPDATE users SET disabled_at = COALESCE(disabled_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP WHERE id = $1"
+	}
+	return execOne(r.db, query, id)
+}
+
+func (r *sqlUsers) SetPassword(id int, passwordHash string) error {
+	return execOne(r.db, "UPDATE users SET password = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", passwordHash, id)
+}
+
+func (r *sqlUsers) Delete(id int) error {
+	return execOne(r.db, "DELETE FROM users WHERE id = $1", id)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+	"strconv"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// WebhookColumns - kolom webhook tanpa secret, urutannya harus sama dengan ScanWebhook
+const WebhookColumns = "id, user_id, workspace_id, url, events, description, is_active, created_at, updated_at"
+
+// Kolom delivery untuk list; payload hanya di detail
+const (
+	deliverySummaryColumns = "id, webhook_id, event_type, '', status, attempts, next_attempt_at, last_attempt_at, response_status, error, redelivery_of, created_at"
+	deliveryColumns        = "id, webhook_id, event_type, payload, status, attempts, next_attempt_at, last_attempt_at, response_status, error, redelivery_of, created_at"
+)
+
+func ScanWebhook(row RowScanner, w *models.Webhook) error {
+	return row.Scan(&w.ID, &w.UserID, &w.WorkspaceID, &w.URL, pq.Array(&w.Events), &w.Description,
+		&w.IsActive, &w.CreatedAt, &w.UpdatedAt)
+}
+
+func scanDelivery(row RowScanner, d *models.WebhookDelivery) error {
+	return row.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastAttemptAt, &d.ResponseStatus, &d.Error, &d.RedeliveryOf, &d.CreatedAt)
+}
+
+// WebhookRepository - webhook dan log pengirimannya
+type WebhookRepository interface {
+	// Create - webhook baru di board b dengan secret penandatangan payload
+	Create(b Board, req models.CreateWebhookRequest, secret string) (models.Webhook, error)
+	// List - webhook personal milik user plus webhook workspace yang dia kelola,
+	// terbaru dulu. workspaceID seperti ProjectFilter.WorkspaceID.
+	List(userID int, workspaceID string) ([]models.Webhook, error)
+	// Get - ErrNotFound kalau webhook tidak ada
+	Get(id int) (models.Webhook, error)
+	// Update - ubah field yang diisi di req; ErrNotFound kalau webhook tidak ada
+	Update(id int, req models.UpdateWebhookRequest) (models.Webhook, error)
+	// Delete - log pengirimannya ikut terhapus
+	Delete(id int) error
+
+	// Deliveries - 100 pengiriman terbaru tanpa payload; status "" = semua
+	Deliveries(webhookID int, status string) ([]models.WebhookDelivery, error)
+	// Delivery - detail termasuk payload; ErrNotFound kalau bukan milik webhook
+	Delivery(webhookID, id int) (models.WebhookDelivery, error)
+	// Redeliver - antrekan ulang payload delivery id sebagai delivery baru
+	Redeliver(webhookID, id int) (models.WebhookDelivery, error)
+}
+
+type sqlWebhooks struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository - WebhookRepository di atas database SQL aplikasi
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &sqlWebhooks{db: db}
+}
+
+func (r *sqlWebhooks) Create(b Board, req models.CreateWebhookRequest, secret string) (models.Webhook, error) {
+	webhook := models.Webhook{Secret: secret}
+	err := ScanWebhook(r.db.QueryRow(
+		`INSERT INTO webhooks (user_id, workspace_id, url, secret, events, description)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING `+WebhookColumns,
+		b.UserID, b.WorkspaceID, req.URL, secret, pq.Array(req.Events), req.Description,
+	), &webhook)
+	return webhook, err
+}
+
+func (r *sqlWebhooks) List(userID int, workspaceID string) ([]models.Webhook, error) {
+	query := `SELECT ` + WebhookColumns + ` FROM webhooks
+		WHERE ((workspace_id IS NULL AND user_id = $1)
+		   OR workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = $1 AND role IN ($2, $3)))`
+	args := []interface{}{userID, authz.RoleOwner, authz.RoleAdmin}
+	if workspaceID == "personal" {
+		query += " AND workspace_id IS NULL"
+	} else if workspaceID != "" {
+		args = append(args, workspaceID)
+		query += " AND workspace_id = $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := r.db.Query(query+" ORDER BY created_at DESC, id DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var webhook models.Webhook
+		if err := ScanWebhook(rows, &webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *sqlWebhooks) Get(id int) (models.Webhook, error) {
+	var webhook models.Webhook
+	err := ScanWebhook(r.db.QueryRow("SELECT "+WebhookColumns+" FROM webhooks WHERE id = $1", id), &webhook)
+	return webhook, notFound(err)
+}
+
+func (r *sqlWebhooks) Update(id int, req models.UpdateWebhookRequest) (models.Webhook, error) {
+	// Build dynamic update query
+	query := "UPDATE webhooks SET updated_at = CURRENT_TIMESTAMP"
+	args := []interface{}{}
+
+	if req.URL != nil {
+		args = append(args, *req.URL)
+		query += ", url = $" + strconv.Itoa(len(args))
+	}
+	if req.Events != nil {
+		args = append(args, pq.Array(req.Events))
+		query += ", events = $" + strconv.Itoa(len(args))
+	}
+	if req.Description != nil {
+		args = append(args, *req.Description)
+		query += ", description = $" + strconv.Itoa(len(args))
+	}
+	if req.IsActive != nil {
+		args = append(args, *req.IsActive)
+		query += ", is_active = $" + strconv.Itoa(len(args))
+	}
+
+	args = append(args, id)
+	query += " WHERE id = $" + strconv.Itoa(len(args)) + " RETURNING " + WebhookColumns
+
+	var webhook models.Webhook
+	err := ScanWebhook(r.db.QueryRow(query, args...), &webhook)
+	return webhook, notFound(err)
+}
+
+func (r *sqlWebhooks) Delete(id int) error {
+	return execOne(r.db, "DELETE FROM webhooks WHERE id = $1", id)
+}
+
+func (r *sqlWebhooks) Deliveries(webhookID int, status string) ([]models.WebhookDelivery, error) {
+	query := "SELECT " + deliverySummaryColumns + " FROM webhook_deliveries WHERE webhook_id = $1"
+	args := []interface{}{webhookID}
+	if status != "" {
+		args = append(args, status)
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := r.db.Query(query+" ORDER BY created_at DESC, id DESC LIMIT 100", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := scanDelivery(rows, &delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *sqlWebhooks) Delivery(webhookID, id int) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := scanDelivery(r.db.QueryRow(
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2",
+		id, webhookID,
+	), &delivery)
+	return delivery, notFound(err)
+}
+
+func (r *sqlWebhooks) Redeliver(webhookID, id int) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := scanDelivery(r.db.QueryRow(
+		`INSERT INTO webhook_deliveries (webhook_id, event_type, payload, redelivery_of)
+		 SELECT webhook_id, event_type, payload, id FROM webhook_deliveries
+		 WHERE id = $1 AND webhook_id = $2
+		 RETURNING `+deliveryColumns,
+		id, webhookID,
+	), &delivery)
+	return delivery, notFound(err)
+}
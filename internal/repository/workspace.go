@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+)
+
+// WorkspaceRepository - workspace, anggota dan undangannya
+type WorkspaceRepository interface {
+	// Transact - jalankan fn dengan repository yang terikat ke satu transaksi;
+	// di-commit kalau fn tidak mengembalikan error
+	Transact(fn func(tx WorkspaceRepository) error) error
+
+	// Create - workspace baru dengan ownerID sebagai owner, beserta kolom board default-nya
+	Create(name string, ownerID int) (models.Workspace, error)
+	// List - workspace tempat user menjadi anggota beserta role-nya, urut nama
+	List(userID int) ([]models.Workspace, error)
+	// Get - ErrNotFound kalau workspace tidak ada; Role tidak diisi
+	Get(id int) (models.Workspace, error)
+	Rename(id int, name string) (models.Workspace, error)
+	// Delete - semua task dan project di workspace ikut terhapus
+	Delete(id int) error
+
+	// Members - anggota urut nama
+	Members(workspaceID int) ([]models.WorkspaceMember, error)
+	// IsMemberEmail - user dengan email ini (tidak peka huruf besar) sudah anggota
+	IsMemberEmail(workspaceID int, email string) (bool, error)
+	// SetMemberRole - ErrNotFound kalau bukan anggota atau owner
+	SetMemberRole(workspaceID, userID int, role string) error
+	// RemoveMember - hapus keanggotaan beserta assignment dan reminder mantan
+	// anggota di workspace. ErrNotFound kalau bukan anggota atau owner.
+	RemoveMember(workspaceID, userID int) error
+
+	// CreateInvitation - undangan baru; token-nya hanya disimpan sebagai hash
+	CreateInvitation(inv models.WorkspaceInvitation, tokenHash string) (models.WorkspaceInvitation, error)
+	// Invitations - undangan yang belum dipakai dan belum kedaluwarsa di workspace
+	Invitations(workspaceID int) ([]models.WorkspaceInvitation, error)
+	// InvitationsFor - undangan yang masih berlaku untuk email user
+	InvitationsFor(userID int) ([]models.WorkspaceInvitation, error)
+	// RevokeInvitation - ErrNotFound kalau tidak ada atau sudah dipakai
+	RevokeInvitation(workspaceID, id int) error
+	// LockInvitation - undangan dengan token hash, terkunci sampai transaksi selesai
+	LockInvitation(tokenHash string) (models.WorkspaceInvitation, error)
+	// AcceptInvitation - jadikan user anggota (kalau belum) dan tandai undangan terpakai
+	AcceptInvitation(inv models.WorkspaceInvitation, userID int) error
+}
+
+type sqlWorkspaces struct {
+	db      *sql.DB // nil kalau sudah di dalam transaksi
+	q       Queryer
+	dialect database.Dialect
+}
+
+// NewWorkspaceRepository - WorkspaceRepository di atas database SQL aplikasi
+func NewWorkspaceRepository(db *sql.DB) WorkspaceRepository {
+	return &sqlWorkspaces{db: db, q: db, dialect: database.DialectOf(db)}
+}
+
+func (r *sqlWorkspaces) Transact(fn func(tx WorkspaceRepository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&sqlWorkspaces{q: tx, dialect: r.dialect}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlWorkspaces) Create(name string, ownerID int) (models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.Transact(func(tx WorkspaceRepository) error {
+		q := tx.(*sqlWorkspaces).q
+		workspace = models.Workspace{Role: string(authz.RoleOwner)}
+		err := q.QueryRow(
+			"INSERT INTO workspaces (name, owner_id) VALUES ($1, $2) RETURNING id, name, owner_id, created_at, updated_at",
+			name, ownerID,
+		).Scan(&workspace.ID, &workspace.Name, &workspace.OwnerID, &workspace.CreatedAt, &workspace.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		// Pembuat workspace otomatis jadi owner
+		if _, err := q.Exec(
+			"INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)",
+			workspace.ID, ownerID, authz.RoleOwner,
+		); err != nil {
+			return err
+		}
+		return SeedDefaultStatuses(q, Board{UserID: ownerID, WorkspaceID: &workspace.ID})
+	})
+	return workspace, err
+}
+
+func (r *sqlWorkspaces) List(userID int) ([]models.Workspace, error) {
+	rows, err := r.q.Query(
+		`SELECT w.id, w.name, w.owner_id, m.role, w.created_at, w.updated_at
+		 FROM workspaces w JOIN workspace_members m ON m.workspace_id = w.id
+		 WHERE m.user_id = $1 ORDER BY w.name, w.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []models.Workspace{}
+	for rows.Next() {
+		var w models.Workspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.OwnerID, &w.Role, &w.CreatedAt, &w.UpdatedAt); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, w)
+	}
+	return workspaces, rows.Err()
+}
+
+func (r *sqlWorkspaces) Get(id int) (models.Workspace, error) {
+	var w models.Workspace
+	err := r.q.QueryRow(
+		"SELECT id, name, owner_id, created_at, updated_at FROM workspaces WHERE id = $1", id,
+	).Scan(&w.ID, &w.Name, &w.OwnerID, &w.CreatedAt, &w.UpdatedAt)
+	return w, notFound(err)
+}
+
+func (r *sqlWorkspaces) Rename(id int, name string) (models.Workspace, error) {
+	var w models.Workspace
+	err := r.q.QueryRow(
+		`UPDATE workspaces SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2
+		 RETURNING id, name, owner_id, created_at, updated_at`,
+		name, id,
+	).Scan(&w.ID, &w.Name, &w.OwnerID, &w.CreatedAt, &w.UpdatedAt)
+	return w, notFound(err)
+}
+
+func (r *sqlWorkspaces) Delete(id int) error {
+	return execOne(r.q, "DELETE FROM workspaces WHERE id = $1", id)
+}
+
+func (r *sqlWorkspaces) Members(workspaceID int) ([]models.WorkspaceMember, error) {
+	rows, err := r.q.Query(
+		`SELECT u.id, u.name, u.email, m.role, m.created_at
+		 FROM workspace_members m JOIN users u ON u.id = m.user_id
+		 WHERE m.workspace_id = $1 ORDER BY u.name, u.id`,
+		workspaceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.WorkspaceMember{}
+	for rows.Next() {
+		var m models.WorkspaceMember
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *sqlWorkspaces) IsMemberEmail(workspaceID int, email string) (bool, error) {
+	var exists bool
+	err := r.q.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM workspace_members m JOIN users u ON u.id = m.user_id
+		 WHERE m.workspace_id = $1 AND LOWER(u.email) = LOWER($2))`,
+		workspaceID, email,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *sqlWorkspaces) SetMemberRole(workspaceID, userID int, role string) error {
+	return execOne(r.q,
+		"UPDATE workspace_members SET role = $1 WHERE workspace_id = $2 AND user_id = $3 AND role <> $4",
+		role, workspaceID, userID, authz.RoleOwner,
+	)
+}
+
+func (r *sqlWorkspaces) RemoveMember(workspaceID, userID int) error {
+	return r.Transact(func(tx WorkspaceRepository) error {
+		q := tx.(*sqlWorkspaces).q
+		if err := execOne(q,
+			"DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2 AND role <> $3",
+			workspaceID, userID, authz.RoleOwner,
+		); err != nil {
+			return err
+		}
+
+		// Task yang di-assign ke mantan anggota jadi unassigned
+		if _, err := q.Exec(
+			"UPDATE tasks SET assignee_id = NULL, version = version + 1 WHERE workspace_id = $1 AND assignee_id = $2",
+			workspaceID, userID,
+		); err != nil {
+			return err
+		}
+		_, err := q.Exec(
+			"DELETE FROM task_reminders WHERE user_id = $1 AND task_id IN (SELECT id FROM tasks WHERE workspace_id = $2)",
+			userID, workspaceID,
+		)
+		return err
+	})
+}
+
+func (r *sqlWorkspaces) CreateInvitation(inv models.WorkspaceInvitation, tokenHash string) (models.WorkspaceInvitation, error) {
+	created := models.WorkspaceInvitation{Token: inv.Token}
+	err := r.q.QueryRow(
+		`INSERT INTO workspace_invitations (workspace_id, email, role, token_hash, invited_by, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, workspace_id, email, role, invited_by, expires_at, created_at`,
+		inv.WorkspaceID, strings.ToLower(inv.Email), inv.Role, tokenHash, inv.InvitedBy, inv.ExpiresAt,
+	).Scan(&created.ID, &created.WorkspaceID, &created.Email, &created.Role,
+		&created.InvitedBy, &created.ExpiresAt, &created.CreatedAt)
+	return created, err
+}
+
+func (r *sqlWorkspaces) Invitations(workspaceID int) ([]models.WorkspaceInvitation, error) {
+	return r.queryInvitations(
+		"i.workspace_id = $1 AND i.accepted_at IS NULL AND i.expires_at > $2", workspaceID, time.Now(),
+	)
+}
+
+func (r *sqlWorkspaces) InvitationsFor(userID int) ([]models.WorkspaceInvitation, error) {
+	return r.queryInvitations(
+		`LOWER(i.email) = (SELECT LOWER(email) FROM users WHERE id = $1)
+		 AND i.accepted_at IS NULL AND i.expires_at > $2`,
+		userID, time.Now(),
+	)
+}
+
+func (r *sqlWorkspaces) RevokeInvitation(workspaceID, id int) error {
+	return execOne(r.q,
+		"DELETE FROM workspace_invitations WHERE id = $1 AND workspace_id = $2 AND accepted_at IS NULL",
+		id, workspaceID,
+	)
+}
+
+func (r *sqlWorkspaces) LockInvitation(tokenHash string) (models.WorkspaceInvitation, error) {
+	var i models.WorkspaceInvitation
+	err := r.q.QueryRow(
+		`SELECT id, workspace_id, email, role, invited_by, expires_at, accepted_at, created_at
+		 FROM workspace_invitations WHERE token_hash = $1`+r.dialect.LockRows("FOR UPDATE"),
+		tokenHash,
+	).Scan(&i.ID, &i.WorkspaceID, &i.Email, &i.Role, &i.InvitedBy, &i.ExpiresAt, &i.AcceptedAt, &i.CreatedAt)
+	return i, notFound(err)
+}
+
+func (r *sqlWorkspaces) AcceptInvitation(inv models.WorkspaceInvitation, userID int) error {
+	if _, err := r.q.Exec(
+		`INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)
+		 ON CONFLICT (workspace_id, user_id) DO NOTHING`,
+		inv.WorkspaceID, userID, inv.Role,
+	); err != nil {
+		return err
+	}
+	_, err := r.q.Exec("UPDATE workspace_invitations SET accepted_at = CURRENT_TIMESTAMP WHERE id = $1", inv.ID)
+	return err
+}
+
+func (r *sqlWorkspaces) queryInvitations(where string, args ...interface{}) ([]models.WorkspaceInvitation, error) {
+	rows, err := r.q.Query(
+		`SELECT i.id, i.workspace_id, w.name, i.email, i.role, i.invited_by, i.expires_at, i.accepted_at, i.created_at
+		 FROM workspace_invitations i JOIN workspaces w ON w.id = i.workspace_id
+		 WHERE `+where+` ORDER BY i.created_at DESC, i.id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []models.WorkspaceInvitation{}
+	for rows.Next() {
+		var i models.WorkspaceInvitation
+		if err := rows.Scan(&i.ID, &i.WorkspaceID, &i.Workspace, &i.Email, &i.Role, &i.InvitedBy,
+			&i.ExpiresAt, &i.AcceptedAt, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+	return invitations, rows.Err()
+}
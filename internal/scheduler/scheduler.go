@@ -0,0 +1,332 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/notify"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// batchSize - jumlah maksimum baris yang diklaim sekaligus
+	batchSize = 10
+	// sendTimeout - batas waktu pengiriman satu notifikasi
+	sendTimeout = 30 * time.Second
+	// lease - baris yang diklaim tapi tidak selesai (mis. proses mati) dicoba lagi
+	// setelah ini; cukup untuk mengirim satu batch penuh
+	lease = batchSize*sendTimeout + time.Minute
+	// maxAttempts - setelah ini notifikasi dianggap terkirim dan error terakhirnya disimpan
+	maxAttempts = 8
+	// baseBackoff dan maxBackoff - retry ke-n menunggu baseBackoff * 2^(n-1), maksimal maxBackoff
+	baseBackoff = time.Minute
+	maxBackoff  = time.Hour
+)
+
+// dueNotification - notifikasi yang dikirim sekali per task berdasarkan due date.
+// prefix menentukan kolomnya: <prefix>_notified_at, <prefix>_attempts,
+// <prefix>_next_attempt_at dan <prefix>_pending_channels.
+type dueNotification struct {
+	kind      string // jenis notifikasi
+	prefix    string
+	condition string // kondisi due date, $1 = waktu sekarang, $4 = $1 + window
+	window    time.Duration
+	subject   string
+	body      string
+}
+
+var dueNotifications = []dueNotification{
+	{
+		kind:      models.NotificationDueSoon,
+		prefix:    "due_soon",
+		condition: "due_date > $1 AND due_date <= $4",
+		window:    24 * time.Hour,
+		subject:   "Task due soon: %s",
+		body:      "The task %q (#%d) is due %s.",
+	},
+	{
+		kind:      models.NotificationOverdue,
+		prefix:    "due",
+		condition: "due_date <= $4",
+		subject:   "Task overdue: %s",
+		body:      "The task %q (#%d) was due %s.",
+	},
+}
+
+// Scheduler - background job yang mengirim reminder dan notifikasi due date.
+// Semua state ada di database, jadi aman di-restart. Baris diklaim dengan satu
+// UPDATE (FOR UPDATE SKIP LOCKED + lease di next_attempt_at) yang langsung
+// di-commit, lalu dikirim tanpa transaksi terbuka: edit task tidak menunggu
+// pengiriman, dan beberapa instance API tidak mengirim notifikasi yang sama dua
+// kali. Pengiriman yang gagal dicoba lagi dengan backoff.
+type Scheduler struct {
+	db       *sql.DB
+	dialect  database.Dialect
+	notifier *notify.Notifier
+	interval time.Duration
+}
+
+func New(db *sql.DB, notifier *notify.Notifier, interval time.Duration) *Scheduler {
+	return &Scheduler{db: db, dialect: database.DialectOf(db), notifier: notifier, interval: interval}
+}
+
+// Run - jalan sampai ctx dibatalkan
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	// Ulangi selama batch penuh, supaya antrean panjang cepat habis
+	for {
+		n, err := s.fireReminders(ctx)
+		if err != nil {
+			log.Printf("scheduler: reminders: %v", err)
+		}
+		if n < batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	for _, d := range dueNotifications {
+		for {
+			n, err := s.fireDueNotifications(ctx, d)
+			if err != nil {
+				log.Printf("scheduler: %s notifications: %v", d.kind, err)
+			}
+			if n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+type dueReminder struct {
+	id          int
+	userID      int
+	channels    []string
+	attempts    int
+	taskID      int
+	title       string
+	dueDate     *time.Time
+	isCompleted bool
+}
+
+// fireReminders - kirim reminder yang fire_at-nya sudah lewat.
+// Reminder untuk task yang sudah selesai ditandai terkirim tanpa dikirim.
+func (s *Scheduler) fireReminders(ctx context.Context) (int, error) {
+	now, leaseUntil := claimTimes()
+	rows, err := s.db.QueryContext(ctx,
+		`UPDATE task_reminders
+		 SET attempts = attempts + 1, next_attempt_at = $2
+		 WHERE id IN (
+		     SELECT id FROM task_reminders
+		     WHERE sent_at IS NULL AND fire_at <= $1 AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+		     ORDER BY fire_at
+		     LIMIT $3`+s.dialect.LockRows("FOR UPDATE SKIP LOCKED")+`)
+		 RETURNING id, user_id, COALESCE(pending_channels, channels), attempts, task_id,
+		     (SELECT title FROM tasks WHERE id = task_reminders.task_id),
+		     (SELECT due_date FROM tasks WHERE id = task_reminders.task_id),
+		     (SELECT is_completed FROM tasks WHERE id = task_reminders.task_id)`,
+		now, leaseUntil, batchSize,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var reminders []dueReminder
+	for rows.Next() {
+		var r dueReminder
+		if err := rows.Scan(&r.id, &r.userID, pq.Array(&r.channels), &r.attempts,
+			&r.taskID, &r.title, &r.dueDate, &r.isCompleted); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		reminders = append(reminders, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, r := range reminders {
+		var sendErr error
+		if !r.isCompleted {
+			taskID := r.taskID
+			msg := notify.Message{
+				Type:    models.NotificationReminder,
+				UserID:  r.userID,
+				Subject: fmt.Sprintf("Reminder: %s", r.title),
+				Body:    fmt.Sprintf("This is your reminder for the task %q (#%d).", r.title, r.taskID),
+				TaskID:  &taskID,
+			}
+			if r.dueDate != nil {
+				msg.Body += fmt.Sprintf("\nDue: %s", r.dueDate.Format(time.RFC1123))
+			}
+			sendErr = s.send(ctx, msg, r.channels)
+		}
+
+		// Lease yang berubah berarti reminder dijadwalkan ulang selama pengiriman
+		// (due date diedit); hasil ini tidak berlaku lagi
+		outcome := retryOutcome(sendErr, r.attempts, r.channels)
+		if _, err := s.db.ExecContext(ctx,
+			`UPDATE task_reminders
+			 SET sent_at = $1, next_attempt_at = $2, pending_channels = $3, last_error = $4
+			 WHERE id = $5 AND next_attempt_at = $6`,
+			outcome.sentAt, outcome.nextAttempt, outcome.pending, outcome.lastError, r.id, leaseUntil,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(reminders), nil
+}
+
+type dueTask struct {
+	id       int
+	userID   int
+	title    string
+	dueDate  time.Time
+	attempts int
+	channels []string
+}
+
+// fireDueNotifications - beri tahu assignee (atau pembuat kalau belum di-assign)
+// sekali per task yang belum selesai dan memenuhi kondisi due date
+func (s *Scheduler) fireDueNotifications(ctx context.Context, d dueNotification) (int, error) {
+	notifiedAt, attempts := d.prefix+"_notified_at", d.prefix+"_attempts"
+	nextAttemptAt, pending := d.prefix+"_next_attempt_at", d.prefix+"_pending_channels"
+
+	now, leaseUntil := claimTimes()
+	rows, err := s.db.QueryContext(ctx,
+		`UPDATE tasks
+		 SET `+attempts+` = `+attempts+` + 1, `+nextAttemptAt+` = $3
+		 WHERE id IN (
+		     SELECT id FROM tasks
+		     WHERE `+d.condition+` AND is_completed = false AND `+notifiedAt+` IS NULL
+		       AND (`+nextAttemptAt+` IS NULL OR `+nextAttemptAt+` <= $1)
+		     ORDER BY due_date
+		     LIMIT $2`+s.dialect.LockRows("FOR UPDATE SKIP LOCKED")+`)
+		 RETURNING id, COALESCE(assignee_id, user_id), title, due_date, `+attempts+`, `+pending,
+		now, batchSize, leaseUntil, now.Add(d.window),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var tasks []dueTask
+	for rows.Next() {
+		var t dueTask
+		if err := rows.Scan(&t.id, &t.userID, &t.title, &t.dueDate, &t.attempts, pq.Array(&t.channels)); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tasks = append(tasks, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, t := range tasks {
+		taskID := t.id
+		sendErr := s.send(ctx, notify.Message{
+			Type:    d.kind,
+			UserID:  t.userID,
+			Subject: fmt.Sprintf(d.subject, t.title),
+			Body:    fmt.Sprintf(d.body, t.title, t.id, t.dueDate.Format(time.RFC1123)),
+			TaskID:  &taskID,
+		}, t.channels)
+		if sendErr != nil {
+			log.Printf("scheduler: %s notification for task %d (attempt %d): %v", d.kind, t.id, t.attempts, sendErr)
+		}
+
+		// Due date yang diedit selama pengiriman sudah mereset lease-nya
+		outcome := retryOutcome(sendErr, t.attempts, t.channels)
+		if _, err := s.db.ExecContext(ctx,
+			`UPDATE tasks SET `+notifiedAt+` = $1, `+nextAttemptAt+` = $2, `+pending+` = $3
+			 WHERE id = $4 AND `+nextAttemptAt+` = $5`,
+			outcome.sentAt, outcome.nextAttempt, outcome.pending, t.id, leaseUntil,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(tasks), nil
+}
+
+// claimTimes - waktu sekarang dan akhir lease untuk satu klaim. Lease juga
+// menjadi penanda klaim saat hasilnya disimpan, jadi dibulatkan ke presisi
+// kolom TIMESTAMP (mikrodetik) supaya bisa dibandingkan persis.
+func claimTimes() (time.Time, time.Time) {
+	now := time.Now()
+	return now, now.Add(lease).Truncate(time.Microsecond)
+}
+
+func (s *Scheduler) send(ctx context.Context, msg notify.Message, channels []string) error {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return s.notifier.Send(sendCtx, msg, channels)
+}
+
+// outcome - kolom yang disimpan setelah satu percobaan
+type outcome struct {
+	sentAt      *time.Time
+	nextAttempt *time.Time
+	pending     interface{} // array channel atau NULL
+	lastError   *string
+}
+
+// retryOutcome - berhasil, atau gagal dan dicoba lagi setelah backoff. Kalau
+// hanya sebagian channel yang gagal, percobaan berikutnya hanya lewat channel
+// itu. Setelah maxAttempts percobaan notifikasi dianggap terkirim.
+func retryOutcome(err error, attempts int, channels []string) outcome {
+	now := time.Now()
+	if err == nil {
+		return outcome{sentAt: &now}
+	}
+
+	errText := err.Error()
+	if attempts >= maxAttempts {
+		return outcome{sentAt: &now, lastError: &errText}
+	}
+
+	next := now.Add(backoff(attempts))
+	o := outcome{nextAttempt: &next, lastError: &errText}
+	var delivery *notify.DeliveryError
+	if errors.As(err, &delivery) {
+		o.pending = pq.Array(delivery.Channels)
+	} else if channels != nil {
+		o.pending = pq.Array(channels)
+	}
+	return o
+}
+
+// backoff - jeda sebelum percobaan berikutnya setelah attempts kali gagal
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
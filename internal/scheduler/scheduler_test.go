@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/notify"
+
+	"github.com/lib/pq"
+)
+
+// fakeChannel - channel yang mencatat pengiriman dan gagal selama err di-set
+type fakeChannel struct {
+	name string
+	sent int
+	err  error
+}
+
+func (f *fakeChannel) Name() string { return f.name }
+
+func (f *fakeChannel) Send(ctx context.Context, msg notify.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent++
+	return nil
+}
+
+func newScheduler(t *testing.T, channels ...notify.Channel) (*Scheduler, *sql.DB) {
+	t.Helper()
+	db := dbtest.Open(t)
+	return New(db, notify.NewNotifier(db, channels...), time.Minute), db
+}
+
+// insertTask - task milik userID dengan due date
+func insertTask(t *testing.T, db *sql.DB, userID int, due time.Time) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(
+		"INSERT INTO tasks (user_id, title, due_date) VALUES ($1, 'Pay rent', $2) RETURNING id",
+		userID, due,
+	).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert task: %v", err)
+	}
+	return id
+}
+
+// insertReminder - reminder yang sudah jatuh tempo lewat channels
+func insertReminder(t *testing.T, db *sql.DB, taskID, userID int, channels []string) int {
+	t.Helper()
+	fireAt := time.Now().Add(-time.Minute)
+	var id int
+	err := db.QueryRow(
+		`INSERT INTO task_reminders (task_id, user_id, remind_at, fire_at, channels)
+		 VALUES ($1, $2, $3, $3, $4) RETURNING id`,
+		taskID, userID, fireAt, pq.Array(channels),
+	).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert reminder: %v", err)
+	}
+	return id
+}
+
+type reminderState struct {
+	sent          bool
+	attempts      int
+	nextAttemptAt *time.Time
+	pending       []string
+}
+
+func loadReminder(t *testing.T, db *sql.DB, id int) reminderState {
+	t.Helper()
+	var r reminderState
+	var sentAt *time.Time
+	err := db.QueryRow(
+		"SELECT sent_at, attempts, next_attempt_at, pending_channels FROM task_reminders WHERE id = $1", id,
+	).Scan(&sentAt, &r.attempts, &r.nextAttemptAt, pq.Array(&r.pending))
+	if err != nil {
+		t.Fatalf("load reminder: %v", err)
+	}
+	r.sent = sentAt != nil
+	return r
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 4, want: 8 * time.Minute},
+		{attempts: 6, want: 32 * time.Minute},
+		{attempts: 7, want: maxBackoff},
+		{attempts: 50, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRetryOutcome(t *testing.T) {
+	partial := &notify.DeliveryError{Channels: []string{"email"}, Err: errors.New("smtp down")}
+
+	tests := []struct {
+		name        string
+		err         error
+		attempts    int
+		channels    []string
+		wantSent    bool
+		wantRetry   bool
+		wantPending interface{}
+	}{
+		{name: "delivered", wantSent: true},
+		{name: "partial failure keeps failed channels", err: partial, attempts: 1,
+			channels: []string{"email", "in_app"}, wantRetry: true, wantPending: pq.Array([]string{"email"})},
+		{name: "failure keeps selected channels", err: errors.New("no user"), attempts: 1,
+			channels: []string{"in_app"}, wantRetry: true, wantPending: pq.Array([]string{"in_app"})},
+		{name: "failure with all channels", err: errors.New("no user"), attempts: 1, wantRetry: true},
+		{name: "gives up after maxAttempts", err: partial, attempts: maxAttempts, wantSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := retryOutcome(tt.err, tt.attempts, tt.channels)
+			if (o.sentAt != nil) != tt.wantSent {
+				t.Errorf("sent = %v, want %v", o.sentAt != nil, tt.wantSent)
+			}
+			if (o.nextAttempt != nil) != tt.wantRetry {
+				t.Errorf("retry = %v, want %v", o.nextAttempt != nil, tt.wantRetry)
+			}
+			if !reflect.DeepEqual(o.pending, tt.wantPending) {
+				t.Errorf("pending = %#v, want %#v", o.pending, tt.wantPending)
+			}
+			if (o.lastError != nil) != (tt.err != nil) {
+				t.Errorf("lastError = %v, want set = %v", o.lastError, tt.err != nil)
+			}
+		})
+	}
+}
+
+func TestFireRemindersRetriesFailedChannelWithBackoff(t *testing.T) {
+	email := &fakeChannel{name: "email", err: errors.New("smtp down")}
+	inApp := &fakeChannel{name: "in_app"}
+	s, db := newScheduler(t, email, inApp)
+	ctx := context.Background()
+
+	userID := dbtest.User(t, db, "a@example.com")
+	taskID := insertTask(t, db, userID, time.Now().Add(48*time.Hour))
+	id := insertReminder(t, db, taskID, userID, []string{"email", "in_app"})
+
+	if n, err := s.fireReminders(ctx); err != nil || n != 1 {
+		t.Fatalf("first run = %d, %v, want 1 claimed", n, err)
+	}
+	r := loadReminder(t, db, id)
+	if r.sent || r.attempts != 1 || !reflect.DeepEqual(r.pending, []string{"email"}) {
+		t.Fatalf("after failure = %+v, want unsent, 1 attempt, pending [email]", r)
+	}
+	if wait := time.Until(*r.nextAttemptAt); wait < 50*time.Second || wait > baseBackoff {
+		t.Errorf("next attempt in %v, want about %v", wait, baseBackoff)
+	}
+
+	// Backoff belum lewat: tidak diklaim lagi
+	if n, err := s.fireReminders(ctx); err != nil || n != 0 {
+		t.Fatalf("run during backoff = %d, %v, want 0 claimed", n, err)
+	}
+
+	email.err = nil
+	if _, err := db.Exec("UPDATE task_reminders SET next_attempt_at = $1 WHERE id = $2", time.Now().Add(-time.Second), id); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := s.fireReminders(ctx); err != nil || n != 1 {
+		t.Fatalf("retry = %d, %v, want 1 claimed", n, err)
+	}
+	if r := loadReminder(t, db, id); !r.sent || r.attempts != 2 {
+		t.Errorf("after retry = %+v, want sent after 2 attempts", r)
+	}
+	// Retry hanya lewat channel yang gagal
+	if email.sent != 1 || inApp.sent != 1 {
+		t.Errorf("deliveries email=%d in_app=%d, want 1 each", email.sent, inApp.sent)
+	}
+}
+
+func TestFireRemindersSkipsLeasedRows(t *testing.T) {
+	inApp := &fakeChannel{name: "in_app"}
+	s, db := newScheduler(t, inApp)
+	ctx := context.Background()
+
+	userID := dbtest.User(t, db, "a@example.com")
+	taskID := insertTask(t, db, userID, time.Now().Add(48*time.Hour))
+	id := insertReminder(t, db, taskID, userID, []string{"in_app"})
+
+	// Diklaim instance lain yang masih mengirim
+	if _, err := db.Exec("UPDATE task_reminders SET attempts = 1, next_attempt_at = $1 WHERE id = $2", time.Now().Add(lease), id); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := s.fireReminders(ctx); err != nil || n != 0 {
+		t.Fatalf("run during lease = %d, %v, want 0 claimed", n, err)
+	}
+
+	// Instance itu mati: setelah lease habis reminder diklaim lagi
+	if _, err := db.Exec("UPDATE task_reminders SET next_attempt_at = $1 WHERE id = $2", time.Now().Add(-time.Second), id); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := s.fireReminders(ctx); err != nil || n != 1 {
+		t.Fatalf("run after lease = %d, %v, want 1 claimed", n, err)
+	}
+	if r := loadReminder(t, db, id); !r.sent || r.attempts != 2 || inApp.sent != 1 {
+		t.Errorf("after lease = %+v with %d deliveries, want sent once after 2 attempts", r, inApp.sent)
+	}
+}
+
+func TestFireDueNotificationsOncePerTask(t *testing.T) {
+	inApp := &fakeChannel{name: "in_app"}
+	s, db := newScheduler(t, inApp)
+	ctx := context.Background()
+
+	userID := dbtest.User(t, db, "a@example.com")
+	dueSoon := insertTask(t, db, userID, time.Now().Add(2*time.Hour))
+	overdue := insertTask(t, db, userID, time.Now().Add(-2*time.Hour))
+	insertTask(t, db, userID, time.Now().Add(72*time.Hour))
+
+	for _, d := range dueNotifications {
+		for run := 0; run < 2; run++ {
+			if _, err := s.fireDueNotifications(ctx, d); err != nil {
+				t.Fatalf("%s run %d: %v", d.kind, run+1, err)
+			}
+		}
+	}
+
+	if inApp.sent != 2 {
+		t.Errorf("deliveries = %d, want 2", inApp.sent)
+	}
+	var soonNotified, dueNotified bool
+	err := db.QueryRow(
+		`SELECT (SELECT due_soon_notified_at IS NOT NULL FROM tasks WHERE id = $1),
+		        (SELECT due_notified_at IS NOT NULL FROM tasks WHERE id = $2)`,
+		dueSoon, overdue,
+	).Scan(&soonNotified, &dueNotified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !soonNotified || !dueNotified {
+		t.Errorf("due soon notified = %v, overdue notified = %v, want both", soonNotified, dueNotified)
+	}
+}
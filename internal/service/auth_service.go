@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"taskflow-api/internal/jwtkeys"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MinPasswordLength - sama dengan aturan binding RegisterRequest
+const MinPasswordLength = 6
+
+// AuthService - registrasi, login dengan JWT, dan profil user
+type AuthService struct {
+	users repository.UserRepository
+	keys  *jwtkeys.Keyring
+}
+
+func NewAuthService(users repository.UserRepository, keys *jwtkeys.Keyring) *AuthService {
+	return &AuthService{users: users, keys: keys}
+}
+
+// Register - buat user beserta board default-nya
+func (s *AuthService) Register(req models.RegisterRequest) (models.User, error) {
+	// Check if email already exists
+	exists, err := s.users.EmailExists(req.Email)
+	if err != nil {
+		return models.User{}, internalError("Database error")
+	}
+	if exists {
+		return models.User{}, &Error{Status: http.StatusConflict, Message: "Email already registered"}
+	}
+
+	// Hash password
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return models.User{}, internalError("Failed to hash password")
+	}
+
+	user, err := s.users.Create(req.Name, req.Email, hashedPassword)
+	if err != nil {
+		return models.User{}, internalError("Failed to create user")
+	}
+	return user, nil
+}
+
+// Login - cek email dan password lalu terbitkan JWT
+func (s *AuthService) Login(req models.LoginRequest) (models.LoginResponse, error) {
+	user, hashedPassword, err := s.users.GetByEmail(req.Email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.LoginResponse{}, &Error{Status: http.StatusUnauthorized, Message: "Invalid email or password"}
+	}
+	if err != nil {
+		return models.LoginResponse{}, internalError("Database error")
+	}
+
+	// Check password
+	if !utils.CheckPassword(req.Password, hashedPassword) {
+		return models.LoginResponse{}, &Error{Status: http.StatusUnauthorized, Message: "Invalid email or password"}
+	}
+
+	// Disabled accounts are only revealed to someone who knows the password
+	if user.DisabledAt != nil {
+		return models.LoginResponse{}, &Error{Status: http.StatusForbidden, Message: "Account is disabled"}
+	}
+
+	// Generate JWT token
+	tokenString, err := s.keys.Sign(jwt.MapClaims{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+	})
+	if err != nil {
+		return models.LoginResponse{}, internalError("Failed to generate token")
+	}
+
+	return models.LoginResponse{
+		Token: tokenString,
+		User:  user,
+	}, nil
+}
+
+func (s *AuthService) Profile(userID int) (models.User, error) {
+	user, err := s.users.Get(userID)
+	if err != nil {
+		return user, &Error{Status: http.StatusNotFound, Message: "User not found"}
+	}
+	return user, nil
+}
+
+// ResetPassword - ganti password user tanpa password lama (untuk admin)
+func (s *AuthService) ResetPassword(userID int, password string) error {
+	if len(password) < MinPasswordLength {
+		return &Error{Status: http.StatusBadRequest, Message: "Password must be at least 6 characters"}
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return internalError("Failed to hash password")
+	}
+	return userError(s.users.SetPassword(userID, hashedPassword))
+}
+
+// SetDisabled - user nonaktif tidak bisa login, dan token serta app password-nya
+// langsung ditolak
+func (s *AuthService) SetDisabled(userID int, disabled bool) error {
+	return userError(s.users.SetDisabled(userID, disabled))
+}
+
+func userError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return &Error{Status: http.StatusNotFound, Message: "User not found"}
+	}
+	if err != nil {
+		return internalError("Database error")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"taskflow-api/internal/jwtkeys"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/repository/memory"
+)
+
+func newAuthService(t *testing.T) (*AuthService, repository.UserRepository, *jwtkeys.Keyring) {
+	t.Helper()
+	users := memory.NewUserRepository(memory.NewStore())
+	keys := jwtkeys.New(nil, "test-secret")
+	return NewAuthService(users, keys), users, keys
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	auth, users, keys := newAuthService(t)
+
+	user, err := auth.Register(models.RegisterRequest{Name: "Alice", Email: "alice@example.com", Password: "secret1"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	_, err = auth.Register(models.RegisterRequest{Name: "Again", Email: "alice@example.com", Password: "secret2"})
+	wantError(t, err, http.StatusConflict, "Email already registered")
+
+	// Password disimpan sebagai hash
+	if _, hash, _ := users.GetByEmail("alice@example.com"); hash == "" || hash == "secret1" {
+		t.Errorf("stored password = %q, want a hash", hash)
+	}
+
+	resp, err := auth.Login(models.LoginRequest{Email: "alice@example.com", Password: "secret1"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.User.ID != user.ID {
+		t.Errorf("login user = %d, want %d", resp.User.ID, user.ID)
+	}
+	token, err := keys.Parse(resp.Token)
+	if err != nil || !token.Valid {
+		t.Fatalf("token does not verify: %v", err)
+	}
+
+	_, err = auth.Login(models.LoginRequest{Email: "alice@example.com", Password: "wrong"})
+	wantError(t, err, http.StatusUnauthorized, "Invalid email or password")
+	_, err = auth.Login(models.LoginRequest{Email: "nobody@example.com", Password: "secret1"})
+	wantError(t, err, http.StatusUnauthorized, "Invalid email or password")
+}
+
+func TestDisabledUser(t *testing.T) {
+	auth, _, _ := newAuthService(t)
+	user, err := auth.Register(models.RegisterRequest{Name: "Bob", Email: "bob@example.com", Password: "secret1"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if err := auth.SetDisabled(user.ID, true); err != nil {
+		t.Fatalf("SetDisabled: %v", err)
+	}
+	// Akun nonaktif hanya terungkap ke yang tahu password-nya
+	_, err = auth.Login(models.LoginRequest{Email: "bob@example.com", Password: "wrong"})
+	wantError(t, err, http.StatusUnauthorized, "")
+	_, err = auth.Login(models.LoginRequest{Email: "bob@example.com", Password: "secret1"})
+	wantError(t, err, http.StatusForbidden, "Account is disabled")
+
+	if err := auth.SetDisabled(user.ID, false); err != nil {
+		t.Fatalf("SetDisabled: %v", err)
+	}
+	if _, err := auth.Login(models.LoginRequest{Email: "bob@example.com", Password: "secret1"}); err != nil {
+		t.Errorf("Login after enabling: %v", err)
+	}
+
+	wantError(t, auth.SetDisabled(user.ID+100, true), http.StatusNotFound, "User not found")
+}
+
+func TestResetPassword(t *testing.T) {
+	auth, _, _ := newAuthService(t)
+	user, err := auth.Register(models.RegisterRequest{Name: "Carol", Email: "carol@example.com", Password: "secret1"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	wantError(t, auth.ResetPassword(user.ID, "short"), http.StatusBadRequest, "")
+	wantError(t, auth.ResetPassword(user.ID+100, "long enough"), http.StatusNotFound, "User not found")
+
+	if err := auth.ResetPassword(user.ID, "new secret"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	_, err = auth.Login(models.LoginRequest{Email: "carol@example.com", Password: "secret1"})
+	wantError(t, err, http.StatusUnauthorized, "")
+	if _, err := auth.Login(models.LoginRequest{Email: "carol@example.com", Password: "new secret"}); err != nil {
+		t.Errorf("Login with new password: %v", err)
+	}
+
+	if _, err := auth.Profile(user.ID + 100); err == nil {
+		t.Error("Profile of missing user succeeded")
+	}
+}
@@ -0,0 +1,83 @@
+// Package service - logika bisnis task dan auth yang dipakai bersama oleh REST,
+// GraphQL, gRPC, CalDAV, sync dan job import. Handler hanya menerjemahkan
+// request/response; data diakses lewat interface di package repository.
+package service
+
+import (
+	"errors"
+	"net/http"
+
+	"taskflow-api/internal/authz"
+)
+
+// Error - kegagalan operasi service beserta status HTTP-nya. Version diisi
+// untuk 412 supaya client tahu versi terbaru.
+type Error struct {
+	Status  int
+	Message string
+	Version int
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Code - kode error yang stabil untuk client GraphQL dan gRPC
+func (e *Error) Code() string {
+	switch e.Status {
+	case http.StatusBadRequest:
+		return "BAD_USER_INPUT"
+	case http.StatusUnauthorized:
+		return "UNAUTHENTICATED"
+	case http.StatusForbidden:
+		return "FORBIDDEN"
+	case http.StatusNotFound:
+		return "NOT_FOUND"
+	case http.StatusConflict:
+		return "CONFLICT"
+	case http.StatusPreconditionFailed:
+		return "VERSION_MISMATCH"
+	}
+	return "INTERNAL_SERVER_ERROR"
+}
+
+// AuthzError - error dari authz sebagai *Error; notFound untuk resource yang tidak ada
+func AuthzError(err error, notFound string) *Error {
+	switch {
+	case errors.Is(err, authz.ErrNotFound):
+		return &Error{Status: http.StatusNotFound, Message: notFound}
+	case errors.Is(err, authz.ErrForbidden):
+		return &Error{Status: http.StatusForbidden, Message: "You don't have permission to perform this action"}
+	}
+	return &Error{Status: http.StatusInternalServerError, Message: "Database error"}
+}
+
+// VersionMismatch - 412 beserta versi sekarang
+func VersionMismatch(version int) *Error {
+	return &Error{
+		Status:  http.StatusPreconditionFailed,
+		Message: "Task was modified by someone else; reload it and try again",
+		Version: version,
+	}
+}
+
+// MatchesVersion - syarat If-Match terpenuhi (nil = tanpa syarat)
+func MatchesVersion(versions []int64, version int) bool {
+	if versions == nil {
+		return true
+	}
+	for _, v := range versions {
+		if v == int64(version) {
+			return true
+		}
+	}
+	return false
+}
+
+func internalError(message string) *Error {
+	return &Error{Status: http.StatusInternalServerError, Message: message}
+}
+
+func badRequest(message string) *Error {
+	return &Error{Status: http.StatusBadRequest, Message: message}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"taskflow-api/internal/models"
+)
+
+// mentionPattern - mention ditulis sebagai @ diikuti email user, mis. "@jane@example.com"
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+-])@([\w.%+-]+@[\w.-]+\.[A-Za-z]{2,})`)
+
+// mentionedEmails - email (huruf kecil, unik) yang di-mention di teks
+func mentionedEmails(text string) map[string]bool {
+	emails := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		emails[strings.ToLower(m[1])] = true
+	}
+	return emails
+}
+
+// newlyMentioned - email yang di-mention di teks baru tapi belum di teks lama
+func newlyMentioned(oldText, newText string) []string {
+	before := mentionedEmails(oldText)
+	var emails []string
+	for email := range mentionedEmails(newText) {
+		if !before[email] {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// taskText - teks yang dicari mention-nya
+func taskText(task models.Task) string {
+	if task.Description == nil {
+		return task.Title
+	}
+	return task.Title + " " + *task.Description
+}
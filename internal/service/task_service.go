@@ -0,0 +1,646 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/jsonpatch"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/utils"
+)
+
+// Authorizer - cek izin yang dipakai TaskService, dipenuhi oleh *authz.Authorizer
+type Authorizer interface {
+	Task(userID, taskID int, action authz.Action) (authz.Resource, error)
+	Scope(userID int, workspaceID *int, action authz.Action) error
+	Check(userID int, res authz.Resource, action authz.Action) error
+}
+
+// TaskService - operasi task: cek izin, aturan board (status, posisi, project,
+// assignee), syarat versi, lalu event setelah perubahan tersimpan
+type TaskService struct {
+	tasks repository.TaskRepository
+	users repository.UserRepository
+	authz Authorizer
+	bus   *events.Bus
+}
+
+func NewTaskService(tasks repository.TaskRepository, users repository.UserRepository, az Authorizer, bus *events.Bus) *TaskService {
+	return &TaskService{tasks: tasks, users: users, authz: az, bus: bus}
+}
+
+// Create - buat task di board req (personal atau workspace) paling bawah di kolomnya
+func (s *TaskService) Create(userID int, req models.CreateTaskRequest) (models.Task, error) {
+	// Set defaults
+	if req.Priority == "" {
+		req.Priority = models.PriorityMedium
+	}
+	if req.Category == "" {
+		req.Category = models.CategoryPersonal
+	}
+
+	if err := s.authz.Scope(userID, req.WorkspaceID, authz.ActionEdit); err != nil {
+		return models.Task{}, AuthzError(err, "Workspace not found")
+	}
+	b := repository.Board{UserID: userID, WorkspaceID: req.WorkspaceID}
+
+	if req.ProjectID != nil {
+		if err := s.tasks.CheckProject(b, *req.ProjectID); err != nil {
+			return models.Task{}, badRequest(err.Error())
+		}
+	}
+	if req.AssigneeID != nil {
+		if err := s.checkAssignee(b, *req.AssigneeID); err != nil {
+			return models.Task{}, badRequest(err.Error())
+		}
+	}
+
+	var task models.Task
+	err := s.tasks.Transact(func(tx repository.TaskRepository) error {
+		statuses, err := tx.Statuses(inProject(b, req.ProjectID))
+		if err != nil {
+			return internalError("Failed to fetch statuses")
+		}
+
+		var status models.TaskStatus
+		var ok bool
+		if req.Status == "" {
+			status, ok = models.FirstStatus(statuses, req.IsCompleted != nil && *req.IsCompleted)
+		} else {
+			status, ok = models.FindStatus(statuses, req.Status)
+		}
+		if !ok {
+			return badRequest("Unknown status")
+		}
+
+		// Task baru ditaruh di bawah kolomnya
+		position, err := appendPosition(tx, b, status.Key)
+		if err != nil {
+			return internalError("Failed to compute position")
+		}
+
+		task, err = tx.Create(models.Task{
+			UserID:      userID,
+			WorkspaceID: req.WorkspaceID,
+			ProjectID:   req.ProjectID,
+			AssigneeID:  req.AssigneeID,
+			Title:       req.Title,
+			Description: req.Description,
+			Priority:    req.Priority,
+			Category:    req.Category,
+			Status:      status.Key,
+			Position:    position,
+			IsCompleted: status.IsTerminal,
+			DueDate:     req.DueDate,
+		})
+		if err != nil {
+			return internalError("Failed to create task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to create task")
+	}
+
+	s.bus.Publish(events.Event{Type: events.TaskCreated, ActorID: userID, Task: task})
+	if task.AssigneeID != nil {
+		s.bus.Publish(events.Event{Type: events.TaskAssigned, ActorID: userID, Task: task})
+	}
+	s.publishMentions(b, userID, "", task)
+	return task, nil
+}
+
+// Import - Create untuk baris import. Status dicocokkan dengan key atau nama
+// kolom project/board-nya (tidak peka huruf besar); yang tidak dikenal masuk
+// kolom default.
+func (s *TaskService) Import(userID int, req models.CreateTaskRequest) (models.Task, error) {
+	if req.Status != "" {
+		statuses, err := s.tasks.Statuses(repository.Board{UserID: userID, WorkspaceID: req.WorkspaceID, ProjectID: req.ProjectID})
+		if err != nil {
+			return models.Task{}, internalError("Failed to fetch statuses")
+		}
+		key := ""
+		for _, st := range statuses {
+			if strings.EqualFold(st.Key, req.Status) || strings.EqualFold(st.Name, req.Status) {
+				key = st.Key
+				break
+			}
+		}
+		req.Status = key
+	}
+	return s.Create(userID, req)
+}
+
+// Get - task yang boleh dilihat user
+func (s *TaskService) Get(userID, taskID int) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionView)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+
+	task, err := s.tasks.Get(res.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return models.Task{}, &Error{Status: http.StatusNotFound, Message: "Task not found"}
+	}
+	if err != nil {
+		return models.Task{}, internalError("Failed to fetch task")
+	}
+	return task, nil
+}
+
+// List - task yang terlihat oleh user dan cocok dengan filter
+func (s *TaskService) List(userID int, f repository.TaskFilter) ([]models.Task, error) {
+	tasks, err := s.tasks.List(userID, f)
+	if err != nil {
+		return nil, internalError("Failed to fetch tasks")
+	}
+	return tasks, nil
+}
+
+func (s *TaskService) Stats(userID int, f repository.TaskFilter) (models.TaskStats, error) {
+	stats, err := s.tasks.Stats(userID, f)
+	if err != nil {
+		return stats, internalError("Failed to fetch statistics")
+	}
+	return stats, nil
+}
+
+// Board - task di board b dikelompokkan per status, urut sesuai position.
+// Dengan b.ProjectID hanya task project itu, di kolom project-nya.
+// Izin melihat board dicek oleh pemanggil.
+func (s *TaskService) Board(b repository.Board, f repository.TaskFilter) ([]models.BoardColumn, error) {
+	statuses, err := s.tasks.Statuses(b)
+	if err != nil {
+		return nil, internalError("Failed to fetch statuses")
+	}
+
+	f.Board = &b
+	tasks, err := s.tasks.List(b.UserID, f)
+	if err != nil {
+		return nil, internalError("Failed to fetch tasks")
+	}
+
+	columns := make([]models.BoardColumn, len(statuses))
+	index := map[string]int{}
+	for i, st := range statuses {
+		columns[i] = models.BoardColumn{Status: st, Tasks: []models.Task{}}
+		index[st.Key] = i
+	}
+	for _, task := range tasks {
+		if i, ok := index[task.Status]; ok {
+			columns[i].Tasks = append(columns[i].Tasks, task)
+		}
+	}
+	return columns, nil
+}
+
+// Update - lock task, cek versi (nil = tanpa syarat), bangun dokumen barunya
+// dari state sekarang, simpan, lalu kirim event
+func (s *TaskService) Update(userID, taskID int, versions []int64, build func(current models.Task) (models.TaskDocument, error)) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionEdit)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+	b := repository.BoardOf(res)
+
+	var current, task models.Task
+	err = s.tasks.Transact(func(tx repository.TaskRepository) error {
+		var err error
+		if current, err = lockVersion(tx, res.ID, versions); err != nil {
+			return err
+		}
+
+		doc, err := build(current)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return &Error{Status: http.StatusConflict, Message: err.Error()}
+		}
+		if err != nil {
+			return badRequest(err.Error())
+		}
+
+		// Set defaults
+		if doc.Priority == "" {
+			doc.Priority = models.PriorityMedium
+		}
+		if doc.Category == "" {
+			doc.Category = models.CategoryPersonal
+		}
+
+		if doc.ProjectID != nil && !sameID(doc.ProjectID, current.ProjectID) {
+			if err := tx.CheckProject(b, *doc.ProjectID); err != nil {
+				return badRequest(err.Error())
+			}
+		}
+		if doc.AssigneeID != nil && !sameID(doc.AssigneeID, current.AssigneeID) {
+			if err := s.checkAssignee(b, *doc.AssigneeID); err != nil {
+				return badRequest(err.Error())
+			}
+		}
+
+		// Status menentukan is_completed; mengubah is_completed saja memindah task
+		// ke kolom open/terminal pertama. Task yang pindah kolom ditaruh di bawah.
+		// Kolom yang berlaku adalah kolom project tujuan.
+		statuses, err := tx.Statuses(inProject(b, doc.ProjectID))
+		if err != nil {
+			return internalError("Failed to fetch statuses")
+		}
+
+		var target models.TaskStatus
+		var ok bool
+		completedChanged := doc.IsCompleted != nil && *doc.IsCompleted != current.IsCompleted
+		switch {
+		case doc.Status != "" && (doc.Status != current.Status || !completedChanged):
+			target, ok = models.FindStatus(statuses, doc.Status)
+		case completedChanged:
+			target, ok = models.FirstStatus(statuses, *doc.IsCompleted)
+		case doc.IsCompleted != nil:
+			target, ok = models.FindStatus(statuses, current.Status)
+		default:
+			target, ok = models.FirstStatus(statuses, false)
+		}
+		if !ok && !sameID(doc.ProjectID, current.ProjectID) && (doc.Status == "" || doc.Status == current.Status) {
+			// Status lama tidak ada di kolom project tujuan
+			target, ok = models.FirstStatus(statuses, current.IsCompleted)
+		}
+		if !ok {
+			return badRequest("Unknown status")
+		}
+
+		next := current
+		if target.Key != current.Status {
+			if next.Position, err = appendPosition(tx, b, target.Key); err != nil {
+				return internalError("Failed to compute position")
+			}
+		}
+		next.Title = doc.Title
+		next.Description = doc.Description
+		next.ProjectID = doc.ProjectID
+		next.AssigneeID = doc.AssigneeID
+		next.Priority = doc.Priority
+		next.Category = doc.Category
+		next.Status = target.Key
+		next.IsCompleted = target.IsTerminal
+		next.DueDate = doc.DueDate
+
+		if task, err = tx.Update(next); err != nil {
+			return internalError("Failed to update task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to update task")
+	}
+
+	if task.AssigneeID != nil && !sameID(task.AssigneeID, current.AssigneeID) {
+		s.bus.Publish(events.Event{Type: events.TaskAssigned, ActorID: userID, Task: task})
+	}
+	if !sameTime(task.DueDate, current.DueDate) {
+		s.tasks.RescheduleReminders(task.ID)
+	}
+	if taskText(task) != taskText(current) {
+		s.publishMentions(b, userID, taskText(current), task)
+	}
+	s.publishUpdated(userID, task, current.IsCompleted)
+	return task, nil
+}
+
+// Assign - ganti assignee (nil = unassign) dengan syarat versi lalu kirim event
+func (s *TaskService) Assign(userID, taskID int, assigneeID *int, versions []int64) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionEdit)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+
+	if assigneeID != nil {
+		if err := s.checkAssignee(repository.BoardOf(res), *assigneeID); err != nil {
+			return models.Task{}, badRequest(err.Error())
+		}
+	}
+
+	var previousAssignee *int
+	var task models.Task
+	err = s.tasks.Transact(func(tx repository.TaskRepository) error {
+		current, err := lockVersion(tx, res.ID, versions)
+		if err != nil {
+			return err
+		}
+		previousAssignee = current.AssigneeID
+
+		current.AssigneeID = assigneeID
+		if task, err = tx.Update(current); err != nil {
+			return internalError("Failed to assign task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to assign task")
+	}
+
+	if assigneeID != nil && !sameID(assigneeID, previousAssignee) {
+		s.bus.Publish(events.Event{Type: events.TaskAssigned, ActorID: userID, Task: task})
+	}
+	s.publishUpdated(userID, task, task.IsCompleted)
+	return task, nil
+}
+
+// Move - pindah task ke kolom req.Status, di antara req.AfterID dan req.BeforeID
+func (s *TaskService) Move(userID, taskID int, req models.MoveTaskRequest) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionEdit)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+	taskID = res.ID
+	b := repository.BoardOf(res)
+
+	if (req.AfterID != nil && *req.AfterID == taskID) || (req.BeforeID != nil && *req.BeforeID == taskID) {
+		return models.Task{}, badRequest("Task cannot be positioned relative to itself")
+	}
+
+	var wasCompleted bool
+	var task models.Task
+	err = s.tasks.Transact(func(tx repository.TaskRepository) error {
+		// Lock task yang dipindah
+		current, err := tx.Lock(taskID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return &Error{Status: http.StatusNotFound, Message: "Task not found"}
+		}
+		if err != nil {
+			return internalError("Database error")
+		}
+		wasCompleted = current.IsCompleted
+
+		statuses, err := tx.Statuses(inProject(b, current.ProjectID))
+		if err != nil {
+			return internalError("Failed to fetch statuses")
+		}
+		target, ok := models.FindStatus(statuses, req.Status)
+		if !ok {
+			return badRequest("Unknown status")
+		}
+
+		// Serialisasi semua perubahan urutan di kolom tujuan
+		if err := tx.LockColumn(b, target.Key); err != nil {
+			return internalError("Database error")
+		}
+
+		position, err := movePosition(tx, b, target.Key, taskID, req.AfterID, req.BeforeID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return badRequest("after_id and before_id must reference tasks in the target column")
+		}
+		if err != nil {
+			return internalError("Failed to compute position")
+		}
+
+		current.Status = target.Key
+		current.Position = position
+		current.IsCompleted = target.IsTerminal
+		if task, err = tx.Update(current); err != nil {
+			return internalError("Failed to move task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to move task")
+	}
+
+	s.publishUpdated(userID, task, wasCompleted)
+	return task, nil
+}
+
+// Delete - hapus task dengan syarat versi (nil = tanpa syarat) lalu kirim event
+func (s *TaskService) Delete(userID, taskID int, versions []int64) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionDelete)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+
+	// Snapshot task terakhir ikut dikirim di event task.deleted
+	var task models.Task
+	err = s.tasks.Transact(func(tx repository.TaskRepository) error {
+		if _, err := lockVersion(tx, res.ID, versions); err != nil {
+			return err
+		}
+		var err error
+		if task, err = tx.Delete(res.ID); err != nil {
+			return internalError("Failed to delete task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to delete task")
+	}
+
+	s.bus.Publish(events.Event{Type: events.TaskDeleted, ActorID: userID, Task: task})
+	return task, nil
+}
+
+// ToggleComplete - balik is_completed: task pindah ke kolom open/terminal pertama
+func (s *TaskService) ToggleComplete(userID, taskID int, versions []int64) (models.Task, error) {
+	res, err := s.authz.Task(userID, taskID, authz.ActionEdit)
+	if err != nil {
+		return models.Task{}, AuthzError(err, "Task not found")
+	}
+	b := repository.BoardOf(res)
+
+	var task models.Task
+	err = s.tasks.Transact(func(tx repository.TaskRepository) error {
+		current, err := lockVersion(tx, res.ID, versions)
+		if err != nil {
+			return err
+		}
+
+		statuses, err := tx.Statuses(inProject(b, current.ProjectID))
+		if err != nil {
+			return internalError("Failed to fetch statuses")
+		}
+		open, hasOpen := models.FirstStatus(statuses, false)
+		done, hasDone := models.FirstStatus(statuses, true)
+		if !hasOpen || !hasDone {
+			return &Error{Status: http.StatusConflict, Message: "Board needs an open and a terminal status"}
+		}
+
+		target := done
+		if current.IsCompleted {
+			target = open
+		}
+		if current.Position, err = appendPosition(tx, b, target.Key); err != nil {
+			return internalError("Failed to compute position")
+		}
+		current.Status = target.Key
+		current.IsCompleted = target.IsTerminal
+
+		if task, err = tx.Update(current); err != nil {
+			return internalError("Failed to toggle task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to toggle task")
+	}
+
+	s.publishUpdated(userID, task, !task.IsCompleted)
+	return task, nil
+}
+
+// UpdateShared - perubahan lewat share link yang tidak read-only (izinnya dicek
+// oleh pemanggil): hanya deskripsi dan status selesai. Event dikirim tanpa actor.
+func (s *TaskService) UpdateShared(taskID int, req models.PublicTaskUpdateRequest) (models.Task, error) {
+	var wasCompleted bool
+	var task models.Task
+	err := s.tasks.Transact(func(tx repository.TaskRepository) error {
+		// Task bisa sudah dihapus sejak share link-nya dibaca: 404, bukan 500
+		current, err := lockVersion(tx, taskID, nil)
+		if err != nil {
+			return err
+		}
+		wasCompleted = current.IsCompleted
+
+		if req.Description != nil {
+			current.Description = req.Description
+		}
+
+		if req.IsCompleted != nil && *req.IsCompleted != current.IsCompleted {
+			b := repository.Board{UserID: current.UserID, WorkspaceID: current.WorkspaceID, ProjectID: current.ProjectID}
+
+			statuses, err := tx.Statuses(b)
+			if err != nil {
+				return internalError("Failed to fetch statuses")
+			}
+			target, ok := models.FirstStatus(statuses, *req.IsCompleted)
+			if !ok {
+				return &Error{Status: http.StatusConflict, Message: "Board needs an open and a terminal status"}
+			}
+			if current.Position, err = appendPosition(tx, b, target.Key); err != nil {
+				return internalError("Failed to compute position")
+			}
+			current.Status = target.Key
+			current.IsCompleted = target.IsTerminal
+		}
+
+		if task, err = tx.Update(current); err != nil {
+			return internalError("Failed to update task")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Task{}, txError(err, "Failed to update task")
+	}
+
+	s.publishUpdated(0, task, wasCompleted)
+	return task, nil
+}
+
+// checkAssignee - assignee harus bisa mengedit task di board ini:
+// task personal hanya bisa di-assign ke pemiliknya, task workspace ke anggota non-viewer
+func (s *TaskService) checkAssignee(b repository.Board, assigneeID int) error {
+	err := s.authz.Check(assigneeID, authz.Resource{UserID: b.UserID, WorkspaceID: b.WorkspaceID}, authz.ActionEdit)
+	if err != nil {
+		return errors.New("Assignee must be a workspace member who can edit tasks")
+	}
+	return nil
+}
+
+// publishMentions - kirim event untuk user yang baru di-mention di judul/deskripsi.
+// Hanya user yang bisa melihat board yang dihitung.
+func (s *TaskService) publishMentions(b repository.Board, actorID int, previousText string, task models.Task) {
+	emails := newlyMentioned(previousText, taskText(task))
+	if len(emails) == 0 {
+		return
+	}
+	mentioned, err := s.users.BoardMembers(b, emails)
+	if err != nil || len(mentioned) == 0 {
+		return
+	}
+	s.bus.Publish(events.Event{Type: events.TaskMentioned, ActorID: actorID, Task: task, Mentioned: mentioned})
+}
+
+// publishUpdated - task.updated, ditambah task.completed kalau task baru saja selesai
+func (s *TaskService) publishUpdated(actorID int, task models.Task, wasCompleted bool) {
+	s.bus.Publish(events.Event{Type: events.TaskUpdated, ActorID: actorID, Task: task})
+	if task.IsCompleted && !wasCompleted {
+		s.bus.Publish(events.Event{Type: events.TaskCompleted, ActorID: actorID, Task: task})
+	}
+}
+
+// lockVersion - lock task lalu cek syarat versi (nil = tanpa syarat)
+func lockVersion(tx repository.TaskRepository, taskID int, versions []int64) (models.Task, error) {
+	current, err := tx.Lock(taskID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return current, &Error{Status: http.StatusNotFound, Message: "Task not found"}
+	}
+	if err != nil {
+		return current, internalError("Failed to fetch task")
+	}
+	if !MatchesVersion(versions, current.Version) {
+		return current, VersionMismatch(current.Version)
+	}
+	return current, nil
+}
+
+// txError - error dari Transact: *Error dari dalam transaksi apa adanya,
+// selain itu transaksi gagal dimulai atau di-commit
+func txError(err error, message string) error {
+	var se *Error
+	if errors.As(err, &se) {
+		return se
+	}
+	return internalError(message)
+}
+
+// appendPosition - position untuk task yang ditaruh paling bawah di kolom.
+// Kolomnya dikunci dulu, jadi tx harus repository dari Transact: tanpa lock
+// dua task yang ditambahkan bersamaan mendapat position yang sama.
+func appendPosition(tx repository.TaskRepository, b repository.Board, status string) (string, error) {
+	if err := tx.LockColumn(b, status); err != nil {
+		return "", err
+	}
+	last, err := tx.LastPosition(b, status)
+	if err != nil {
+		return "", err
+	}
+	return utils.KeyBetween(last, "")
+}
+
+// movePosition - hitung position baru di antara afterID dan beforeID.
+// Kalau tetangganya belum punya position yang valid (data lama atau
+// tabrakan), seluruh kolom dinomori ulang dulu.
+func movePosition(tx repository.TaskRepository, b repository.Board, status string, taskID int, afterID, beforeID *int) (string, error) {
+	lo, hi, err := tx.Neighbours(b, status, taskID, afterID, beforeID)
+	if err != nil {
+		return "", err
+	}
+
+	position, err := utils.KeyBetween(lo, hi)
+	if err == nil && (afterID == nil || lo != "") && (beforeID == nil || hi != "") {
+		return position, nil
+	}
+
+	if err := tx.Rebalance(b, status, taskID); err != nil {
+		return "", err
+	}
+	lo, hi, err = tx.Neighbours(b, status, taskID, afterID, beforeID)
+	if err != nil {
+		return "", err
+	}
+	return utils.KeyBetween(lo, hi)
+}
+
+// inProject - board b untuk task di project projectID (nil = di luar project),
+// untuk mencari kolom yang berlaku bagi task itu
+func inProject(b repository.Board, projectID *int) repository.Board {
+	b.ProjectID = projectID
+	return b
+}
+
+func sameID(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func sameTime(a, b *time.Time) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && a.Equal(*b))
+}
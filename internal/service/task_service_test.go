@@ -0,0 +1,731 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/authz"
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/repository"
+	"taskflow-api/internal/repository/memory"
+)
+
+// testEnv - TaskService di atas repository memori, dengan event yang terkirim
+type testEnv struct {
+	store  *memory.Store
+	tasks  repository.TaskRepository
+	users  repository.UserRepository
+	svc    *TaskService
+	events []events.Event
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{store: memory.NewStore()}
+	env.tasks = memory.NewTaskRepository(env.store)
+	env.users = memory.NewUserRepository(env.store)
+
+	bus := events.NewBus()
+	bus.Subscribe(func(e events.Event) { env.events = append(env.events, e) })
+	env.svc = NewTaskService(env.tasks, env.users, memory.NewAuthorizer(env.store), bus)
+	return env
+}
+
+// user - user baru beserta board personal-nya
+func (env *testEnv) user(t *testing.T, name string) int {
+	t.Helper()
+	u, err := env.users.Create(name, name+"@example.com", "hash")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return u.ID
+}
+
+func (env *testEnv) create(t *testing.T, userID int, req models.CreateTaskRequest) models.Task {
+	t.Helper()
+	task, err := env.svc.Create(userID, req)
+	if err != nil {
+		t.Fatalf("Create(%q): %v", req.Title, err)
+	}
+	return task
+}
+
+// eventTypes - tipe event yang terkirim sejak panggilan terakhir
+func (env *testEnv) eventTypes() []events.Type {
+	var types []events.Type
+	for _, e := range env.events {
+		types = append(types, e.Type)
+	}
+	env.events = nil
+	return types
+}
+
+// wantError - err harus *Error dengan status dan pesan tertentu
+func wantError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+	var se *Error
+	if !errors.As(err, &se) {
+		t.Fatalf("error = %v, want *Error %d", err, status)
+	}
+	if se.Status != status || (message != "" && se.Message != message) {
+		t.Errorf("error = %d %q, want %d %q", se.Status, se.Message, status, message)
+	}
+}
+
+func titles(tasks []models.Task) []string {
+	out := []string{}
+	for _, task := range tasks {
+		out = append(out, task.Title)
+	}
+	return out
+}
+
+func boolPtr(v bool) *bool    { return &v }
+func strPtr(v string) *string { return &v }
+
+func TestCreate(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+
+	first := env.create(t, alice, models.CreateTaskRequest{Title: "First"})
+	if first.Priority != models.PriorityMedium || first.Category != models.CategoryPersonal {
+		t.Errorf("defaults = %s/%s, want medium/personal", first.Priority, first.Category)
+	}
+	if first.Status != models.StatusTodo || first.IsCompleted || first.Version != 1 {
+		t.Errorf("new task = %+v, want open todo task at version 1", first)
+	}
+
+	second := env.create(t, alice, models.CreateTaskRequest{Title: "Second"})
+	if second.Position <= first.Position {
+		t.Errorf("second position %q not after %q", second.Position, first.Position)
+	}
+
+	done := env.create(t, alice, models.CreateTaskRequest{Title: "Done", IsCompleted: boolPtr(true)})
+	if done.Status != models.StatusDone || !done.IsCompleted {
+		t.Errorf("completed task = %s/%v, want done/true", done.Status, done.IsCompleted)
+	}
+
+	_, err := env.svc.Create(alice, models.CreateTaskRequest{Title: "Nope", Status: "missing"})
+	wantError(t, err, http.StatusBadRequest, "Unknown status")
+
+	want := []events.Type{events.TaskCreated, events.TaskCreated, events.TaskCreated}
+	if got := env.eventTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestCreateInWorkspace(t *testing.T) {
+	env := newTestEnv(t)
+	owner, member, viewer, outsider := env.user(t, "owner"), env.user(t, "member"), env.user(t, "viewer"), env.user(t, "outsider")
+	ws := env.store.AddWorkspace(owner)
+	env.store.AddMember(ws, member, authz.RoleMember)
+	env.store.AddMember(ws, viewer, authz.RoleViewer)
+	b := repository.Board{UserID: owner, WorkspaceID: &ws}
+	project := env.store.AddProject(b, false)
+	archived := env.store.AddProject(b, true)
+	personal := env.store.AddProject(repository.Board{UserID: member}, false)
+
+	task := env.create(t, member, models.CreateTaskRequest{Title: "Shared", WorkspaceID: &ws, ProjectID: &project, AssigneeID: &owner})
+	if *task.WorkspaceID != ws || *task.ProjectID != project || *task.AssigneeID != owner {
+		t.Errorf("task = %+v", task)
+	}
+	if got, want := env.eventTypes(), []events.Type{events.TaskCreated, events.TaskAssigned}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		userID  int
+		req     models.CreateTaskRequest
+		status  int
+		message string
+	}{
+		{"viewer", viewer, models.CreateTaskRequest{WorkspaceID: &ws}, http.StatusForbidden, ""},
+		{"outsider", outsider, models.CreateTaskRequest{WorkspaceID: &ws}, http.StatusNotFound, "Workspace not found"},
+		{"archived project", member, models.CreateTaskRequest{WorkspaceID: &ws, ProjectID: &archived}, http.StatusBadRequest, "Project is archived"},
+		{"project on another board", member, models.CreateTaskRequest{WorkspaceID: &ws, ProjectID: &personal}, http.StatusBadRequest, "Project not found"},
+		{"viewer as assignee", member, models.CreateTaskRequest{WorkspaceID: &ws, AssigneeID: &viewer}, http.StatusBadRequest, "Assignee must be a workspace member who can edit tasks"},
+		{"outsider as assignee", member, models.CreateTaskRequest{WorkspaceID: &ws, AssigneeID: &outsider}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Title = tt.name
+			_, err := env.svc.Create(tt.userID, tt.req)
+			wantError(t, err, tt.status, tt.message)
+		})
+	}
+	if got := env.eventTypes(); len(got) != 0 {
+		t.Errorf("failed creates published %v", got)
+	}
+}
+
+func TestGet(t *testing.T) {
+	env := newTestEnv(t)
+	alice, bob := env.user(t, "alice"), env.user(t, "bob")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Mine"})
+
+	if got, err := env.svc.Get(alice, task.ID); err != nil || got.ID != task.ID {
+		t.Errorf("Get by owner = %+v, %v", got, err)
+	}
+	_, err := env.svc.Get(bob, task.ID)
+	wantError(t, err, http.StatusNotFound, "Task not found")
+	_, err = env.svc.Get(alice, task.ID+100)
+	wantError(t, err, http.StatusNotFound, "Task not found")
+}
+
+func TestUpdate(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Draft", Priority: models.PriorityLow})
+	env.eventTypes()
+
+	updated, err := env.svc.Update(alice, task.ID, []int64{int64(task.Version)}, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{Title: "Final", Status: models.StatusDone}, nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Title != "Final" || updated.Status != models.StatusDone || !updated.IsCompleted || updated.Version != task.Version+1 {
+		t.Errorf("updated = %+v", updated)
+	}
+	// Field yang tidak diisi kembali ke default, seperti PUT
+	if updated.Priority != models.PriorityMedium {
+		t.Errorf("priority = %s, want medium", updated.Priority)
+	}
+	if got, want := env.eventTypes(), []events.Type{events.TaskUpdated, events.TaskCompleted}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	// is_completed saja memindah task ke kolom open pertama
+	reopened, err := env.svc.Update(alice, task.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{Title: current.Title, IsCompleted: boolPtr(false)}, nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if reopened.Status != models.StatusTodo || reopened.IsCompleted {
+		t.Errorf("reopened = %s/%v, want todo/false", reopened.Status, reopened.IsCompleted)
+	}
+}
+
+func TestUpdateVersionMismatch(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Draft"})
+
+	called := false
+	_, err := env.svc.Update(alice, task.ID, []int64{int64(task.Version + 1)}, func(current models.Task) (models.TaskDocument, error) {
+		called = true
+		return models.TaskDocument{Title: "Lost"}, nil
+	})
+	wantError(t, err, http.StatusPreconditionFailed, "")
+	if se := (*Error)(nil); errors.As(err, &se) && se.Version != task.Version {
+		t.Errorf("mismatch version = %d, want %d", se.Version, task.Version)
+	}
+	if called {
+		t.Error("document was built despite the version mismatch")
+	}
+}
+
+func TestUpdateRollsBack(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Draft"})
+	env.eventTypes()
+
+	_, err := env.svc.Update(alice, task.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{Title: "Changed", Status: "missing"}, nil
+	})
+	wantError(t, err, http.StatusBadRequest, "Unknown status")
+
+	_, err = env.svc.Update(alice, task.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{}, errors.New("bad document")
+	})
+	wantError(t, err, http.StatusBadRequest, "bad document")
+
+	got, _ := env.tasks.Get(task.ID)
+	if got.Title != "Draft" || got.Version != task.Version {
+		t.Errorf("task after failed updates = %+v", got)
+	}
+	if types := env.eventTypes(); len(types) != 0 {
+		t.Errorf("failed updates published %v", types)
+	}
+}
+
+func TestUpdateReschedulesReminders(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	due := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Due", DueDate: &due})
+
+	update := func(due time.Time) {
+		t.Helper()
+		_, err := env.svc.Update(alice, task.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+			return models.TaskDocument{Title: current.Title, DueDate: &due}, nil
+		})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	update(due.In(time.FixedZone("WIB", 7*3600)))
+	if got := env.store.Rescheduled(); len(got) != 0 {
+		t.Errorf("same instant rescheduled %v", got)
+	}
+	update(due.Add(time.Hour))
+	if got := env.store.Rescheduled(); !reflect.DeepEqual(got, []int{task.ID}) {
+		t.Errorf("rescheduled = %v, want [%d]", got, task.ID)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	env := newTestEnv(t)
+	owner, bob, carol := env.user(t, "owner"), env.user(t, "bob"), env.user(t, "carol")
+	ws := env.store.AddWorkspace(owner)
+	env.store.AddMember(ws, bob, authz.RoleViewer)
+
+	task := env.create(t, owner, models.CreateTaskRequest{
+		Title:       "Review with @bob@example.com",
+		Description: strPtr("and @carol@example.com"),
+		WorkspaceID: &ws,
+	})
+	if len(env.events) != 2 || env.events[1].Type != events.TaskMentioned || !reflect.DeepEqual(env.events[1].Mentioned, []int{bob}) {
+		t.Fatalf("events = %+v, want task.mentioned for bob only", env.events)
+	}
+	env.eventTypes()
+
+	// Mention yang sudah ada tidak dikirim lagi
+	env.store.AddMember(ws, carol, authz.RoleMember)
+	_, err := env.svc.Update(owner, task.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{Title: current.Title + " @carol@example.com", Description: current.Description}, nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, want := env.eventTypes(), []events.Type{events.TaskUpdated}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestAssign(t *testing.T) {
+	env := newTestEnv(t)
+	owner, member, viewer := env.user(t, "owner"), env.user(t, "member"), env.user(t, "viewer")
+	ws := env.store.AddWorkspace(owner)
+	env.store.AddMember(ws, member, authz.RoleMember)
+	env.store.AddMember(ws, viewer, authz.RoleViewer)
+	task := env.create(t, owner, models.CreateTaskRequest{Title: "Shared", WorkspaceID: &ws})
+	env.eventTypes()
+
+	assigned, err := env.svc.Assign(owner, task.ID, &member, nil)
+	if err != nil || assigned.AssigneeID == nil || *assigned.AssigneeID != member {
+		t.Fatalf("Assign = %+v, %v", assigned, err)
+	}
+	if got, want := env.eventTypes(), []events.Type{events.TaskAssigned, events.TaskUpdated}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	_, err = env.svc.Assign(owner, task.ID, &viewer, nil)
+	wantError(t, err, http.StatusBadRequest, "")
+	_, err = env.svc.Assign(viewer, task.ID, nil, nil)
+	wantError(t, err, http.StatusForbidden, "")
+
+	unassigned, err := env.svc.Assign(member, task.ID, nil, []int64{int64(assigned.Version)})
+	if err != nil || unassigned.AssigneeID != nil {
+		t.Errorf("unassign = %+v, %v", unassigned, err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	b := repository.Board{UserID: alice}
+	a := env.create(t, alice, models.CreateTaskRequest{Title: "A"})
+	bb := env.create(t, alice, models.CreateTaskRequest{Title: "B"})
+	c := env.create(t, alice, models.CreateTaskRequest{Title: "C"})
+	other := env.create(t, alice, models.CreateTaskRequest{Title: "Other", Status: models.StatusReview})
+
+	column := func(status string) []string {
+		t.Helper()
+		tasks, err := env.tasks.List(alice, repository.TaskFilter{Board: &b, Status: status})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		return titles(tasks)
+	}
+
+	if _, err := env.svc.Move(alice, c.ID, models.MoveTaskRequest{Status: models.StatusTodo, AfterID: &a.ID}); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if got, want := column(models.StatusTodo), []string{"A", "C", "B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("todo = %v, want %v", got, want)
+	}
+
+	if _, err := env.svc.Move(alice, a.ID, models.MoveTaskRequest{Status: models.StatusReview, BeforeID: &other.ID}); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if got, want := column(models.StatusReview), []string{"A", "Other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("review = %v, want %v", got, want)
+	}
+
+	done, err := env.svc.Move(alice, bb.ID, models.MoveTaskRequest{Status: models.StatusDone})
+	if err != nil || !done.IsCompleted {
+		t.Errorf("move to done = %+v, %v", done, err)
+	}
+
+	_, err = env.svc.Move(alice, c.ID, models.MoveTaskRequest{Status: models.StatusTodo, AfterID: &c.ID})
+	wantError(t, err, http.StatusBadRequest, "Task cannot be positioned relative to itself")
+	_, err = env.svc.Move(alice, c.ID, models.MoveTaskRequest{Status: models.StatusTodo, AfterID: &other.ID})
+	wantError(t, err, http.StatusBadRequest, "after_id and before_id must reference tasks in the target column")
+	_, err = env.svc.Move(alice, c.ID, models.MoveTaskRequest{Status: "missing"})
+	wantError(t, err, http.StatusBadRequest, "Unknown status")
+}
+
+func TestMoveRebalances(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	b := repository.Board{UserID: alice}
+	a := env.create(t, alice, models.CreateTaskRequest{Title: "A"})
+	bb := env.create(t, alice, models.CreateTaskRequest{Title: "B"})
+	c := env.create(t, alice, models.CreateTaskRequest{Title: "C"})
+
+	// Data lama: semua task di kolom punya position yang sama
+	for _, task := range []models.Task{a, bb, c} {
+		task.Position = "a0"
+		if _, err := env.tasks.Update(task); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+
+	if _, err := env.svc.Move(alice, a.ID, models.MoveTaskRequest{Status: models.StatusTodo, AfterID: &c.ID, BeforeID: &bb.ID}); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	tasks, _ := env.tasks.List(alice, repository.TaskFilter{Board: &b})
+	if got, want := titles(tasks), []string{"C", "A", "B"}; !reflect.DeepEqual(got, want) {
+		// Rebalance mempertahankan urutan tampilan (terbaru dulu untuk position yang sama)
+		t.Errorf("board = %v, want %v", got, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	env := newTestEnv(t)
+	owner, member := env.user(t, "owner"), env.user(t, "member")
+	ws := env.store.AddWorkspace(owner)
+	env.store.AddMember(ws, member, authz.RoleMember)
+	task := env.create(t, owner, models.CreateTaskRequest{Title: "Owner's", WorkspaceID: &ws})
+	env.eventTypes()
+
+	_, err := env.svc.Delete(member, task.ID, nil)
+	wantError(t, err, http.StatusForbidden, "")
+	_, err = env.svc.Delete(owner, task.ID, []int64{99})
+	wantError(t, err, http.StatusPreconditionFailed, "")
+
+	deleted, err := env.svc.Delete(owner, task.ID, []int64{int64(task.Version)})
+	if err != nil || deleted.Title != "Owner's" {
+		t.Fatalf("Delete = %+v, %v", deleted, err)
+	}
+	if len(env.events) != 1 || env.events[0].Type != events.TaskDeleted || env.events[0].Task.ID != task.ID {
+		t.Errorf("events = %+v, want task.deleted with the snapshot", env.events)
+	}
+	if _, err := env.tasks.Get(task.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Get after delete: %v", err)
+	}
+}
+
+func TestToggleComplete(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Toggle", Status: models.StatusInProgress})
+
+	done, err := env.svc.ToggleComplete(alice, task.ID, nil)
+	if err != nil || done.Status != models.StatusDone || !done.IsCompleted {
+		t.Fatalf("toggle = %+v, %v", done, err)
+	}
+	open, err := env.svc.ToggleComplete(alice, task.ID, nil)
+	if err != nil || open.Status != models.StatusTodo || open.IsCompleted {
+		t.Errorf("toggle back = %+v, %v", open, err)
+	}
+}
+
+func TestUpdateShared(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	task := env.create(t, alice, models.CreateTaskRequest{Title: "Public", Description: strPtr("old")})
+	env.eventTypes()
+
+	updated, err := env.svc.UpdateShared(task.ID, models.PublicTaskUpdateRequest{Description: strPtr("new"), IsCompleted: boolPtr(true)})
+	if err != nil {
+		t.Fatalf("UpdateShared: %v", err)
+	}
+	if *updated.Description != "new" || updated.Status != models.StatusDone || updated.Title != "Public" {
+		t.Errorf("updated = %+v", updated)
+	}
+	if len(env.events) != 2 || env.events[0].ActorID != 0 || env.events[1].Type != events.TaskCompleted {
+		t.Errorf("events = %+v, want task.updated and task.completed without actor", env.events)
+	}
+
+	_, err = env.svc.UpdateShared(task.ID+100, models.PublicTaskUpdateRequest{})
+	wantError(t, err, http.StatusNotFound, "Task not found")
+}
+
+func TestListAndStats(t *testing.T) {
+	env := newTestEnv(t)
+	alice, bob := env.user(t, "alice"), env.user(t, "bob")
+	ws := env.store.AddWorkspace(alice)
+	env.store.AddMember(ws, bob, authz.RoleMember)
+	archived := env.store.AddProject(repository.Board{UserID: alice, WorkspaceID: &ws}, false)
+	past := time.Now().Add(-time.Hour)
+
+	env.create(t, alice, models.CreateTaskRequest{Title: "Personal", Priority: models.PriorityHigh, DueDate: &past})
+	env.create(t, alice, models.CreateTaskRequest{Title: "Team", WorkspaceID: &ws, AssigneeID: &bob})
+	env.create(t, bob, models.CreateTaskRequest{Title: "Bob's", WorkspaceID: &ws, Description: strPtr("needs REVIEW"), IsCompleted: boolPtr(true)})
+	env.create(t, alice, models.CreateTaskRequest{Title: "Archived", WorkspaceID: &ws, ProjectID: &archived})
+	env.store.ArchiveProject(archived)
+
+	tests := []struct {
+		name   string
+		viewer int
+		filter repository.TaskFilter
+		want   []string
+	}{
+		{"everything visible", alice, repository.TaskFilter{}, []string{"Bob's", "Team", "Personal"}},
+		{"bob cannot see personal tasks", bob, repository.TaskFilter{}, []string{"Bob's", "Team"}},
+		{"include archived", alice, repository.TaskFilter{IncludeArchived: true}, []string{"Archived", "Bob's", "Team", "Personal"}},
+		{"personal", alice, repository.TaskFilter{WorkspaceID: "personal"}, []string{"Personal"}},
+		{"assigned to me", bob, repository.TaskFilter{Assignee: "me"}, []string{"Team"}},
+		{"unassigned", alice, repository.TaskFilter{Assignee: "none"}, []string{"Bob's", "Personal"}},
+		{"created by me", bob, repository.TaskFilter{CreatedBy: "me"}, []string{"Bob's"}},
+		{"search description", alice, repository.TaskFilter{Search: "review"}, []string{"Bob's"}},
+		{"completed", alice, repository.TaskFilter{IsCompleted: boolPtr(true)}, []string{"Bob's"}},
+		{"limit and offset", alice, repository.TaskFilter{Limit: 1, Offset: 1}, []string{"Team"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := env.svc.List(tt.viewer, tt.filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if got := titles(tasks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	stats, err := env.svc.Stats(alice, repository.TaskFilter{})
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Total != 3 || stats.Completed != 1 || stats.Pending != 2 || stats.HighPriority != 1 || stats.Overdue != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if len(stats.ByAssignee) != 2 || stats.ByAssignee[0].AssigneeID != nil || stats.ByAssignee[0].Total != 2 ||
+		stats.ByAssignee[1].Name != "bob" || stats.ByAssignee[1].Pending != 1 {
+		t.Errorf("by assignee = %+v", stats.ByAssignee)
+	}
+}
+
+func TestBoard(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+	env.create(t, alice, models.CreateTaskRequest{Title: "One"})
+	env.create(t, alice, models.CreateTaskRequest{Title: "Two"})
+	env.create(t, alice, models.CreateTaskRequest{Title: "Done", IsCompleted: boolPtr(true)})
+
+	columns, err := env.svc.Board(repository.Board{UserID: alice}, repository.TaskFilter{})
+	if err != nil {
+		t.Fatalf("Board: %v", err)
+	}
+	var got []string
+	for _, col := range columns {
+		got = append(got, col.Status.Key+"="+joinTitles(col.Tasks))
+	}
+	want := []string{"todo=One,Two", "in_progress=", "review=", "done=Done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("board = %v, want %v", got, want)
+	}
+}
+
+func TestImport(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.user(t, "alice")
+
+	byName, err := env.svc.Import(alice, models.CreateTaskRequest{Title: "By name", Status: "in progress"})
+	if err != nil || byName.Status != models.StatusInProgress {
+		t.Errorf("by name = %+v, %v", byName, err)
+	}
+	byKey, err := env.svc.Import(alice, models.CreateTaskRequest{Title: "By key", Status: "DONE"})
+	if err != nil || byKey.Status != models.StatusDone || !byKey.IsCompleted {
+		t.Errorf("by key = %+v, %v", byKey, err)
+	}
+	unknown, err := env.svc.Import(alice, models.CreateTaskRequest{Title: "Unknown", Status: "someday"})
+	if err != nil || unknown.Status != models.StatusTodo {
+		t.Errorf("unknown = %+v, %v", unknown, err)
+	}
+}
+
+func joinTitles(tasks []models.Task) string {
+	out := ""
+	for i, task := range tasks {
+		if i > 0 {
+			out += ","
+		}
+		out += task.Title
+	}
+	return out
+}
+
+// TestConcurrentAppendsGetDistinctPositions - Create, ToggleComplete, Update dan
+// UpdateShared yang menaruh task di bawah kolom yang sama secara bersamaan tidak
+// boleh mendapat position yang sama. Memakai database SQL sungguhan, karena
+// balapan ini ada di antara transaksi.
+func TestConcurrentAppendsGetDistinctPositions(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repository.NewUserRepository(db)
+	svc := NewTaskService(repository.NewTaskRepository(db), users, authz.New(db), events.NewBus())
+
+	u, err := users.Create("alice", "alice@example.com", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var open []models.Task
+	for i := 0; i < 9; i++ {
+		task, err := svc.Create(u.ID, models.CreateTaskRequest{Title: "open"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		open = append(open, task)
+	}
+
+	// Semua perubahan menaruh task di bawah kolom done
+	ops := []func(i int) error{
+		func(i int) error {
+			_, err := svc.Create(u.ID, models.CreateTaskRequest{Title: "new", Status: models.StatusDone})
+			return err
+		},
+		func(i int) error {
+			_, err := svc.ToggleComplete(u.ID, open[i].ID, nil)
+			return err
+		},
+		func(i int) error {
+			_, err := svc.UpdateShared(open[i].ID, models.PublicTaskUpdateRequest{IsCompleted: boolPtr(true)})
+			return err
+		},
+		func(i int) error {
+			_, err := svc.Update(u.ID, open[i].ID, nil, func(current models.Task) (models.TaskDocument, error) {
+				return models.TaskDocument{Title: current.Title, Status: models.StatusDone}, nil
+			})
+			return err
+		},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(open)+6)
+	for i := range open {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- ops[1+i%3](i)
+		}(i)
+	}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- ops[0](i)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent change: %v", err)
+		}
+	}
+
+	done, err := svc.List(u.ID, repository.TaskFilter{Status: models.StatusDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != len(open)+6 {
+		t.Fatalf("done column has %d tasks, want %d", len(done), len(open)+6)
+	}
+	seen := map[string]int{}
+	for _, task := range done {
+		if other, ok := seen[task.Position]; ok {
+			t.Errorf("tasks %d and %d share position %q", other, task.ID, task.Position)
+		}
+		seen[task.Position] = task.ID
+	}
+}
+
+// TestProjectStatusesFollowTask - task memakai kolom project-nya; keluar dari
+// project (atau project-nya dihapus) membuat status yang hanya ada di project
+// diganti kolom board pertama
+func TestProjectStatusesFollowTask(t *testing.T) {
+	db := dbtest.Open(t)
+	users := repository.NewUserRepository(db)
+	projects := repository.NewProjectRepository(db)
+	statuses := repository.NewStatusRepository(db)
+	svc := NewTaskService(repository.NewTaskRepository(db), users, authz.New(db), events.NewBus())
+
+	u, err := users.Create("alice", "alice@example.com", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, err := projects.Create(repository.Board{UserID: u.ID}, "Launch", "", "#6366f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb := repository.Board{UserID: u.ID, ProjectID: &project.ID}
+	if _, err := statuses.Customize(pb); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := statuses.Create(pb, models.TaskStatus{Key: "qa", Name: "QA", Position: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	leaving, err := svc.Create(u.ID, models.CreateTaskRequest{Title: "Leaving", ProjectID: &project.ID, Status: "qa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	staying, err := svc.Create(u.ID, models.CreateTaskRequest{Title: "Staying", ProjectID: &project.ID, Status: "qa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keluar dari project tanpa mengubah status
+	moved, err := svc.Update(u.ID, leaving.ID, nil, func(current models.Task) (models.TaskDocument, error) {
+		return models.TaskDocument{Title: current.Title, Status: current.Status}, nil
+	})
+	if err != nil || moved.Status != models.StatusTodo || moved.ProjectID != nil {
+		t.Errorf("moved out of project = %+v, %v; want todo without project", moved, err)
+	}
+
+	// Status project masih bisa dipilih di dalam project
+	if _, err := svc.Move(u.ID, staying.ID, models.MoveTaskRequest{Status: "qa"}); err != nil {
+		t.Errorf("move within project statuses: %v", err)
+	}
+	toggled, err := svc.ToggleComplete(u.ID, staying.ID, nil)
+	if err != nil || toggled.Status != models.StatusDone {
+		t.Fatalf("toggle in project = %+v, %v", toggled, err)
+	}
+	toggled, err = svc.ToggleComplete(u.ID, staying.ID, nil)
+	if err != nil || toggled.Status != models.StatusTodo {
+		t.Fatalf("toggle back in project = %+v, %v", toggled, err)
+	}
+	if _, err := svc.Move(u.ID, staying.ID, models.MoveTaskRequest{Status: "qa"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := projects.Delete(project.ID); err != nil {
+		t.Fatalf("delete project: %v", err)
+	}
+	orphan, err := svc.Get(u.ID, staying.ID)
+	if err != nil || orphan.Status != models.StatusTodo || orphan.ProjectID != nil {
+		t.Errorf("task of deleted project = %+v, %v; want todo on the board", orphan, err)
+	}
+}
@@ -0,0 +1,272 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// Position keys are fractional indexes: strings that sort lexicographically
+// (byte order, so columns must use COLLATE "C") and for which a new key can
+// always be generated between any two existing ones without renumbering.
+//
+// A key is an integer part followed by an optional fraction. The first
+// character of the integer part encodes its length ('a' = 2 chars, 'b' = 3,
+// ..., 'A' = 27, 'B' = 26, ...), the fraction never ends in the zero digit.
+
+const positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var smallestInteger = "A" + strings.Repeat("0", 26)
+
+var ErrInvalidPosition = errors.New("invalid position key")
+
+// KeyBetween - membuat position key di antara a dan b.
+// Empty a means "start of the list", empty b means "end of the list".
+func KeyBetween(a, b string) (string, error) {
+	if a != "" {
+		if err := validatePosition(a); err != nil {
+			return "", err
+		}
+	}
+	if b != "" {
+		if err := validatePosition(b); err != nil {
+			return "", err
+		}
+	}
+	if a != "" && b != "" && a >= b {
+		return "", errors.New("position keys out of order")
+	}
+
+	if a == "" {
+		if b == "" {
+			return "a0", nil
+		}
+		ib, _ := integerPart(b)
+		fb := b[len(ib):]
+		if ib == smallestInteger {
+			mid, err := midpoint("", fb, true)
+			if err != nil {
+				return "", err
+			}
+			return ib + mid, nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		res, ok := decrementInteger(ib)
+		if !ok {
+			return "", errors.New("cannot decrement any more")
+		}
+		return res, nil
+	}
+
+	if b == "" {
+		ia, _ := integerPart(a)
+		fa := a[len(ia):]
+		if i, ok := incrementInteger(ia); ok {
+			return i, nil
+		}
+		mid, err := midpoint(fa, "", false)
+		if err != nil {
+			return "", err
+		}
+		return ia + mid, nil
+	}
+
+	ia, _ := integerPart(a)
+	fa := a[len(ia):]
+	ib, _ := integerPart(b)
+	fb := b[len(ib):]
+	if ia == ib {
+		mid, err := midpoint(fa, fb, true)
+		if err != nil {
+			return "", err
+		}
+		return ia + mid, nil
+	}
+	i, ok := incrementInteger(ia)
+	if !ok {
+		return "", errors.New("cannot increment any more")
+	}
+	if i < b {
+		return i, nil
+	}
+	mid, err := midpoint(fa, "", false)
+	if err != nil {
+		return "", err
+	}
+	return ia + mid, nil
+}
+
+// NKeysBetween - membuat n position key berurutan di antara a dan b.
+func NKeysBetween(a, b string, n int) ([]string, error) {
+	keys := make([]string, 0, n)
+	prev := a
+	for i := 0; i < n; i++ {
+		key, err := KeyBetween(prev, b)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		prev = key
+	}
+	return keys, nil
+}
+
+func midpoint(a, b string, hasB bool) (string, error) {
+	if hasB && a >= b {
+		return "", ErrInvalidPosition
+	}
+	if strings.HasSuffix(a, "0") || (hasB && strings.HasSuffix(b, "0")) {
+		return "", ErrInvalidPosition
+	}
+
+	if hasB {
+		// Skip the common prefix
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			mid, err := midpoint(a[min(n, len(a)):], b[n:], true)
+			if err != nil {
+				return "", err
+			}
+			return b[:n] + mid, nil
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(positionDigits, a[0])
+	}
+	digitB := len(positionDigits)
+	if hasB {
+		digitB = strings.IndexByte(positionDigits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(positionDigits[(digitA+digitB+1)/2]), nil
+	}
+	if hasB && len(b) > 1 {
+		return b[:1], nil
+	}
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	mid, err := midpoint(rest, "", false)
+	if err != nil {
+		return "", err
+	}
+	return string(positionDigits[digitA]) + mid, nil
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return positionDigits[0]
+}
+
+func integerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+func integerPart(key string) (string, error) {
+	if key == "" {
+		return "", ErrInvalidPosition
+	}
+	n, ok := integerLength(key[0])
+	if !ok || n > len(key) {
+		return "", ErrInvalidPosition
+	}
+	return key[:n], nil
+}
+
+func validatePosition(key string) error {
+	if key == smallestInteger {
+		return ErrInvalidPosition
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(positionDigits, key[i]) < 0 {
+			return ErrInvalidPosition
+		}
+	}
+	i, err := integerPart(key)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(key[len(i):], "0") {
+		return ErrInvalidPosition
+	}
+	return nil
+}
+
+func incrementInteger(x string) (string, bool) {
+	head := x[0]
+	digs := []byte(x[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(positionDigits, digs[i]) + 1
+		if d == len(positionDigits) {
+			digs[i] = positionDigits[0]
+		} else {
+			digs[i] = positionDigits[d]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digs), true
+	}
+	if head == 'Z' {
+		return "a" + string(positionDigits[0]), true
+	}
+	if head == 'z' {
+		return "", false
+	}
+	h := head + 1
+	if h > 'a' {
+		digs = append(digs, positionDigits[0])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(h) + string(digs), true
+}
+
+func decrementInteger(x string) (string, bool) {
+	head := x[0]
+	digs := []byte(x[1:])
+	borrow := true
+	last := positionDigits[len(positionDigits)-1]
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(positionDigits, digs[i]) - 1
+		if d == -1 {
+			digs[i] = last
+		} else {
+			digs[i] = positionDigits[d]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+	if head == 'a' {
+		return "Z" + string(last), true
+	}
+	if head == 'A' {
+		return "", false
+	}
+	h := head - 1
+	if h < 'Z' {
+		digs = append(digs, last)
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(h) + string(digs), true
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress - tujuan webhook bukan alamat internet publik
+var ErrPrivateAddress = errors.New("webhook URL must resolve to a public address")
+
+// reservedPrefixes - rentang non-publik di luar loopback, privat, link-local,
+// multicast dan unspecified (yang sudah ditolak lewat method netip.Addr)
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, termasuk broadcast
+	netip.MustParsePrefix("64:ff9b:1::/48"), // NAT64 lokal
+	netip.MustParsePrefix("100::/64"),       // discard
+	netip.MustParsePrefix("fec0::/10"),      // site-local (usang)
+}
+
+// Policy - alamat mana yang boleh dihubungi webhook. Tanpa AllowPrivate hanya
+// alamat publik: user tidak boleh memakai webhook untuk menjangkau host internal
+// (localhost, jaringan privat, metadata cloud di 169.254.169.254).
+type Policy struct {
+	AllowPrivate bool
+}
+
+// CheckURL - validasi saat webhook didaftarkan: http/https dengan host, dan
+// bukan IP literal atau nama localhost yang non-publik. Nama host lain baru
+// bisa dicek saat koneksi dibuat, lihat Client.
+func (p Policy) CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an http or https URL")
+	}
+	if u.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+	if p.AllowPrivate {
+		return nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !publicAddr(addr) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// Client - HTTP client untuk delivery. Alamat tujuan dicek setelah DNS
+// di-resolve, tepat sebelum connect, jadi nama host yang menunjuk (atau
+// belakangan diarahkan) ke alamat internal tetap ditolak. Redirect tidak
+// diikuti dan proxy dari environment tidak dipakai, karena keduanya membuat
+// koneksi ke alamat yang tidak dicek.
+func (p Policy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !p.AllowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("webhook: unexpected dial address %q", address)
+			}
+			if !publicAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w (%s)", ErrPrivateAddress, addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicAddr - false untuk loopback, privat, link-local, multicast dan rentang
+// khusus lainnya. IPv4 dalam IPv6 (::ffff:a.b.c.d) dicek sebagai IPv4.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
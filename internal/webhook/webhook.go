@@ -0,0 +1,294 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"taskflow-api/internal/database"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/utils"
+)
+
+const (
+	// batchSize - jumlah delivery yang diklaim per putaran
+	batchSize = 20
+	// pollInterval - seberapa sering antrean dicek kalau tidak ada event baru
+	pollInterval = 5 * time.Second
+	// lease - delivery yang diklaim tapi tidak selesai (mis. proses mati) dicoba lagi setelah ini
+	lease = 2 * time.Minute
+	// maxAttempts - setelah ini delivery ditandai failed
+	maxAttempts = 10
+	// baseBackoff dan maxBackoff - retry ke-n menunggu baseBackoff * 2^(n-1), maksimal maxBackoff
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+	// maxResponseBody - response dibaca sampai sini supaya koneksi bisa dipakai ulang;
+	// isinya tidak disimpan
+	maxResponseBody = 2048
+)
+
+// Events - tipe event yang bisa di-subscribe
+var Events = []events.Type{events.TaskCreated, events.TaskUpdated, events.TaskCompleted, events.TaskDeleted}
+
+// Payload - body JSON yang dikirim ke endpoint
+type Payload struct {
+	ID         string      `json:"id"`
+	Type       events.Type `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	ActorID    int         `json:"actor_id"`
+	Data       PayloadData `json:"data"`
+}
+
+type PayloadData struct {
+	Task models.Task `json:"task"`
+}
+
+// Dispatcher - memasukkan event ke antrean webhook_deliveries lalu mengirimnya.
+// Antrean ada di database: delivery diklaim dengan FOR UPDATE SKIP LOCKED dan
+// lease, jadi aman dijalankan di beberapa instance dan selamat dari restart.
+type Dispatcher struct {
+	db      *sql.DB
+	dialect database.Dialect
+	client  *http.Client
+	wake    chan struct{}
+}
+
+func NewDispatcher(db *sql.DB, policy Policy) *Dispatcher {
+	return &Dispatcher{
+		db:      db,
+		dialect: database.DialectOf(db),
+		client:  policy.Client(10 * time.Second),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent - subscriber untuk events.Bus; hanya menulis ke antrean
+func (d *Dispatcher) HandleEvent(e events.Event) {
+	if !subscribable(e.Type) {
+		return
+	}
+
+	id, err := utils.GenerateToken(16)
+	if err != nil {
+		log.Printf("webhook: event id: %v", err)
+		return
+	}
+	payload, err := json.Marshal(Payload{
+		ID:         id,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		ActorID:    e.ActorID,
+		Data:       PayloadData{Task: e.Task},
+	})
+	if err != nil {
+		log.Printf("webhook: encode %s: %v", e.Type, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Webhook workspace hanya aktif selama pembuatnya masih owner/admin
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+		 SELECT w.id, $1, $2 FROM webhooks w
+		 WHERE w.is_active AND `+d.dialect.InArray("$1", "w.events")+`
+		   AND ((w.workspace_id IS NULL AND CAST($3 AS integer) IS NULL AND w.user_id = $4)
+		     OR (w.workspace_id = $3 AND w.user_id IN (
+		           SELECT user_id FROM workspace_members
+		           WHERE workspace_id = w.workspace_id AND role IN ('owner', 'admin'))))`,
+		string(e.Type), string(payload), e.Task.WorkspaceID, e.Task.UserID,
+	)
+	if err != nil {
+		log.Printf("webhook: enqueue %s for task %d: %v", e.Type, e.Task.ID, err)
+		return
+	}
+
+	if n, _ := result.RowsAffected(); n > 0 {
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run - kirim delivery yang jatuh tempo sampai ctx dibatalkan
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.deliverBatch(ctx)
+			if err != nil {
+				log.Printf("webhook: %v", err)
+			}
+			if n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+type claimed struct {
+	id        int
+	eventType string
+	payload   string
+	attempts  int
+	url       string
+	secret    string
+}
+
+func (d *Dispatcher) deliverBatch(ctx context.Context) (int, error) {
+	now := time.Now()
+	rows, err := d.db.QueryContext(ctx,
+		`UPDATE webhook_deliveries
+		 SET attempts = attempts + 1, last_attempt_at = $1, next_attempt_at = $2
+		 WHERE id IN (
+		     SELECT q.id FROM webhook_deliveries q
+		     JOIN webhooks qw ON qw.id = q.webhook_id
+		     WHERE q.status = 'pending' AND q.next_attempt_at <= $1 AND qw.is_active
+		     ORDER BY q.next_attempt_at
+		     LIMIT $3`+d.dialect.LockRows("FOR UPDATE OF q SKIP LOCKED")+`)
+		 RETURNING id, event_type, payload, attempts,
+		     (SELECT url FROM webhooks WHERE id = webhook_deliveries.webhook_id),
+		     (SELECT secret FROM webhooks WHERE id = webhook_deliveries.webhook_id)`,
+		now, now.Add(lease), batchSize,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var batch []claimed
+	for rows.Next() {
+		var c claimed
+		if err := rows.Scan(&c.id, &c.eventType, &c.payload, &c.attempts, &c.url, &c.secret); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, c := range batch {
+		d.deliver(ctx, c)
+	}
+	return len(batch), nil
+}
+
+// deliver - kirim satu delivery dan simpan hasilnya
+func (d *Dispatcher) deliver(ctx context.Context, c claimed) {
+	status, err := d.post(ctx, c)
+
+	var responseStatus *int
+	if status != 0 {
+		responseStatus = &status
+	}
+	var errText *string
+	if err != nil {
+		s := err.Error()
+		errText = &s
+	}
+
+	state := models.DeliverySucceeded
+	var nextAttempt *time.Time
+	if err != nil {
+		state = models.DeliveryFailed
+		if c.attempts < maxAttempts {
+			state = models.DeliveryPending
+			t := time.Now().Add(backoff(c.attempts))
+			nextAttempt = &t
+		}
+	}
+
+	_, dbErr := d.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries
+		 SET status = $1, next_attempt_at = $2, response_status = $3, error = $4
+		 WHERE id = $5`,
+		state, nextAttempt, responseStatus, errText, c.id,
+	)
+	if dbErr != nil {
+		log.Printf("webhook: save delivery %d: %v", c.id, dbErr)
+	}
+}
+
+// post - HTTP POST payload bertanda tangan; error kalau gagal atau status bukan 2xx.
+// Response body tidak disimpan: isinya dari server lain dan tidak perlu dilihat
+// pemilik webhook. Redirect (3xx) dihitung gagal.
+func (d *Dispatcher) post(ctx context.Context, c claimed) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader([]byte(c.payload)))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TaskFlow-Webhook")
+	req.Header.Set("X-TaskFlow-Event", c.eventType)
+	req.Header.Set("X-TaskFlow-Delivery", strconv.Itoa(c.id))
+	req.Header.Set("X-TaskFlow-Timestamp", timestamp)
+	req.Header.Set("X-TaskFlow-Signature", "sha256="+Sign(c.secret, timestamp, []byte(c.payload)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign - HMAC-SHA256 (hex) atas "<timestamp>.<body>" dengan secret webhook
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff - jeda sebelum percobaan berikutnya setelah attempts kali gagal
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
+
+func subscribable(t events.Type) bool {
+	for _, e := range Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/database/dbtest"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+)
+
+func TestSign(t *testing.T) {
+	// Dihitung terpisah: HMAC-SHA256("whsec", `1700000000.{"id":"1"}`)
+	want := "60734808e731b08d45bee887cade715d87211348f1bcb975b46c8d2e7fa5dbcd"
+	if got := Sign("whsec", "1700000000", []byte(`{"id":"1"}`)); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+	if Sign("other", "1700000000", []byte(`{"id":"1"}`)) == want {
+		t.Error("signature does not depend on the secret")
+	}
+	if Sign("whsec", "1700000001", []byte(`{"id":"1"}`)) == want {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: baseBackoff},
+		{attempts: 2, want: 2 * baseBackoff},
+		{attempts: 5, want: 16 * baseBackoff},
+		{attempts: 10, want: 512 * baseBackoff},
+		{attempts: 11, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// received - satu request yang diterima endpoint test
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newEndpoint - endpoint webhook yang menjawab dengan status dan mencatat request
+func newEndpoint(t *testing.T, status *int) (*httptest.Server, *[]received) {
+	t.Helper()
+	var got []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, received{header: r.Header.Clone(), body: body})
+		w.WriteHeader(*status)
+		w.Write([]byte("internal details"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &got
+}
+
+// newWebhook - webhook personal userID untuk task.created ke url
+func newWebhook(t *testing.T, db *sql.DB, userID int, url string) {
+	t.Helper()
+	_, err := db.Exec(
+		`INSERT INTO webhooks (user_id, url, secret, events) VALUES ($1, $2, 'whsec', '{"task.created"}')`,
+		userID, url,
+	)
+	if err != nil {
+		t.Fatalf("insert webhook: %v", err)
+	}
+}
+
+type deliveryState struct {
+	status         string
+	attempts       int
+	responseStatus *int
+	nextAttemptAt  *time.Time
+}
+
+func loadDelivery(t *testing.T, db *sql.DB) deliveryState {
+	t.Helper()
+	var d deliveryState
+	err := db.QueryRow(
+		"SELECT status, attempts, response_status, next_attempt_at FROM webhook_deliveries",
+	).Scan(&d.status, &d.attempts, &d.responseStatus, &d.nextAttemptAt)
+	if err != nil {
+		t.Fatalf("load delivery: %v", err)
+	}
+	return d
+}
+
+func TestDispatcherDeliversSignedPayload(t *testing.T) {
+	status := http.StatusOK
+	server, got := newEndpoint(t, &status)
+	db := dbtest.Open(t)
+	userID := dbtest.User(t, db, "a@example.com")
+	newWebhook(t, db, userID, server.URL)
+
+	d := NewDispatcher(db, Policy{AllowPrivate: true})
+	task := models.Task{ID: 7, UserID: userID, Title: "Pay rent"}
+	d.HandleEvent(events.Event{Type: events.TaskCreated, ActorID: userID, Task: task, OccurredAt: time.Now()})
+	// Event yang tidak di-subscribe tidak masuk antrean
+	d.HandleEvent(events.Event{Type: events.TaskDeleted, ActorID: userID, Task: task, OccurredAt: time.Now()})
+
+	if n, err := d.deliverBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("deliverBatch = %d, %v, want 1", n, err)
+	}
+	if len(*got) != 1 {
+		t.Fatalf("endpoint received %d requests, want 1", len(*got))
+	}
+
+	req := (*got)[0]
+	want := "sha256=" + Sign("whsec", req.header.Get("X-TaskFlow-Timestamp"), req.body)
+	if sig := req.header.Get("X-TaskFlow-Signature"); sig != want {
+		t.Errorf("X-TaskFlow-Signature = %s, want %s", sig, want)
+	}
+	if event := req.header.Get("X-TaskFlow-Event"); event != string(events.TaskCreated) {
+		t.Errorf("X-TaskFlow-Event = %s, want %s", event, events.TaskCreated)
+	}
+
+	delivery := loadDelivery(t, db)
+	if delivery.status != models.DeliverySucceeded || delivery.responseStatus == nil || *delivery.responseStatus != http.StatusOK {
+		t.Errorf("delivery = %+v, want succeeded with status 200", delivery)
+	}
+}
+
+func TestDispatcherRetriesFailedDelivery(t *testing.T) {
+	status := http.StatusInternalServerError
+	server, got := newEndpoint(t, &status)
+	db := dbtest.Open(t)
+	userID := dbtest.User(t, db, "a@example.com")
+	newWebhook(t, db, userID, server.URL)
+
+	d := NewDispatcher(db, Policy{AllowPrivate: true})
+	d.HandleEvent(events.Event{Type: events.TaskCreated, ActorID: userID, Task: models.Task{ID: 7, UserID: userID}, OccurredAt: time.Now()})
+	ctx := context.Background()
+
+	if _, err := d.deliverBatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	delivery := loadDelivery(t, db)
+	if delivery.status != models.DeliveryPending || delivery.attempts != 1 || delivery.nextAttemptAt == nil {
+		t.Fatalf("delivery = %+v, want pending after 1 attempt", delivery)
+	}
+	if wait := time.Until(*delivery.nextAttemptAt); wait <= 0 || wait > baseBackoff {
+		t.Errorf("next attempt in %v, want within %v", wait, baseBackoff)
+	}
+
+	// Belum jatuh tempo: tidak dikirim lagi
+	if n, err := d.deliverBatch(ctx); err != nil || n != 0 {
+		t.Fatalf("deliverBatch during backoff = %d, %v, want 0", n, err)
+	}
+
+	// Percobaan terakhir yang gagal menandai delivery failed
+	if _, err := db.Exec("UPDATE webhook_deliveries SET attempts = $1, next_attempt_at = $2", maxAttempts-1, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.deliverBatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if delivery := loadDelivery(t, db); delivery.status != models.DeliveryFailed || delivery.attempts != maxAttempts {
+		t.Errorf("delivery = %+v, want failed after %d attempts", delivery, maxAttempts)
+	}
+	if len(*got) != 2 {
+		t.Errorf("endpoint received %d requests, want 2", len(*got))
+	}
+}
+
+func TestDispatcherRefusesPrivateEndpoint(t *testing.T) {
+	status := http.StatusOK
+	server, got := newEndpoint(t, &status)
+	db := dbtest.Open(t)
+	userID := dbtest.User(t, db, "a@example.com")
+	newWebhook(t, db, userID, server.URL)
+
+	d := NewDispatcher(db, Policy{})
+	d.HandleEvent(events.Event{Type: events.TaskCreated, ActorID: userID, Task: models.Task{ID: 7, UserID: userID}, OccurredAt: time.Now()})
+	if _, err := d.deliverBatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*got) != 0 {
+		t.Errorf("endpoint received %d requests, want 0", len(*got))
+	}
+	if delivery := loadDelivery(t, db); delivery.status != models.DeliveryPending || delivery.responseStatus != nil {
+		t.Errorf("delivery = %+v, want pending without a response", delivery)
+	}
+}
@@ -0,0 +1,4 @@
+-- migrations/001_init.down.sql
+
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS users;
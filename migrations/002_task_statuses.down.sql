@@ -0,0 +1,6 @@
+-- migrations/002_task_statuses.down.sql
+
+DROP INDEX IF EXISTS idx_tasks_status_position;
+ALTER TABLE tasks DROP COLUMN IF EXISTS position;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
+DROP TABLE IF EXISTS task_statuses;
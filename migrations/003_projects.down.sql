@@ -0,0 +1,10 @@
+-- migrations/003_projects.down.sql
+
+DELETE FROM task_statuses WHERE project_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_task_statuses_project_key;
+DROP INDEX IF EXISTS idx_task_statuses_user_key;
+ALTER TABLE task_statuses DROP COLUMN IF EXISTS project_id;
+ALTER TABLE task_statuses ADD CONSTRAINT task_statuses_user_id_key_key UNIQUE (user_id, key);
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
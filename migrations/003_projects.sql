@@ -0,0 +1,25 @@
+-- migrations/003_projects.sql
+
+-- Projects group tasks
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '#6366f1',
+    is_archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+
+-- A project can have its own statuses; tasks in a project without any use the
+-- board's. Board statuses are the rows with project_id NULL.
+ALTER TABLE task_statuses ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE task_statuses DROP CONSTRAINT IF EXISTS task_statuses_user_id_key_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_user_key ON task_statuses(user_id, key) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_project_key ON task_statuses(project_id, key);
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_id ON projects(user_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
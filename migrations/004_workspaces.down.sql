@@ -0,0 +1,17 @@
+-- migrations/004_workspaces.down.sql
+
+-- Workspace tasks stay with their creator as personal tasks; workspace boards and
+-- projects are removed
+DELETE FROM task_statuses WHERE user_id IS NULL;
+DELETE FROM projects WHERE workspace_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_task_statuses_workspace_key;
+ALTER TABLE task_statuses DROP CONSTRAINT IF EXISTS task_statuses_owner_check;
+ALTER TABLE task_statuses DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE task_statuses ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,49 @@
+-- migrations/004_workspaces.sql
+
+-- Shared workspaces
+CREATE TABLE IF NOT EXISTS workspaces (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Invitations by email; only the SHA-256 hash of the token is stored
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id SERIAL PRIMARY KEY,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'member', 'viewer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tasks, projects and board statuses can belong to a workspace.
+-- For workspace tasks user_id is the creator.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+
+-- Workspace boards have their own statuses (user_id NULL)
+ALTER TABLE task_statuses ADD COLUMN IF NOT EXISTS workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE task_statuses ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE task_statuses DROP CONSTRAINT IF EXISTS task_statuses_owner_check;
+ALTER TABLE task_statuses ADD CONSTRAINT task_statuses_owner_check
+    CHECK ((user_id IS NULL) <> (workspace_id IS NULL));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_workspace_key ON task_statuses(workspace_id, key) WHERE project_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_email ON workspace_invitations(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
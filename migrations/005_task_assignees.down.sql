@@ -0,0 +1,3 @@
+-- migrations/005_task_assignees.down.sql
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignee_id;
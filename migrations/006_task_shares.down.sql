@@ -0,0 +1,3 @@
+-- migrations/006_task_shares.down.sql
+
+DROP TABLE IF EXISTS task_shares;
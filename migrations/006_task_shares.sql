@@ -0,0 +1,16 @@
+-- migrations/006_task_shares.sql
+
+-- Public share links for single tasks; only the SHA-256 hash of the token is stored
+CREATE TABLE IF NOT EXISTS task_shares (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_only BOOLEAN NOT NULL DEFAULT TRUE,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    last_accessed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_shares_task_id ON task_shares(task_id);
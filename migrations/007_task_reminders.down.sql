@@ -0,0 +1,9 @@
+-- migrations/007_task_reminders.down.sql
+
+DROP TABLE IF EXISTS notifications;
+DROP INDEX IF EXISTS idx_tasks_due_pending;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_pending_channels;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_next_attempt_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_attempts;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_notified_at;
+DROP TABLE IF EXISTS task_reminders;
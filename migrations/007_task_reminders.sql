@@ -0,0 +1,54 @@
+-- migrations/007_task_reminders.sql
+
+-- Per-user reminders: either an absolute time (remind_at) or minutes before due_date.
+-- fire_at is what the scheduler looks at; it is NULL while an offset reminder has no due date.
+-- Failed deliveries are retried with backoff: attempts counts claims, next_attempt_at
+-- is the lease of a running attempt or the time of the next retry, and
+-- pending_channels holds the channels still to deliver after a partial failure
+-- (NULL = all).
+CREATE TABLE IF NOT EXISTS task_reminders (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    remind_at TIMESTAMP,
+    offset_minutes INTEGER CHECK (offset_minutes >= 0),
+    fire_at TIMESTAMP,
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    sent_at TIMESTAMP,
+    last_error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    pending_channels TEXT[],
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_pending ON task_reminders(fire_at) WHERE sent_at IS NULL;
+
+-- Set once the "task is due" notification went out; cleared when due_date changes.
+-- The due_* retry columns work like those of task_reminders and are reset with it.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_notified_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_next_attempt_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_pending_channels TEXT[];
+
+-- Don't notify about tasks that were already overdue before this migration
+UPDATE tasks SET due_notified_at = CURRENT_TIMESTAMP
+WHERE due_date <= CURRENT_TIMESTAMP AND due_notified_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_pending ON tasks(due_date) WHERE due_notified_at IS NULL AND is_completed = false;
+
+-- In-app notification inbox
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    task_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
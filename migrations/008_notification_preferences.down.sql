@@ -0,0 +1,8 @@
+-- migrations/008_notification_preferences.down.sql
+
+DROP INDEX IF EXISTS idx_notifications_unread;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_soon_pending_channels;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_soon_next_attempt_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_soon_attempts;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_soon_notified_at;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,23 @@
+-- migrations/008_notification_preferences.sql
+
+-- Per-user, per-type delivery preferences; a missing row means every channel is on
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, type)
+);
+
+-- Set once the "due soon" notification went out; cleared when due_date changes.
+-- Retried like the "task is due" notification (see 007).
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_soon_notified_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_soon_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_soon_next_attempt_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_soon_pending_channels TEXT[];
+
+UPDATE tasks SET due_soon_notified_at = CURRENT_TIMESTAMP
+WHERE due_date <= CURRENT_TIMESTAMP + INTERVAL '24 hours' AND due_soon_notified_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
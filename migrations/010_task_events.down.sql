@@ -0,0 +1,4 @@
+-- migrations/010_task_events.down.sql
+
+DROP TABLE IF EXISTS stream_tickets;
+DROP TABLE IF EXISTS task_events;
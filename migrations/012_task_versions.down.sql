@@ -0,0 +1,3 @@
+-- migrations/012_task_versions.down.sql
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
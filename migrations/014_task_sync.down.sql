@@ -0,0 +1,14 @@
+-- migrations/014_task_sync.down.sql
+
+DROP TRIGGER IF EXISTS workspace_members_change ON workspace_members;
+DROP TRIGGER IF EXISTS tasks_tombstone ON tasks;
+DROP TRIGGER IF EXISTS tasks_change_seq_update ON tasks;
+DROP TRIGGER IF EXISTS tasks_change_seq_insert ON tasks;
+DROP FUNCTION IF EXISTS task_tombstone();
+DROP FUNCTION IF EXISTS task_change_seq();
+DROP FUNCTION IF EXISTS membership_change();
+
+DROP TABLE IF EXISTS membership_changes;
+DROP TABLE IF EXISTS task_tombstones;
+DROP INDEX IF EXISTS idx_tasks_change_seq;
+ALTER TABLE tasks DROP COLUMN IF EXISTS change_seq;
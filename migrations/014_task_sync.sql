@@ -0,0 +1,97 @@
+-- migrations/014_task_sync.sql
+
+-- Delta sync for offline clients. change_seq is the id of the transaction that last
+-- changed the task (0 for rows that predate this migration), and deleted tasks leave
+-- a tombstone with the id of the deleting transaction. Unlike a plain sequence,
+-- transaction ids let GET /sync hand out a token below which every change is
+-- committed (the snapshot xmin), so a slow transaction can never be skipped.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_change_seq ON tasks(change_seq);
+
+-- No foreign keys: tombstones are written while users and workspaces are being
+-- deleted (cascade), and a tombstone nobody can see any more is harmless
+CREATE TABLE IF NOT EXISTS task_tombstones (
+    task_id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    workspace_id INTEGER,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tombstones_change_seq ON task_tombstones(change_seq);
+
+-- Joining or leaving a workspace changes which tasks a user can see without
+-- touching the tasks, so membership changes are numbered the same way: GET /sync
+-- sends a new member the workspace's existing tasks and reports them as deleted to
+-- a former member. No foreign keys, like task_tombstones.
+CREATE TABLE IF NOT EXISTS membership_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    workspace_id INTEGER NOT NULL,
+    joined BOOLEAN NOT NULL,
+    change_seq BIGINT NOT NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_membership_changes_user_seq ON membership_changes(user_id, change_seq);
+
+CREATE OR REPLACE FUNCTION task_change_seq() RETURNS trigger AS $$
+BEGIN
+    NEW.change_seq := pg_current_xact_id()::text::bigint;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION task_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO task_tombstones (task_id, user_id, workspace_id, change_seq)
+    VALUES (OLD.id, OLD.user_id, OLD.workspace_id, pg_current_xact_id()::text::bigint)
+    ON CONFLICT (task_id) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION membership_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO membership_changes (user_id, workspace_id, joined, change_seq)
+        VALUES (NEW.user_id, NEW.workspace_id, TRUE, pg_current_xact_id()::text::bigint);
+        RETURN NEW;
+    END IF;
+    INSERT INTO membership_changes (user_id, workspace_id, joined, change_seq)
+    VALUES (OLD.user_id, OLD.workspace_id, FALSE, pg_current_xact_id()::text::bigint);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Triggers instead of application code so FK cascades (project deleted, assignee
+-- removed) and column rebalancing are synced too. Reminder bookkeeping
+-- (due_notified_at, due_soon_notified_at) is not a change clients care about.
+DROP TRIGGER IF EXISTS tasks_change_seq_insert ON tasks;
+CREATE TRIGGER tasks_change_seq_insert
+    BEFORE INSERT ON tasks
+    FOR EACH ROW EXECUTE FUNCTION task_change_seq();
+
+DROP TRIGGER IF EXISTS tasks_change_seq_update ON tasks;
+CREATE TRIGGER tasks_change_seq_update
+    BEFORE UPDATE ON tasks
+    FOR EACH ROW
+    WHEN ((OLD.title, OLD.description, OLD.workspace_id, OLD.project_id, OLD.assignee_id,
+           OLD.priority, OLD.category, OLD.status, OLD.position, OLD.is_completed,
+           OLD.due_date, OLD.version)
+          IS DISTINCT FROM
+          (NEW.title, NEW.description, NEW.workspace_id, NEW.project_id, NEW.assignee_id,
+           NEW.priority, NEW.category, NEW.status, NEW.position, NEW.is_completed,
+           NEW.due_date, NEW.version))
+    EXECUTE FUNCTION task_change_seq();
+
+DROP TRIGGER IF EXISTS tasks_tombstone ON tasks;
+CREATE TRIGGER tasks_tombstone
+    AFTER DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION task_tombstone();
+
+DROP TRIGGER IF EXISTS workspace_members_change ON workspace_members;
+CREATE TRIGGER workspace_members_change
+    AFTER INSERT OR DELETE ON workspace_members
+    FOR EACH ROW EXECUTE FUNCTION membership_change();
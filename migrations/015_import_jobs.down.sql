@@ -0,0 +1,4 @@
+-- migrations/015_import_jobs.down.sql
+
+DROP TABLE IF EXISTS import_errors;
+DROP TABLE IF EXISTS import_jobs;
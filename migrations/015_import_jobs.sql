@@ -0,0 +1,37 @@
+-- migrations/015_import_jobs.sql
+
+-- Background task imports. The uploaded file is kept in data until the job finishes;
+-- processed_rows is the resume point when a job whose lease expired is picked up
+-- again (e.g. after a restart).
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv', 'json', 'todoist', 'trello')),
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    data BYTEA,
+    mapping TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_id ON import_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queue ON import_jobs(id) WHERE status IN ('pending', 'running');
+
+-- Row-level errors, capped per job by the importer
+CREATE TABLE IF NOT EXISTS import_errors (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    message TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_errors_job_id ON import_errors(job_id, row_number);
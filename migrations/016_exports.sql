@@ -0,0 +1,29 @@
+-- migrations/016_exports.sql
+
+-- Background exports for accounts too large to stream in one request. The file is
+-- stored in 1 MB chunks so neither writing nor downloading holds it in memory, and
+-- is deleted together with its chunks once expires_at passes. Only the SHA-256 hash
+-- of the download token is stored.
+CREATE TABLE IF NOT EXISTS exports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('json', 'csv', 'markdown')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_exports_user_id ON exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_exports_expires_at ON exports(expires_at);
+
+CREATE TABLE IF NOT EXISTS export_chunks (
+    export_id INTEGER NOT NULL REFERENCES exports(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (export_id, seq)
+);
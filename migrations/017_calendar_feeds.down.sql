@@ -0,0 +1,9 @@
+-- migrations/017_calendar_feeds.down.sql
+
+-- ICS imports cannot exist without the format
+DELETE FROM import_jobs WHERE format = 'ics';
+ALTER TABLE import_jobs DROP CONSTRAINT IF EXISTS import_jobs_format_check;
+ALTER TABLE import_jobs ADD CONSTRAINT import_jobs_format_check
+    CHECK (format IN ('csv', 'json', 'todoist', 'trello'));
+
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,15 @@
+-- migrations/017_calendar_feeds.sql
+
+-- Secret iCalendar subscription URL, one per user. Only the SHA-256 hash of the
+-- token is stored; creating a new one replaces (and so revokes) the old URL.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_accessed_at TIMESTAMP
+);
+
+-- VTODO files can be imported as well
+ALTER TABLE import_jobs DROP CONSTRAINT IF EXISTS import_jobs_format_check;
+ALTER TABLE import_jobs ADD CONSTRAINT import_jobs_format_check
+    CHECK (format IN ('csv', 'json', 'todoist', 'trello', 'ics'));
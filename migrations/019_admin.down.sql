@@ -0,0 +1,4 @@
+-- migrations/019_admin.down.sql
+
+DROP TABLE IF EXISTS jwt_keys;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
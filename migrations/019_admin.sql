@@ -0,0 +1,15 @@
+-- migrations/019_admin.sql
+
+-- Disabled users cannot log in and their tokens and app passwords stop working
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP;
+
+-- JWT signing keys. The newest key without expires_at signs new tokens (its kid
+-- goes into the token header); older keys keep verifying tokens until they expire.
+-- The row with an empty kid and secret stands for JWT_SECRET from the environment:
+-- it only exists once the first rotation scheduled that key's retirement.
+CREATE TABLE IF NOT EXISTS jwt_keys (
+    kid VARCHAR(32) PRIMARY KEY,
+    secret VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP
+);
@@ -0,0 +1,11 @@
+// Package migrations berisi file SQL skema database. File-file ini ditanam ke
+// binary, jadi runner migrasi tidak bergantung pada working directory.
+package migrations
+
+import "embed"
+
+// FS - migrasi Postgres di root, migrasi SQLite di sqlite/. Tiap versi punya
+// NNN_nama.sql (up) dan NNN_nama.down.sql (down).
+//
+//go:embed *.sql sqlite/*.sql
+var FS embed.FS
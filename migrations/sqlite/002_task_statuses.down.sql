@@ -0,0 +1,7 @@
+-- migrations/sqlite/002_task_statuses.down.sql
+
+-- SQLite refuses to drop an indexed column, so the index goes first
+DROP INDEX IF EXISTS idx_tasks_status_position;
+ALTER TABLE tasks DROP COLUMN position;
+ALTER TABLE tasks DROP COLUMN status;
+DROP TABLE IF EXISTS task_statuses;
@@ -0,0 +1,37 @@
+-- migrations/sqlite/002_task_statuses.sql
+
+-- Workflow statuses (board columns), configurable per user
+CREATE TABLE IF NOT EXISTS task_statuses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_terminal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, key)
+);
+
+-- Default board for existing users
+INSERT INTO task_statuses (user_id, key, name, position, is_terminal)
+SELECT u.id, s.column1, s.column2, s.column3, s.column4
+FROM users u
+CROSS JOIN (VALUES
+    ('todo', 'To Do', 0, FALSE),
+    ('in_progress', 'In Progress', 1, FALSE),
+    ('review', 'Review', 2, FALSE),
+    ('done', 'Done', 3, TRUE)
+) AS s
+WHERE TRUE
+ON CONFLICT (user_id, key) DO NOTHING;
+
+-- Status and manual ordering on tasks.
+-- position is a fractional index and must sort byte-wise (SQLite's default BINARY collation).
+ALTER TABLE tasks ADD COLUMN status VARCHAR(50) NOT NULL DEFAULT 'todo';
+ALTER TABLE tasks ADD COLUMN position VARCHAR(255) NOT NULL DEFAULT '';
+
+UPDATE tasks SET status = 'done' WHERE is_completed = TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_task_statuses_user_id ON task_statuses(user_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_status_position ON tasks(user_id, status, position);
@@ -0,0 +1,28 @@
+-- migrations/sqlite/003_projects.down.sql
+
+-- task_statuses is rebuilt without project_id and with its per-user key constraint back
+DELETE FROM task_statuses WHERE project_id IS NOT NULL;
+
+CREATE TABLE task_statuses_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_terminal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, key)
+);
+
+INSERT INTO task_statuses_new (id, user_id, key, name, position, is_terminal, created_at, updated_at)
+SELECT id, user_id, key, name, position, is_terminal, created_at, updated_at FROM task_statuses;
+
+DROP TABLE task_statuses;
+ALTER TABLE task_statuses_new RENAME TO task_statuses;
+
+CREATE INDEX IF NOT EXISTS idx_task_statuses_user_id ON task_statuses(user_id);
+
+DROP INDEX IF EXISTS idx_tasks_project_id;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
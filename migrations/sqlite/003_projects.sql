@@ -0,0 +1,43 @@
+-- migrations/sqlite/003_projects.sql
+
+-- Projects group tasks
+CREATE TABLE IF NOT EXISTS projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '#6366f1',
+    is_archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+
+-- A project can have its own statuses; tasks in a project without any use the
+-- board's. Board statuses are the rows with project_id NULL. SQLite cannot drop
+-- the UNIQUE (user_id, key) constraint in place, so the table is rebuilt.
+CREATE TABLE task_statuses_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_terminal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE
+);
+
+INSERT INTO task_statuses_new (id, user_id, key, name, position, is_terminal, created_at, updated_at)
+SELECT id, user_id, key, name, position, is_terminal, created_at, updated_at FROM task_statuses;
+
+DROP TABLE task_statuses;
+ALTER TABLE task_statuses_new RENAME TO task_statuses;
+
+CREATE INDEX IF NOT EXISTS idx_task_statuses_user_id ON task_statuses(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_user_key ON task_statuses(user_id, key) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_project_key ON task_statuses(project_id, key);
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_id ON projects(user_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
@@ -0,0 +1,38 @@
+-- migrations/sqlite/004_workspaces.down.sql
+
+-- Workspace tasks stay with their creator as personal tasks; workspace boards and
+-- projects are removed
+DELETE FROM task_statuses WHERE user_id IS NULL;
+DELETE FROM projects WHERE workspace_id IS NOT NULL;
+
+-- The owner check refers to workspace_id, so task_statuses is rebuilt
+CREATE TABLE task_statuses_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_terminal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE
+);
+
+INSERT INTO task_statuses_new (id, user_id, key, name, position, is_terminal, created_at, updated_at, project_id)
+SELECT id, user_id, key, name, position, is_terminal, created_at, updated_at, project_id FROM task_statuses;
+
+DROP TABLE task_statuses;
+ALTER TABLE task_statuses_new RENAME TO task_statuses;
+
+CREATE INDEX IF NOT EXISTS idx_task_statuses_user_id ON task_statuses(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_user_key ON task_statuses(user_id, key) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_project_key ON task_statuses(project_id, key);
+
+DROP INDEX IF EXISTS idx_projects_workspace_id;
+ALTER TABLE projects DROP COLUMN workspace_id;
+DROP INDEX IF EXISTS idx_tasks_workspace_id;
+ALTER TABLE tasks DROP COLUMN workspace_id;
+
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
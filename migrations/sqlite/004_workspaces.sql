@@ -0,0 +1,68 @@
+-- migrations/sqlite/004_workspaces.sql
+
+-- Shared workspaces
+CREATE TABLE IF NOT EXISTS workspaces (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Invitations by email; only the SHA-256 hash of the token is stored
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'member', 'viewer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tasks, projects and board statuses can belong to a workspace.
+-- For workspace tasks user_id is the creator.
+ALTER TABLE tasks ADD COLUMN workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE projects ADD COLUMN workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE;
+
+-- Workspace boards have their own statuses (user_id NULL). SQLite cannot drop
+-- NOT NULL or add a constraint in place, so the table is rebuilt.
+CREATE TABLE task_statuses_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_terminal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    CONSTRAINT task_statuses_owner_check CHECK ((user_id IS NULL) <> (workspace_id IS NULL))
+);
+
+INSERT INTO task_statuses_new (id, user_id, key, name, position, is_terminal, created_at, updated_at, project_id)
+SELECT id, user_id, key, name, position, is_terminal, created_at, updated_at, project_id FROM task_statuses;
+
+DROP TABLE task_statuses;
+ALTER TABLE task_statuses_new RENAME TO task_statuses;
+
+CREATE INDEX IF NOT EXISTS idx_task_statuses_user_id ON task_statuses(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_user_key ON task_statuses(user_id, key) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_project_key ON task_statuses(project_id, key);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_statuses_workspace_key ON task_statuses(workspace_id, key) WHERE project_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_email ON workspace_invitations(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
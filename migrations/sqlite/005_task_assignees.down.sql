@@ -0,0 +1,4 @@
+-- migrations/sqlite/005_task_assignees.down.sql
+
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+ALTER TABLE tasks DROP COLUMN assignee_id;
@@ -0,0 +1,9 @@
+-- migrations/sqlite/007_task_reminders.down.sql
+
+DROP TABLE IF EXISTS notifications;
+DROP INDEX IF EXISTS idx_tasks_due_pending;
+ALTER TABLE tasks DROP COLUMN due_pending_channels;
+ALTER TABLE tasks DROP COLUMN due_next_attempt_at;
+ALTER TABLE tasks DROP COLUMN due_attempts;
+ALTER TABLE tasks DROP COLUMN due_notified_at;
+DROP TABLE IF EXISTS task_reminders;
@@ -0,0 +1,4 @@
+-- migrations/sqlite/009_webhooks.down.sql
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
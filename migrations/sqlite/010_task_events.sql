@@ -0,0 +1,28 @@
+-- migrations/sqlite/010_task_events.sql
+
+-- Short-lived log of task events for the real-time stream. With SQLite new rows
+-- are announced inside the API process only, and the id doubles as the SSE event
+-- id for Last-Event-ID resumption. Rows older than a day are pruned.
+CREATE TABLE IF NOT EXISTS task_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type VARCHAR(50) NOT NULL,
+    task_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_created_at ON task_events(created_at);
+
+-- Short-lived, single-use tickets for routes that browsers open without an
+-- Authorization header (EventSource, WebSocket). The ticket goes into the URL
+-- instead of the JWT; only its SHA-256 hash is stored and it is deleted when used.
+CREATE TABLE IF NOT EXISTS stream_tickets (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stream_tickets_expires_at ON stream_tickets(expires_at);
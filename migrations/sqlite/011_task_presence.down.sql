@@ -0,0 +1,3 @@
+-- migrations/sqlite/011_task_presence.down.sql
+
+DROP TABLE IF EXISTS task_presence;
@@ -0,0 +1,14 @@
+-- migrations/sqlite/011_task_presence.sql
+
+-- Who is currently viewing a task over the collaboration WebSocket. One row per
+-- connection and task; rows whose seen_at stops being refreshed by the heartbeat
+-- expire.
+CREATE TABLE IF NOT EXISTS task_presence (
+    connection_id VARCHAR(64) NOT NULL,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (connection_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_presence_task_id ON task_presence(task_id);
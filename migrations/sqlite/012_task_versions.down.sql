@@ -0,0 +1,3 @@
+-- migrations/sqlite/012_task_versions.down.sql
+
+ALTER TABLE tasks DROP COLUMN version;
@@ -0,0 +1,4 @@
+-- migrations/sqlite/012_task_versions.sql
+
+-- Optimistic concurrency: bumped on every edit, exposed as the task's ETag
+ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,19 @@
+-- migrations/sqlite/013_idempotency_keys.sql
+
+-- Idempotency-Key support for POST routes. The first request with a key claims the
+-- row (completed_at NULL while it runs); retries with the same fingerprint replay the
+-- stored response until expires_at.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INTEGER,
+    response_headers TEXT,
+    response_body BLOB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,13 @@
+-- migrations/sqlite/014_task_sync.down.sql
+
+DROP TRIGGER IF EXISTS workspace_members_leave;
+DROP TRIGGER IF EXISTS workspace_members_join;
+DROP TRIGGER IF EXISTS tasks_tombstone;
+DROP TRIGGER IF EXISTS tasks_change_seq_update;
+DROP TRIGGER IF EXISTS tasks_change_seq_insert;
+
+DROP TABLE IF EXISTS membership_changes;
+DROP TABLE IF EXISTS task_tombstones;
+DROP TABLE IF EXISTS sync_counter;
+DROP INDEX IF EXISTS idx_tasks_change_seq;
+ALTER TABLE tasks DROP COLUMN change_seq;
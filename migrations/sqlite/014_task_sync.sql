@@ -0,0 +1,102 @@
+-- migrations/sqlite/014_task_sync.sql
+
+-- Delta sync for offline clients. SQLite has no transaction ids, but it also has
+-- only one writer at a time, so a plain counter works: every change to a task takes
+-- the next value of sync_counter as its change_seq, and GET /sync hands out
+-- value + 1 as the token. A writer can never commit a value below a token that was
+-- already handed out.
+ALTER TABLE tasks ADD COLUMN change_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_change_seq ON tasks(change_seq);
+
+CREATE TABLE IF NOT EXISTS sync_counter (
+    value BIGINT NOT NULL
+);
+
+INSERT INTO sync_counter (value) SELECT 0 WHERE NOT EXISTS (SELECT 1 FROM sync_counter);
+
+-- No foreign keys: tombstones are written while users and workspaces are being
+-- deleted (cascade), and a tombstone nobody can see any more is harmless
+CREATE TABLE IF NOT EXISTS task_tombstones (
+    task_id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    workspace_id INTEGER,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tombstones_change_seq ON task_tombstones(change_seq);
+
+-- Joining or leaving a workspace changes which tasks a user can see without
+-- touching the tasks, so membership changes are numbered the same way: GET /sync
+-- sends a new member the workspace's existing tasks and reports them as deleted to
+-- a former member. No foreign keys, like task_tombstones.
+CREATE TABLE IF NOT EXISTS membership_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    workspace_id INTEGER NOT NULL,
+    joined BOOLEAN NOT NULL,
+    change_seq BIGINT NOT NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_membership_changes_user_seq ON membership_changes(user_id, change_seq);
+
+-- Triggers instead of application code so FK cascades (project deleted, assignee
+-- removed) and column rebalancing are synced too. Reminder bookkeeping
+-- (due_notified_at, due_soon_notified_at) is not a change clients care about.
+DROP TRIGGER IF EXISTS tasks_change_seq_insert;
+CREATE TRIGGER tasks_change_seq_insert
+    AFTER INSERT ON tasks
+    FOR EACH ROW
+BEGIN
+    UPDATE sync_counter SET value = value + 1;
+    UPDATE tasks SET change_seq = (SELECT value FROM sync_counter) WHERE id = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS tasks_change_seq_update;
+CREATE TRIGGER tasks_change_seq_update
+    AFTER UPDATE ON tasks
+    FOR EACH ROW
+    WHEN ((OLD.title, OLD.description, OLD.workspace_id, OLD.project_id, OLD.assignee_id,
+           OLD.priority, OLD.category, OLD.status, OLD.position, OLD.is_completed,
+           OLD.due_date, OLD.version)
+          IS NOT
+          (NEW.title, NEW.description, NEW.workspace_id, NEW.project_id, NEW.assignee_id,
+           NEW.priority, NEW.category, NEW.status, NEW.position, NEW.is_completed,
+           NEW.due_date, NEW.version))
+BEGIN
+    UPDATE sync_counter SET value = value + 1;
+    UPDATE tasks SET change_seq = (SELECT value FROM sync_counter) WHERE id = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS tasks_tombstone;
+CREATE TRIGGER tasks_tombstone
+    AFTER DELETE ON tasks
+    FOR EACH ROW
+BEGIN
+    UPDATE sync_counter SET value = value + 1;
+    INSERT INTO task_tombstones (task_id, user_id, workspace_id, change_seq)
+    VALUES (OLD.id, OLD.user_id, OLD.workspace_id, (SELECT value FROM sync_counter))
+    ON CONFLICT (task_id) DO NOTHING;
+END;
+
+DROP TRIGGER IF EXISTS workspace_members_join;
+CREATE TRIGGER workspace_members_join
+    AFTER INSERT ON workspace_members
+    FOR EACH ROW
+BEGIN
+    UPDATE sync_counter SET value = value + 1;
+    INSERT INTO membership_changes (user_id, workspace_id, joined, change_seq)
+    VALUES (NEW.user_id, NEW.workspace_id, TRUE, (SELECT value FROM sync_counter));
+END;
+
+DROP TRIGGER IF EXISTS workspace_members_leave;
+CREATE TRIGGER workspace_members_leave
+    AFTER DELETE ON workspace_members
+    FOR EACH ROW
+BEGIN
+    UPDATE sync_counter SET value = value + 1;
+    INSERT INTO membership_changes (user_id, workspace_id, joined, change_seq)
+    VALUES (OLD.user_id, OLD.workspace_id, FALSE, (SELECT value FROM sync_counter));
+END;
@@ -0,0 +1,4 @@
+-- migrations/sqlite/016_exports.down.sql
+
+DROP TABLE IF EXISTS export_chunks;
+DROP TABLE IF EXISTS exports;
@@ -0,0 +1,46 @@
+-- migrations/sqlite/017_calendar_feeds.down.sql
+
+-- ICS imports cannot exist without the format. import_jobs and import_errors are
+-- rebuilt the same way as in the up migration.
+DELETE FROM import_jobs WHERE format = 'ics';
+
+CREATE TABLE import_jobs_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CONSTRAINT import_jobs_format_check CHECK (format IN ('csv', 'json', 'todoist', 'trello')),
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    data BLOB,
+    mapping TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE import_errors_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES import_jobs_new(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    message TEXT NOT NULL
+);
+
+INSERT INTO import_jobs_new SELECT * FROM import_jobs;
+INSERT INTO import_errors_new SELECT * FROM import_errors;
+
+DROP TABLE import_errors;
+DROP TABLE import_jobs;
+ALTER TABLE import_jobs_new RENAME TO import_jobs;
+ALTER TABLE import_errors_new RENAME TO import_errors;
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_id ON import_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queue ON import_jobs(id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_import_errors_job_id ON import_errors(job_id, row_number);
+
+DROP TABLE IF EXISTS calendar_feeds;
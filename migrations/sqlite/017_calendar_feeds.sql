@@ -0,0 +1,55 @@
+-- migrations/sqlite/017_calendar_feeds.sql
+
+-- Secret iCalendar subscription URL, one per user. Only the SHA-256 hash of the
+-- token is stored; creating a new one replaces (and so revokes) the old URL.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_accessed_at TIMESTAMP
+);
+
+-- VTODO files can be imported as well. SQLite cannot change a CHECK constraint in
+-- place, so import_jobs is rebuilt. Foreign keys are on inside the migration
+-- transaction, so import_errors is rebuilt with it: dropping import_jobs while
+-- import_errors still points at it would cascade, and a rename would rewrite the
+-- reference to the old table.
+
+CREATE TABLE import_jobs_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id INTEGER REFERENCES workspaces(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CONSTRAINT import_jobs_format_check CHECK (format IN ('csv', 'json', 'todoist', 'trello', 'ics')),
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    data BLOB,
+    mapping TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE import_errors_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL REFERENCES import_jobs_new(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    message TEXT NOT NULL
+);
+
+INSERT INTO import_jobs_new SELECT * FROM import_jobs;
+INSERT INTO import_errors_new SELECT * FROM import_errors;
+
+DROP TABLE import_errors;
+DROP TABLE import_jobs;
+ALTER TABLE import_jobs_new RENAME TO import_jobs;
+ALTER TABLE import_errors_new RENAME TO import_errors;
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_id ON import_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queue ON import_jobs(id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_import_errors_job_id ON import_errors(job_id, row_number);
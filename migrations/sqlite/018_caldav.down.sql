@@ -0,0 +1,4 @@
+-- migrations/sqlite/018_caldav.down.sql
+
+DROP TABLE IF EXISTS caldav_objects;
+DROP TABLE IF EXISTS app_passwords;
@@ -0,0 +1,25 @@
+-- migrations/sqlite/018_caldav.sql
+
+-- App passwords for clients that can only do HTTP Basic auth (CalDAV). Passwords
+-- are random, so a SHA-256 hash is enough; each one can be revoked on its own.
+CREATE TABLE IF NOT EXISTS app_passwords (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    password_hash VARCHAR(64) UNIQUE NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_passwords_user_id ON app_passwords(user_id);
+
+-- Resource name and UID a CalDAV client chose when it created a task with PUT.
+-- Tasks created elsewhere are served as task-<id>.ics. No foreign key: the row
+-- outlives the task so sync-collection can still report the deleted href.
+CREATE TABLE IF NOT EXISTS caldav_objects (
+    task_id INTEGER PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    uid VARCHAR(255) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_caldav_objects_name ON caldav_objects(name);
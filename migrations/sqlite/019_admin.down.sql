@@ -0,0 +1,4 @@
+-- migrations/sqlite/019_admin.down.sql
+
+DROP TABLE IF EXISTS jwt_keys;
+ALTER TABLE users DROP COLUMN disabled_at;